@@ -1,3 +1,3 @@
 module github.com/ryandgoulding/godatastructures
 
-go 1.14
+go 1.23
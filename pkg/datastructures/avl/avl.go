@@ -0,0 +1,331 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avl
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrCorrupt is wrapped by the errors returned from Verify.
+var ErrCorrupt = errors.New("avl: corrupt")
+
+// A Tree is an AVL tree mapping keys to values.  Insert, Delete and Search are O(log n) in the worst case.
+type Tree[K, V any] struct {
+	root    *node[K, V]
+	size    int
+	compare func(a, b K) int
+}
+
+// node is a node of the Tree.
+type node[K, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	height      int // The height of the subtree rooted at the node; a leaf has height 1.
+}
+
+// New returns an empty Tree with keys in ascending order.
+func New[K cmp.Ordered, V any]() *Tree[K, V] {
+	return NewFunc[K, V](cmp.Compare[K])
+}
+
+// NewFunc returns an empty Tree with keys ordered by compare, which returns a negative number when a sorts before b, a
+// positive number when a sorts after b and zero when they are equal, as in slices.SortFunc.
+func NewFunc[K, V any](compare func(a, b K) int) *Tree[K, V] {
+	return &Tree[K, V]{compare: compare}
+}
+
+// Len returns the number of keys in the Tree.
+func (t *Tree[K, V]) Len() int {
+	return t.size
+}
+
+// Height returns the number of nodes on the longest path from the root to a leaf in O(1), which is 0 for an empty Tree.
+func (t *Tree[K, V]) Height() int {
+	return t.root.depth()
+}
+
+// Insert sets the value of key, adding key if it is not already in the Tree.  It returns true if key was added.
+func (t *Tree[K, V]) Insert(key K, value V) bool {
+	var added bool
+	t.root = t.insert(t.root, key, value, &added)
+	if added {
+		t.size++
+	}
+	return added
+}
+
+// Delete removes key from the Tree.  It returns false if key was not in the Tree.
+func (t *Tree[K, V]) Delete(key K) bool {
+	var removed bool
+	t.root = t.delete(t.root, key, &removed)
+	if removed {
+		t.size--
+	}
+	return removed
+}
+
+// Search returns the value of key.  The second return value is false if key is not in the Tree.
+func (t *Tree[K, V]) Search(key K) (V, bool) {
+	n := t.root
+	for n != nil {
+		c := t.compare(key, n.key)
+		if c == 0 {
+			return n.value, true
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Min returns the smallest key in the Tree and its value.  The third return value is false if the Tree is empty.
+func (t *Tree[K, V]) Min() (K, V, bool) {
+	return t.root.min().get()
+}
+
+// Max returns the largest key in the Tree and its value.  The third return value is false if the Tree is empty.
+func (t *Tree[K, V]) Max() (K, V, bool) {
+	n := t.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n.get()
+}
+
+// Successor returns the smallest key in the Tree that sorts after key, and its value.  key need not be in the Tree.  The
+// third return value is false if there is no such key.
+func (t *Tree[K, V]) Successor(key K) (K, V, bool) {
+	var successor *node[K, V]
+	for n := t.root; n != nil; {
+		if t.compare(key, n.key) < 0 {
+			successor, n = n, n.left
+		} else {
+			n = n.right
+		}
+	}
+	return successor.get()
+}
+
+// Predecessor returns the largest key in the Tree that sorts before key, and its value.  key need not be in the Tree.
+// The third return value is false if there is no such key.
+func (t *Tree[K, V]) Predecessor(key K) (K, V, bool) {
+	var predecessor *node[K, V]
+	for n := t.root; n != nil; {
+		if t.compare(key, n.key) > 0 {
+			predecessor, n = n, n.right
+		} else {
+			n = n.left
+		}
+	}
+	return predecessor.get()
+}
+
+// InOrder returns an iterator over the keys and values of the Tree in ascending order of key.  The Tree must not be
+// modified during iteration.
+func (t *Tree[K, V]) InOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.inOrder(yield)
+	}
+}
+
+// PreOrder returns an iterator over the keys and values of the Tree, visiting each node before its left and then its
+// right subtree.  The Tree must not be modified during iteration.
+func (t *Tree[K, V]) PreOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.preOrder(yield)
+	}
+}
+
+// PostOrder returns an iterator over the keys and values of the Tree, visiting each node after its left and then its
+// right subtree.  The Tree must not be modified during iteration.
+func (t *Tree[K, V]) PostOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.postOrder(yield)
+	}
+}
+
+// Verify checks in O(n) that the keys are in order, that every node records its true height and that every node is
+// balanced.  It returns nil if the Tree is sound, and otherwise an error wrapping ErrCorrupt that describes the first
+// problem found.
+func (t *Tree[K, V]) Verify() error {
+	size, err := t.verify(t.root, nil, nil)
+	if err != nil {
+		return err
+	}
+	if size != t.size {
+		return fmt.Errorf("%w: Tree has %d nodes but records %d", ErrCorrupt, size, t.size)
+	}
+	return nil
+}
+
+// verify checks the subtree rooted at n, whose keys must sort after lower and before upper where those are not nil, and
+// returns its size.
+func (t *Tree[K, V]) verify(n, lower, upper *node[K, V]) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+	if lower != nil && t.compare(n.key, lower.key) <= 0 || upper != nil && t.compare(n.key, upper.key) >= 0 {
+		return 0, fmt.Errorf("%w: key %v is out of order", ErrCorrupt, n.key)
+	}
+	left, err := t.verify(n.left, lower, n)
+	if err != nil {
+		return 0, err
+	}
+	right, err := t.verify(n.right, n, upper)
+	if err != nil {
+		return 0, err
+	}
+	if height := max(n.left.depth(), n.right.depth()) + 1; n.height != height {
+		return 0, fmt.Errorf("%w: key %v records height %d but has height %d", ErrCorrupt, n.key, n.height, height)
+	}
+	if skew := n.left.depth() - n.right.depth(); skew < -1 || skew > 1 {
+		return 0, fmt.Errorf("%w: key %v has balance factor %d", ErrCorrupt, n.key, skew)
+	}
+	return left + right + 1, nil
+}
+
+// insert sets key to value in the subtree rooted at n and returns its new, rebalanced root.
+func (t *Tree[K, V]) insert(n *node[K, V], key K, value V, added *bool) *node[K, V] {
+	if n == nil {
+		*added = true
+		return &node[K, V]{key: key, value: value, height: 1}
+	}
+	c := t.compare(key, n.key)
+	switch {
+	case c < 0:
+		n.left = t.insert(n.left, key, value, added)
+	case c > 0:
+		n.right = t.insert(n.right, key, value, added)
+	default:
+		n.value = value
+		return n
+	}
+	return n.balance()
+}
+
+// delete removes key from the subtree rooted at n and returns its new, rebalanced root.
+func (t *Tree[K, V]) delete(n *node[K, V], key K, removed *bool) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := t.compare(key, n.key)
+	switch {
+	case c < 0:
+		n.left = t.delete(n.left, key, removed)
+	case c > 0:
+		n.right = t.delete(n.right, key, removed)
+	default:
+		*removed = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		successor := n.right.min()
+		n.key, n.value = successor.key, successor.value
+		n.right = t.delete(n.right, successor.key, new(bool))
+	}
+	return n.balance()
+}
+
+// get returns the key and value of n, or false if n is nil.
+func (n *node[K, V]) get() (K, V, bool) {
+	if n == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
+
+// min returns the leftmost node of the subtree rooted at n, or nil if n is nil.
+func (n *node[K, V]) min() *node[K, V] {
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// depth returns the height of the subtree rooted at n, which may be nil.
+func (n *node[K, V]) depth() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// balance recomputes the height of n and restores the AVL invariant at n, whose children are balanced and differ in
+// height by at most 2.  It returns the new root of the subtree.
+func (n *node[K, V]) balance() *node[K, V] {
+	n.update()
+	switch skew := n.left.depth() - n.right.depth(); {
+	case skew > 1:
+		if n.left.left.depth() < n.left.right.depth() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case skew < -1:
+		if n.right.right.depth() < n.right.left.depth() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+// update recomputes the height of n from its children.
+func (n *node[K, V]) update() {
+	n.height = max(n.left.depth(), n.right.depth()) + 1
+}
+
+// rotateLeft rotates the subtree rooted at n to the left and returns its new root.
+func (n *node[K, V]) rotateLeft() *node[K, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.update()
+	r.update()
+	return r
+}
+
+// rotateRight rotates the subtree rooted at n to the right and returns its new root.
+func (n *node[K, V]) rotateRight() *node[K, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.update()
+	l.update()
+	return l
+}
+
+// inOrder yields the subtree rooted at n in order.  It returns false if yield asked to stop.
+func (n *node[K, V]) inOrder(yield func(K, V) bool) bool {
+	return n == nil || n.left.inOrder(yield) && yield(n.key, n.value) && n.right.inOrder(yield)
+}
+
+// preOrder yields the subtree rooted at n in pre-order.  It returns false if yield asked to stop.
+func (n *node[K, V]) preOrder(yield func(K, V) bool) bool {
+	return n == nil || yield(n.key, n.value) && n.left.preOrder(yield) && n.right.preOrder(yield)
+}
+
+// postOrder yields the subtree rooted at n in post-order.  It returns false if yield asked to stop.
+func (n *node[K, V]) postOrder(yield func(K, V) bool) bool {
+	return n == nil || n.left.postOrder(yield) && n.right.postOrder(yield) && yield(n.key, n.value)
+}
@@ -0,0 +1,122 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avl_test
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/avl"
+)
+
+func TestTree(t *testing.T) {
+	tree := avl.New[int, string]()
+	for i := 1; i <= 7; i++ {
+		tree.Insert(i, string(rune('a'+i-1)))
+	}
+	// Sorted insertions would degenerate a BST into a list of height 7.
+	if tree.Height() != 3 {
+		t.Fatalf("Expected: %d Actual: %d", 3, tree.Height())
+	}
+	if expected, actual := []int{4, 2, 1, 3, 6, 5, 7}, keys(tree.PreOrder()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if expected, actual := []int{1, 3, 2, 5, 7, 6, 4}, keys(tree.PostOrder()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if tree.Insert(3, "three") {
+		t.Fatalf("Expected Insert of an existing key to replace its value")
+	}
+	if value, ok := tree.Search(3); !ok || value != "three" {
+		t.Fatalf("Expected: %s Actual: %s", "three", value)
+	}
+	if key, _, _ := tree.Min(); key != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, key)
+	}
+	if key, value, _ := tree.Max(); key != 7 || value != "g" {
+		t.Fatalf("Expected: %d Actual: %d", 7, key)
+	}
+	if key, _, ok := tree.Successor(4); !ok || key != 5 {
+		t.Fatalf("Expected: %d Actual: %d", 5, key)
+	}
+	if key, _, ok := tree.Predecessor(4); !ok || key != 3 {
+		t.Fatalf("Expected: %d Actual: %d", 3, key)
+	}
+	if !tree.Delete(4) || tree.Delete(4) || tree.Len() != 6 {
+		t.Fatalf("Expected to delete %d once", 4)
+	}
+	if err := tree.Verify(); err != nil {
+		t.Fatalf("Expected no error Actual: %s", err)
+	}
+}
+
+func TestTree_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := avl.New[int, int]()
+	reference := map[int]int{}
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(1000)
+		if r.Intn(3) == 0 {
+			_, ok := reference[key]
+			if tree.Delete(key) != ok {
+				t.Fatalf("Delete(%d) disagreed with the reference", key)
+			}
+			delete(reference, key)
+		} else {
+			tree.Insert(key, i)
+			reference[key] = i
+		}
+		if i%100 == 0 {
+			if err := tree.Verify(); err != nil {
+				t.Fatalf("Expected no error Actual: %s", err)
+			}
+		}
+	}
+	if tree.Len() != len(reference) {
+		t.Fatalf("Expected: %d Actual: %d", len(reference), tree.Len())
+	}
+	for key, value := range tree.InOrder() {
+		if reference[key] != value {
+			t.Fatalf("Expected: %d Actual: %d", reference[key], value)
+		}
+	}
+}
+
+func TestTree_Verify(t *testing.T) {
+	reversed := false
+	tree := avl.NewFunc[int, int](func(a, b int) int {
+		if reversed {
+			return cmp.Compare(b, a)
+		}
+		return cmp.Compare(a, b)
+	})
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+	reversed = true
+	if err := tree.Verify(); !errors.Is(err, avl.ErrCorrupt) {
+		t.Fatalf("Expected: %s Actual: %v", avl.ErrCorrupt, err)
+	}
+}
+
+// keys collects the keys of seq.
+func keys(seq func(func(int, string) bool)) []int {
+	var keys []int
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
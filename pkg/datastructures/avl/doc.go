@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package avl implements a generic AVL Tree that maps ordered keys to values.  It has the same API as the unbalanced
+bst.Tree, but rebalances after every Insert and Delete so that the heights of the two subtrees of any node differ by at
+most one, which bounds the height of the Tree by about 1.44 log2 n.
+*/
+
+package avl
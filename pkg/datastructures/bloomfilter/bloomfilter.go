@@ -0,0 +1,178 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bloomfilter
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// binaryVersion is the version of the encoding produced by MarshalBinary, written as its first byte.
+const binaryVersion = 1
+
+// ErrIncompatible is returned by Union when the Filters differ in size or number of hashes.
+var ErrIncompatible = errors.New("bloomfilter: incompatible filters")
+
+// A Filter is a Bloom filter:  an array of m bits, of which each added item sets k, chosen by hashing the item.  An item
+// may be in the Filter only if all k of its bits are set.
+type Filter struct {
+	bits []uint64
+	m    uint64 // The number of bits.
+	k    uint64 // The number of hashes.
+}
+
+// New returns an empty Filter sized to hold n items with a false positive rate of at most p, using the optimal number of
+// bits, m = -n ln p / (ln 2)^2, and of hashes, k = (m / n) ln 2.  It panics if n is not positive or p is not strictly
+// between 0 and 1.
+func New(n int, p float64) *Filter {
+	if n <= 0 {
+		panic("bloomfilter: n must be positive")
+	}
+	if !(p > 0 && p < 1) {
+		panic("bloomfilter: p must be between 0 and 1")
+	}
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/float64(n)*math.Ln2))
+	return NewWithSize(uint64(m), uint64(k))
+}
+
+// NewWithSize returns an empty Filter of m bits that sets k bits per item.  It panics if m or k is zero.
+func NewWithSize(m, k uint64) *Filter {
+	if m == 0 || k == 0 {
+		panic("bloomfilter: m and k must be positive")
+	}
+	return &Filter{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+// Bits returns the number of bits in the Filter.
+func (f *Filter) Bits() uint64 {
+	return f.m
+}
+
+// Hashes returns the number of bits set for each item.
+func (f *Filter) Hashes() uint64 {
+	return f.k
+}
+
+// Add adds item to the Filter.
+func (f *Filter) Add(item []byte) {
+	h1, h2 := hash(item)
+	for i := uint64(0); i < f.k; i++ {
+		bit := (h1 + i*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// AddString adds item to the Filter.
+func (f *Filter) AddString(item string) {
+	f.Add([]byte(item))
+}
+
+// MayContain reports whether item may be in the Filter.  False means item was certainly never added, while true may be
+// a false positive.
+func (f *Filter) MayContain(item []byte) bool {
+	h1, h2 := hash(item)
+	for i := uint64(0); i < f.k; i++ {
+		bit := (h1 + i*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// MayContainString reports whether item may be in the Filter.
+func (f *Filter) MayContainString(item string) bool {
+	return f.MayContain([]byte(item))
+}
+
+// EstimatedFalsePositiveRate returns the probability that MayContain returns true for an item that was never added,
+// estimated from the fraction of bits set as that fraction raised to the power k.
+func (f *Filter) EstimatedFalsePositiveRate() float64 {
+	set := 0
+	for _, word := range f.bits {
+		set += bits.OnesCount64(word)
+	}
+	return math.Pow(float64(set)/float64(f.m), float64(f.k))
+}
+
+// Union adds every item in other to the Filter, so that it may contain an item if either Filter may.  It returns
+// ErrIncompatible if the Filters differ in size or number of hashes.
+func (f *Filter) Union(other *Filter) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrIncompatible
+	}
+	for i, word := range other.bits {
+		f.bits[i] |= word
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The encoding holds m and k as varints followed by the bits as
+// little-endian 64-bit words.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 1+2*binary.MaxVarintLen64+8*len(f.bits))
+	b = append(b, binaryVersion)
+	b = binary.AppendUvarint(b, f.m)
+	b = binary.AppendUvarint(b, f.k)
+	for _, word := range f.bits {
+		b = binary.LittleEndian.AppendUint64(b, word)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler by replacing the Filter with the one encoded in data by
+// MarshalBinary.  The Filter is left unchanged if data cannot be decoded.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return errors.New("bloomfilter: unsupported binary encoding")
+	}
+	corrupt := errors.New("bloomfilter: corrupt binary encoding")
+	data = data[1:]
+	m, i := binary.Uvarint(data)
+	if i <= 0 || m == 0 {
+		return corrupt
+	}
+	data = data[i:]
+	k, i := binary.Uvarint(data)
+	if i <= 0 || k == 0 {
+		return corrupt
+	}
+	data = data[i:]
+	// m is bounded by the bits present before the words it needs are counted, so that a huge m cannot overflow.
+	words := uint64(len(data)) / 8
+	if uint64(len(data))%8 != 0 || m > 64*words || (m+63)/64 != words {
+		return corrupt
+	}
+	b := make([]uint64, words)
+	for i := range b {
+		b[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	f.bits, f.m, f.k = b, m, k
+	return nil
+}
+
+// hash returns two independent 64-bit hashes of item, the halves of its 128-bit FNV-1a hash, which are combined as
+// h1 + i*h2 to choose the k bits of item.
+func hash(item []byte) (uint64, uint64) {
+	h := fnv.New128a()
+	h.Write(item)
+	var sum [16]byte
+	h.Sum(sum[:0])
+	h1 := binary.BigEndian.Uint64(sum[:8])
+	h2 := binary.BigEndian.Uint64(sum[8:])
+	return h1, h2 | 1 // an odd step never cycles early when m is a power of two
+}
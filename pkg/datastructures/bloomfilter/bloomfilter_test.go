@@ -0,0 +1,103 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bloomfilter_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/bloomfilter"
+)
+
+func TestFilter(t *testing.T) {
+	f := bloomfilter.New(1000, 0.01)
+	if f.Bits() != 9586 || f.Hashes() != 7 {
+		t.Fatalf("Expected: %d bits and %d hashes Actual: %d bits and %d hashes", 9586, 7, f.Bits(), f.Hashes())
+	}
+	for i := 0; i < 1000; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	for i := 0; i < 1000; i++ {
+		if !f.MayContainString(strconv.Itoa(i)) {
+			t.Fatalf("Expected %d to be in the Filter", i)
+		}
+	}
+	falsePositives := 0
+	for i := 1000; i < 11000; i++ {
+		if f.MayContainString(strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	// The false positive rate should be about 1%, so 200 in 10000 is well beyond chance.
+	if falsePositives > 200 {
+		t.Fatalf("Expected about %d false positives Actual: %d", 100, falsePositives)
+	}
+	if rate := f.EstimatedFalsePositiveRate(); rate < 0.005 || rate > 0.02 {
+		t.Fatalf("Expected: %f Actual: %f", 0.01, rate)
+	}
+}
+
+func TestFilter_Union(t *testing.T) {
+	a := bloomfilter.NewWithSize(1024, 3)
+	b := bloomfilter.NewWithSize(1024, 3)
+	a.AddString("apple")
+	b.AddString("banana")
+	if err := a.Union(b); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !a.MayContainString("apple") || !a.MayContainString("banana") {
+		t.Fatalf("Expected the union to contain both items")
+	}
+	if err := a.Union(bloomfilter.NewWithSize(1024, 4)); !errors.Is(err, bloomfilter.ErrIncompatible) {
+		t.Fatalf("Expected: %s Actual: %v", bloomfilter.ErrIncompatible, err)
+	}
+}
+
+func TestFilter_Binary(t *testing.T) {
+	f := bloomfilter.New(100, 0.001)
+	f.AddString("apple")
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling: %s", err)
+	}
+	var restored bloomfilter.Filter
+	if err := restored.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Unexpected error unmarshaling: %s", err)
+	}
+	if restored.Bits() != f.Bits() || restored.Hashes() != f.Hashes() || !restored.MayContainString("apple") {
+		t.Fatalf("Expected the restored Filter to match the original")
+	}
+	if restored.MayContainString("banana") {
+		t.Fatalf("Expected %s not to be in the restored Filter", "banana")
+	}
+	huge := []byte{1, 255, 255, 255, 255, 255, 255, 255, 255, 255, 1, 3} // m = 2^64-1 with no bits
+	for _, corrupt := range [][]byte{nil, {2}, b[:len(b)-1], append(b, 0, 0, 0, 0, 0, 0, 0, 0), {1, 0, 1}, huge} {
+		if err := restored.UnmarshalBinary(corrupt); err == nil {
+			t.Fatalf("Expected an error unmarshaling %v", corrupt)
+		}
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	for _, p := range []float64{0, 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Expected a panic for p %f", p)
+				}
+			}()
+			bloomfilter.New(10, p)
+		}()
+	}
+}
@@ -0,0 +1,231 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bst
+
+import (
+	"cmp"
+	"iter"
+)
+
+// A Tree is a binary search tree mapping keys to values.  Every key in the left subtree of a node sorts before the key
+// of the node, and every key in the right subtree sorts after it.
+type Tree[K, V any] struct {
+	root    *node[K, V]
+	size    int
+	compare func(a, b K) int
+}
+
+// node is a node of the Tree.
+type node[K, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+}
+
+// New returns an empty Tree with keys in ascending order.
+func New[K cmp.Ordered, V any]() *Tree[K, V] {
+	return NewFunc[K, V](cmp.Compare[K])
+}
+
+// NewFunc returns an empty Tree with keys ordered by compare, which returns a negative number when a sorts before b, a
+// positive number when a sorts after b and zero when they are equal, as in slices.SortFunc.
+func NewFunc[K, V any](compare func(a, b K) int) *Tree[K, V] {
+	return &Tree[K, V]{compare: compare}
+}
+
+// Len returns the number of keys in the Tree.
+func (t *Tree[K, V]) Len() int {
+	return t.size
+}
+
+// Height returns the number of nodes on the longest path from the root to a leaf, which is 0 for an empty Tree.
+func (t *Tree[K, V]) Height() int {
+	return t.root.height()
+}
+
+// Insert sets the value of key, adding key if it is not already in the Tree.  It returns true if key was added.
+func (t *Tree[K, V]) Insert(key K, value V) bool {
+	link := &t.root
+	for *link != nil {
+		c := t.compare(key, (*link).key)
+		switch {
+		case c < 0:
+			link = &(*link).left
+		case c > 0:
+			link = &(*link).right
+		default:
+			(*link).value = value
+			return false
+		}
+	}
+	*link = &node[K, V]{key: key, value: value}
+	t.size++
+	return true
+}
+
+// Delete removes key from the Tree.  It returns false if key was not in the Tree.
+func (t *Tree[K, V]) Delete(key K) bool {
+	link := t.find(key)
+	n := *link
+	if n == nil {
+		return false
+	}
+	switch {
+	case n.left == nil:
+		*link = n.right
+	case n.right == nil:
+		*link = n.left
+	default:
+		// Replace n with its successor, the leftmost node of its right subtree, which has no left child.
+		successor := &n.right
+		for (*successor).left != nil {
+			successor = &(*successor).left
+		}
+		s := *successor
+		*successor = s.right
+		s.left, s.right = n.left, n.right
+		*link = s
+	}
+	t.size--
+	return true
+}
+
+// Search returns the value of key.  The second return value is false if key is not in the Tree.
+func (t *Tree[K, V]) Search(key K) (V, bool) {
+	if n := *t.find(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Min returns the smallest key in the Tree and its value.  The third return value is false if the Tree is empty.
+func (t *Tree[K, V]) Min() (K, V, bool) {
+	n := t.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n.get()
+}
+
+// Max returns the largest key in the Tree and its value.  The third return value is false if the Tree is empty.
+func (t *Tree[K, V]) Max() (K, V, bool) {
+	n := t.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n.get()
+}
+
+// Successor returns the smallest key in the Tree that sorts after key, and its value.  key need not be in the Tree.  The
+// third return value is false if there is no such key.
+func (t *Tree[K, V]) Successor(key K) (K, V, bool) {
+	var successor *node[K, V]
+	for n := t.root; n != nil; {
+		if t.compare(key, n.key) < 0 {
+			successor, n = n, n.left
+		} else {
+			n = n.right
+		}
+	}
+	return successor.get()
+}
+
+// Predecessor returns the largest key in the Tree that sorts before key, and its value.  key need not be in the Tree.
+// The third return value is false if there is no such key.
+func (t *Tree[K, V]) Predecessor(key K) (K, V, bool) {
+	var predecessor *node[K, V]
+	for n := t.root; n != nil; {
+		if t.compare(key, n.key) > 0 {
+			predecessor, n = n, n.right
+		} else {
+			n = n.left
+		}
+	}
+	return predecessor.get()
+}
+
+// InOrder returns an iterator over the keys and values of the Tree in ascending order of key.  The Tree must not be
+// modified during iteration.
+func (t *Tree[K, V]) InOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.inOrder(yield)
+	}
+}
+
+// PreOrder returns an iterator over the keys and values of the Tree, visiting each node before its left and then its
+// right subtree.  Inserting the keys into an empty Tree in this order rebuilds the same shape.  The Tree must not be
+// modified during iteration.
+func (t *Tree[K, V]) PreOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.preOrder(yield)
+	}
+}
+
+// PostOrder returns an iterator over the keys and values of the Tree, visiting each node after its left and then its
+// right subtree.  The Tree must not be modified during iteration.
+func (t *Tree[K, V]) PostOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.postOrder(yield)
+	}
+}
+
+// find returns the link that points to the node with key, or the nil link where such a node would be inserted.
+func (t *Tree[K, V]) find(key K) **node[K, V] {
+	link := &t.root
+	for *link != nil {
+		c := t.compare(key, (*link).key)
+		if c == 0 {
+			break
+		}
+		if c < 0 {
+			link = &(*link).left
+		} else {
+			link = &(*link).right
+		}
+	}
+	return link
+}
+
+// get returns the key and value of n, or false if n is nil.
+func (n *node[K, V]) get() (K, V, bool) {
+	if n == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
+
+// height returns the height of the subtree rooted at n, which may be nil.
+func (n *node[K, V]) height() int {
+	if n == nil {
+		return 0
+	}
+	return max(n.left.height(), n.right.height()) + 1
+}
+
+// inOrder yields the subtree rooted at n in order.  It returns false if yield asked to stop.
+func (n *node[K, V]) inOrder(yield func(K, V) bool) bool {
+	return n == nil || n.left.inOrder(yield) && yield(n.key, n.value) && n.right.inOrder(yield)
+}
+
+// preOrder yields the subtree rooted at n in pre-order.  It returns false if yield asked to stop.
+func (n *node[K, V]) preOrder(yield func(K, V) bool) bool {
+	return n == nil || yield(n.key, n.value) && n.left.preOrder(yield) && n.right.preOrder(yield)
+}
+
+// postOrder yields the subtree rooted at n in post-order.  It returns false if yield asked to stop.
+func (n *node[K, V]) postOrder(yield func(K, V) bool) bool {
+	return n == nil || n.left.postOrder(yield) && n.right.postOrder(yield) && yield(n.key, n.value)
+}
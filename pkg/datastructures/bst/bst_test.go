@@ -0,0 +1,110 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bst_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/bst"
+)
+
+// newTree returns a Tree holding keys, inserted in order, each mapped to twice its value.
+func newTree(keys ...int) *bst.Tree[int, int] {
+	t := bst.New[int, int]()
+	for _, key := range keys {
+		t.Insert(key, 2*key)
+	}
+	return t
+}
+
+func TestTree(t *testing.T) {
+	tree := newTree(50, 30, 70, 20, 40, 60, 80)
+	if tree.Insert(40, 0) || tree.Len() != 7 {
+		t.Fatalf("Expected Insert of an existing key to replace its value")
+	}
+	if value, ok := tree.Search(40); !ok || value != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, value)
+	}
+	if _, ok := tree.Search(45); ok {
+		t.Fatalf("Expected %d to be absent", 45)
+	}
+	if key, _, _ := tree.Min(); key != 20 {
+		t.Fatalf("Expected: %d Actual: %d", 20, key)
+	}
+	if key, _, _ := tree.Max(); key != 80 {
+		t.Fatalf("Expected: %d Actual: %d", 80, key)
+	}
+	if key, _, ok := tree.Successor(40); !ok || key != 50 {
+		t.Fatalf("Expected: %d Actual: %d", 50, key)
+	}
+	if key, _, ok := tree.Successor(55); !ok || key != 60 {
+		t.Fatalf("Expected: %d Actual: %d", 60, key)
+	}
+	if _, _, ok := tree.Successor(80); ok {
+		t.Fatalf("Expected no Successor of the Max")
+	}
+	if key, _, ok := tree.Predecessor(60); !ok || key != 50 {
+		t.Fatalf("Expected: %d Actual: %d", 50, key)
+	}
+	if _, _, ok := tree.Predecessor(20); ok {
+		t.Fatalf("Expected no Predecessor of the Min")
+	}
+	if tree.Height() != 3 {
+		t.Fatalf("Expected: %d Actual: %d", 3, tree.Height())
+	}
+}
+
+func TestTree_Traversals(t *testing.T) {
+	tree := newTree(50, 30, 70, 20, 40, 60, 80)
+	collect := func(seq func(func(int, int) bool)) []int {
+		var keys []int
+		for key := range seq {
+			keys = append(keys, key)
+		}
+		return keys
+	}
+	if expected, actual := []int{20, 30, 40, 50, 60, 70, 80}, collect(tree.InOrder()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if expected, actual := []int{50, 30, 20, 40, 70, 60, 80}, collect(tree.PreOrder()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if expected, actual := []int{20, 40, 30, 60, 80, 70, 50}, collect(tree.PostOrder()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
+
+func TestTree_Delete(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	keys := r.Perm(200)
+	tree := newTree(keys...)
+	for _, key := range keys[:100] {
+		if !tree.Delete(key) || tree.Delete(key) {
+			t.Fatalf("Expected to delete %d once", key)
+		}
+	}
+	expected := slices.Clone(keys[100:])
+	slices.Sort(expected)
+	var actual []int
+	for key, value := range tree.InOrder() {
+		if value != 2*key {
+			t.Fatalf("Expected: %d Actual: %d", 2*key, value)
+		}
+		actual = append(actual, key)
+	}
+	if !slices.Equal(expected, actual) || tree.Len() != 100 {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package bst implements a generic, unbalanced binary search Tree that maps ordered keys to values.  Its operations take
+time proportional to the height of the Tree, which is O(log n) for random insertions but O(n) for sorted ones; the
+balanced trees in sibling packages keep the same shape of API with guaranteed logarithmic height.
+*/
+
+package bst
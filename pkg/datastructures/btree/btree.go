@@ -0,0 +1,346 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package btree
+
+import (
+	"cmp"
+	"iter"
+	"sort"
+)
+
+// A BTree is a B-tree of distinct items.  Every node other than the root holds between degree-1 and 2*degree-1 items,
+// and all leaves are at the same depth, so Get, ReplaceOrInsert and Delete are O(log n).
+type BTree[T any] struct {
+	root    *node[T]
+	size    int
+	degree  int
+	compare func(a, b T) int
+}
+
+// node is a node of the BTree.  An internal node has one more child than it has items, and the items of children[i]
+// sort between items[i-1] and items[i].
+type node[T any] struct {
+	items    []T
+	children []*node[T]
+}
+
+// New returns an empty BTree of items in ascending order, whose nodes hold up to 2*degree-1 items.  It panics if degree
+// is less than 2.
+func New[T cmp.Ordered](degree int) *BTree[T] {
+	return NewFunc(degree, cmp.Compare[T])
+}
+
+// NewFunc returns an empty BTree of items ordered by compare, whose nodes hold up to 2*degree-1 items.  compare returns
+// a negative number when a sorts before b, a positive number when a sorts after b and zero when they are equal, as in
+// slices.SortFunc.  It panics if degree is less than 2.
+func NewFunc[T any](degree int, compare func(a, b T) int) *BTree[T] {
+	if degree < 2 {
+		panic("btree: degree must be at least 2")
+	}
+	return &BTree[T]{degree: degree, compare: compare}
+}
+
+// Len returns the number of items in the BTree.
+func (t *BTree[T]) Len() int {
+	return t.size
+}
+
+// Get returns the item in the BTree equal to key.  The second return value is false if there is no such item.
+func (t *BTree[T]) Get(key T) (T, bool) {
+	for n := t.root; n != nil; {
+		i, found := t.find(n, key)
+		if found {
+			return n.items[i], true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	var zero T
+	return zero, false
+}
+
+// Has reports whether an item equal to key is in the BTree.
+func (t *BTree[T]) Has(key T) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// ReplaceOrInsert adds item to the BTree.  If an equal item was already in the BTree, it is replaced and returned with
+// true.
+func (t *BTree[T]) ReplaceOrInsert(item T) (T, bool) {
+	if t.root == nil {
+		t.root = &node[T]{items: []T{item}}
+		t.size++
+		return item, false
+	}
+	if len(t.root.items) == t.maxItems() {
+		middle, right := t.root.split(t.degree - 1)
+		t.root = &node[T]{items: []T{middle}, children: []*node[T]{t.root, right}}
+	}
+	old, replaced := t.insert(t.root, item)
+	if !replaced {
+		t.size++
+	}
+	return old, replaced
+}
+
+// Delete removes the item equal to key from the BTree and returns it.  The second return value is false if there was
+// no such item.
+func (t *BTree[T]) Delete(key T) (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+	out, removed := t.remove(t.root, key, false)
+	if len(t.root.items) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+	if removed {
+		t.size--
+	}
+	return out, removed
+}
+
+// Min returns the smallest item in the BTree.  The second return value is false if the BTree is empty.
+func (t *BTree[T]) Min() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+	n := t.root
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n.items[0], true
+}
+
+// Max returns the largest item in the BTree.  The second return value is false if the BTree is empty.
+func (t *BTree[T]) Max() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+	n := t.root
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n.items[len(n.items)-1], true
+}
+
+// Ascend returns an iterator over the items of the BTree in ascending order.  The BTree must not be modified during
+// iteration.
+func (t *BTree[T]) Ascend() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.ascend(t.root, nil, nil, yield)
+	}
+}
+
+// AscendRange returns an iterator over the items v of the BTree with greaterOrEqual <= v < lessThan, in ascending
+// order.  The BTree must not be modified during iteration.
+func (t *BTree[T]) AscendRange(greaterOrEqual, lessThan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.ascend(t.root, &greaterOrEqual, &lessThan, yield)
+	}
+}
+
+// maxItems returns the most items a node may hold.
+func (t *BTree[T]) maxItems() int {
+	return 2*t.degree - 1
+}
+
+// minItems returns the fewest items a node other than the root may hold.
+func (t *BTree[T]) minItems() int {
+	return t.degree - 1
+}
+
+// find returns the index of the item in n equal to key and true, or the index of the child that would hold key and
+// false.
+func (t *BTree[T]) find(n *node[T], key T) (int, bool) {
+	i := sort.Search(len(n.items), func(i int) bool { return t.compare(key, n.items[i]) < 0 })
+	if i > 0 && t.compare(n.items[i-1], key) == 0 {
+		return i - 1, true
+	}
+	return i, false
+}
+
+// insert adds item to the subtree rooted at n, which is not full.  Full children are split on the way down, so there is
+// always room for the item when it reaches a leaf.
+func (t *BTree[T]) insert(n *node[T], item T) (T, bool) {
+	i, found := t.find(n, item)
+	if found {
+		old := n.items[i]
+		n.items[i] = item
+		return old, true
+	}
+	if n.leaf() {
+		n.items = insertAt(n.items, i, item)
+		var zero T
+		return zero, false
+	}
+	if len(n.children[i].items) == t.maxItems() {
+		middle, right := n.children[i].split(t.degree - 1)
+		n.items = insertAt(n.items, i, middle)
+		n.children = insertAt(n.children, i+1, right)
+		switch c := t.compare(item, middle); {
+		case c > 0:
+			i++
+		case c == 0:
+			n.items[i] = item
+			return middle, true
+		}
+	}
+	return t.insert(n.children[i], item)
+}
+
+// remove removes the item equal to key, or the largest item if max is true, from the subtree rooted at n.  Children
+// with the minimum number of items are grown on the way down, so a leaf can always give up an item.
+func (t *BTree[T]) remove(n *node[T], key T, max bool) (T, bool) {
+	var i int
+	var found bool
+	if max {
+		i = len(n.items)
+		if n.leaf() {
+			i--
+			found = true
+		}
+	} else {
+		i, found = t.find(n, key)
+	}
+	if n.leaf() {
+		if !found {
+			var zero T
+			return zero, false
+		}
+		out := n.items[i]
+		n.items = removeAt(n.items, i)
+		return out, true
+	}
+	if len(n.children[i].items) <= t.minItems() {
+		t.grow(n, i)
+		return t.remove(n, key, max)
+	}
+	if found {
+		// Replace the item with its predecessor, the largest item of the child to its left.
+		out := n.items[i]
+		n.items[i], _ = t.remove(n.children[i], key, true)
+		return out, true
+	}
+	return t.remove(n.children[i], key, max)
+}
+
+// grow gives child i of n an extra item, either by rotating one through n from a sibling with items to spare or by
+// merging the child with a sibling and the item of n between them.
+func (t *BTree[T]) grow(n *node[T], i int) {
+	child := n.children[i]
+	switch {
+	case i > 0 && len(n.children[i-1].items) > t.minItems():
+		left := n.children[i-1]
+		child.items = insertAt(child.items, 0, n.items[i-1])
+		n.items[i-1] = left.items[len(left.items)-1]
+		left.items = removeAt(left.items, len(left.items)-1)
+		if !left.leaf() {
+			child.children = insertAt(child.children, 0, left.children[len(left.children)-1])
+			left.children = removeAt(left.children, len(left.children)-1)
+		}
+	case i < len(n.items) && len(n.children[i+1].items) > t.minItems():
+		right := n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.items[0]
+		right.items = removeAt(right.items, 0)
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+	default:
+		if i == len(n.items) {
+			i--
+			child = n.children[i]
+		}
+		right := n.children[i+1]
+		child.items = append(append(child.items, n.items[i]), right.items...)
+		child.children = append(child.children, right.children...)
+		n.items = removeAt(n.items, i)
+		n.children = removeAt(n.children, i+1)
+	}
+}
+
+// ascend yields the items of the subtree rooted at n in ascending order, starting at the first item not less than from
+// and stopping before the first item not less than to, where a nil bound is unbounded.  It returns false once iteration
+// should stop.
+func (t *BTree[T]) ascend(n *node[T], from, to *T, yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	start := 0
+	if from != nil {
+		start = sort.Search(len(n.items), func(i int) bool { return t.compare(n.items[i], *from) >= 0 })
+	}
+	for i := start; i < len(n.items); i++ {
+		if !n.leaf() && !t.ascend(n.children[i], from, to, yield) {
+			return false
+		}
+		if to != nil && t.compare(n.items[i], *to) >= 0 {
+			return false
+		}
+		if !yield(n.items[i]) {
+			return false
+		}
+	}
+	if !n.leaf() {
+		return t.ascend(n.children[len(n.items)], from, to, yield)
+	}
+	return true
+}
+
+// leaf reports whether n has no children.
+func (n *node[T]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// split splits n, which is full, around the item at index i.  n keeps the items before i and the function returns the
+// item at i and a new node holding the items after it.
+func (n *node[T]) split(i int) (T, *node[T]) {
+	middle := n.items[i]
+	right := &node[T]{items: append([]T(nil), n.items[i+1:]...)}
+	clear(n.items[i:]) // avoid memory leak
+	n.items = n.items[:i]
+	if !n.leaf() {
+		right.children = append([]*node[T](nil), n.children[i+1:]...)
+		clear(n.children[i+1:]) // avoid memory leak
+		n.children = n.children[:i+1]
+	}
+	return middle, right
+}
+
+// insertAt inserts value into s at index i.
+func insertAt[E any](s []E, i int, value E) []E {
+	var zero E
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = value
+	return s
+}
+
+// removeAt removes the element at index i of s.
+func removeAt[E any](s []E, i int) []E {
+	copy(s[i:], s[i+1:])
+	var zero E
+	s[len(s)-1] = zero // avoid memory leak
+	return s[:len(s)-1]
+}
@@ -0,0 +1,138 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package btree_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/btree"
+)
+
+func TestBTree(t *testing.T) {
+	tree := btree.New[int](2)
+	if _, ok := tree.Min(); ok {
+		t.Fatalf("Expected an empty BTree to have no Min")
+	}
+	for i := 0; i < 100; i++ {
+		if _, replaced := tree.ReplaceOrInsert(i); replaced {
+			t.Fatalf("Expected %d to be new", i)
+		}
+	}
+	if old, replaced := tree.ReplaceOrInsert(42); !replaced || old != 42 {
+		t.Fatalf("Expected: %d Actual: %d", 42, old)
+	}
+	if tree.Len() != 100 {
+		t.Fatalf("Expected: %d Actual: %d", 100, tree.Len())
+	}
+	if item, ok := tree.Get(17); !ok || item != 17 {
+		t.Fatalf("Expected: %d Actual: %d", 17, item)
+	}
+	if tree.Has(100) {
+		t.Fatalf("Expected %d to be absent", 100)
+	}
+	if min, _ := tree.Min(); min != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, min)
+	}
+	if max, _ := tree.Max(); max != 99 {
+		t.Fatalf("Expected: %d Actual: %d", 99, max)
+	}
+	expected := []int{10, 11, 12, 13, 14}
+	if actual := slices.Collect(tree.AscendRange(10, 15)); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	for item := range tree.Ascend() {
+		if item != 0 {
+			t.Fatalf("Expected: %d Actual: %d", 0, item)
+		}
+		break
+	}
+	if item, ok := tree.Delete(50); !ok || item != 50 {
+		t.Fatalf("Expected: %d Actual: %d", 50, item)
+	}
+	if _, ok := tree.Delete(50); ok {
+		t.Fatalf("Expected %d to be absent", 50)
+	}
+}
+
+// pair is an item keyed by its key alone, so ReplaceOrInsert can replace its value.
+type pair struct {
+	key, value int
+}
+
+func TestBTree_Replace(t *testing.T) {
+	tree := btree.NewFunc(3, func(a, b pair) int { return a.key - b.key })
+	tree.ReplaceOrInsert(pair{1, 1})
+	if old, replaced := tree.ReplaceOrInsert(pair{1, 2}); !replaced || old.value != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, old.value)
+	}
+	if item, _ := tree.Get(pair{key: 1}); item.value != 2 {
+		t.Fatalf("Expected: %d Actual: %d", 2, item.value)
+	}
+}
+
+func TestBTree_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, degree := range []int{2, 3, 8, 32} {
+		tree := btree.New[int](degree)
+		reference := map[int]bool{}
+		for i := 0; i < 20000; i++ {
+			item := r.Intn(2000)
+			if r.Intn(2) == 0 {
+				if _, ok := tree.Delete(item); ok != reference[item] {
+					t.Fatalf("Delete(%d) disagreed with the reference", item)
+				}
+				delete(reference, item)
+			} else {
+				if _, replaced := tree.ReplaceOrInsert(item); replaced != reference[item] {
+					t.Fatalf("ReplaceOrInsert(%d) disagreed with the reference", item)
+				}
+				reference[item] = true
+			}
+		}
+		var expected []int
+		for item := range reference {
+			expected = append(expected, item)
+		}
+		slices.Sort(expected)
+		if actual := slices.Collect(tree.Ascend()); !slices.Equal(expected, actual) || tree.Len() != len(expected) {
+			t.Fatalf("Expected: %v Actual: %v", expected, actual)
+		}
+		from, to := 500, 1500
+		var window []int
+		for _, item := range expected {
+			if item >= from && item < to {
+				window = append(window, item)
+			}
+		}
+		if actual := slices.Collect(tree.AscendRange(from, to)); !slices.Equal(window, actual) {
+			t.Fatalf("Expected: %v Actual: %v", window, actual)
+		}
+		for _, item := range expected {
+			tree.Delete(item)
+		}
+		if tree.Len() != 0 || tree.Has(expected[0]) {
+			t.Fatalf("Expected an empty BTree")
+		}
+	}
+}
+
+func TestNew_InvalidDegree(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected a panic for degree %d", 1)
+		}
+	}()
+	btree.New[int](1)
+}
@@ -0,0 +1,44 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import "iter"
+
+// A Cache maps keys to values, holding at most a fixed number of keys and evicting one, chosen by its policy, to make
+// room for a new key.
+type Cache[K comparable, V any] interface {
+	// Len returns the number of keys in the Cache.
+	Len() int
+	// Cap returns the greatest number of keys the Cache holds.
+	Cap() int
+	// Get returns the value of key, recording the use of key for the eviction policy, and counts a hit.  The second
+	// return value is false, and a miss is counted, if key is not in the Cache.
+	Get(key K) (V, bool)
+	// Peek returns the value of key like Get, but records neither a use of key nor a hit or miss.
+	Peek(key K) (V, bool)
+	// Contains reports whether key is in the Cache, without recording a use of it.
+	Contains(key K) bool
+	// Put sets the value of key, recording the use of key for the eviction policy.  If key is new and the Cache is
+	// full, a key is evicted first.  It returns true if a key was evicted.
+	Put(key K, value V) bool
+	// Delete removes key from the Cache.  It returns false if key was not in the Cache.
+	Delete(key K) bool
+	// Purge removes every key from the Cache.
+	Purge()
+	// Hits returns the number of calls to Get that found their key.
+	Hits() uint64
+	// Misses returns the number of calls to Get that did not find their key.
+	Misses() uint64
+	// All returns an iterator over the keys of the Cache and their values, with the key to be evicted next last.
+	All() iter.Seq2[K, V]
+}
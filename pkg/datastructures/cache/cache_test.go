@@ -0,0 +1,81 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/cache"
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/lfucache"
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/lrucache"
+)
+
+// keys returns the keys of c in the order of All.
+func keys(c cache.Cache[string, int]) []string {
+	var keys []string
+	for key := range c.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestCache(t *testing.T) {
+	cases := []struct {
+		name    string
+		new     func(capacity int, onEvict func(string, int)) cache.Cache[string, int]
+		evicted string   // The key evicted by Put("d") after the Puts and Gets below.
+		order   []string // The keys afterwards, in the order of All.
+	}{
+		{"LRU", func(capacity int, onEvict func(string, int)) cache.Cache[string, int] {
+			return lrucache.NewWithEvict(capacity, onEvict)
+		}, "a", []string{"d", "c", "b"}},
+		{"LFU", func(capacity int, onEvict func(string, int)) cache.Cache[string, int] {
+			return lfucache.NewWithEvict(capacity, onEvict)
+		}, "b", []string{"a", "c", "d"}},
+	}
+	for _, c := range cases {
+		var evicted []string
+		impl := c.new(3, func(key string, value int) {
+			evicted = append(evicted, key)
+		})
+		impl.Put("a", 1)
+		impl.Put("b", 2)
+		impl.Put("c", 3)
+		impl.Get("a")
+		impl.Get("a")
+		impl.Get("b")
+		impl.Get("c")
+		impl.Get("z")
+		if !impl.Put("d", 4) || !slices.Equal(evicted, []string{c.evicted}) {
+			t.Fatalf("%s Expected: %s Actual: %v", c.name, c.evicted, evicted)
+		}
+		if actual := keys(impl); !slices.Equal(c.order, actual) {
+			t.Fatalf("%s Expected: %v Actual: %v", c.name, c.order, actual)
+		}
+		if impl.Hits() != 4 || impl.Misses() != 1 || impl.Len() != 3 || impl.Cap() != 3 {
+			t.Fatalf("%s Expected: %d hits and %d misses Actual: %d hits and %d misses", c.name, 4, 1, impl.Hits(),
+				impl.Misses())
+		}
+		if value, ok := impl.Peek("d"); !ok || value != 4 || !impl.Contains("c") || impl.Contains(c.evicted) {
+			t.Fatalf("%s Expected: %d Actual: %d", c.name, 4, value)
+		}
+		if !impl.Delete("d") || impl.Delete("d") {
+			t.Fatalf("%s Expected to delete %s once", c.name, "d")
+		}
+		impl.Purge()
+		if impl.Len() != 0 || len(evicted) != 1 {
+			t.Fatalf("%s Expected Purge to empty the Cache without evicting", c.name)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package countminsketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+)
+
+// binaryVersion is the version of the encoding produced by MarshalBinary, written as its first byte.
+const binaryVersion = 1
+
+// ErrIncompatible is returned by Merge when the Sketches differ in width or depth.
+var ErrIncompatible = errors.New("countminsketch: incompatible sketches")
+
+// A Sketch is a count-min sketch:  depth rows of width counters, where each row hashes an item to one of its counters.
+// Adding an item increments its counter in every row, and the estimate of an item is the smallest of its counters,
+// which other items have inflated the least.
+type Sketch struct {
+	counts []uint64 // The rows, one after another.
+	width  uint64
+	depth  uint64
+	total  uint64 // The sum of all counts added.
+}
+
+// New returns an empty Sketch whose estimates exceed the true count by at most epsilon times the total count with
+// probability at least 1 - delta, using width e / epsilon and depth ln(1 / delta).  It panics if epsilon or delta is
+// not strictly between 0 and 1.
+func New(epsilon, delta float64) *Sketch {
+	if !(epsilon > 0 && epsilon < 1) || !(delta > 0 && delta < 1) {
+		panic("countminsketch: epsilon and delta must be between 0 and 1")
+	}
+	width := math.Ceil(math.E / epsilon)
+	depth := math.Ceil(math.Log(1 / delta))
+	return NewWithSize(uint64(width), uint64(depth))
+}
+
+// NewWithSize returns an empty Sketch with depth rows of width counters.  It panics if width or depth is zero.
+func NewWithSize(width, depth uint64) *Sketch {
+	if width == 0 || depth == 0 {
+		panic("countminsketch: width and depth must be positive")
+	}
+	return &Sketch{counts: make([]uint64, width*depth), width: width, depth: depth}
+}
+
+// Width returns the number of counters in each row of the Sketch.
+func (s *Sketch) Width() uint64 {
+	return s.width
+}
+
+// Depth returns the number of rows in the Sketch.
+func (s *Sketch) Depth() uint64 {
+	return s.depth
+}
+
+// Total returns the sum of all counts added to the Sketch.
+func (s *Sketch) Total() uint64 {
+	return s.total
+}
+
+// Add adds count occurrences of item to the Sketch.
+func (s *Sketch) Add(item []byte, count uint64) {
+	h1, h2 := hash(item)
+	for i := uint64(0); i < s.depth; i++ {
+		s.counts[i*s.width+(h1+i*h2)%s.width] += count
+	}
+	s.total += count
+}
+
+// AddString adds count occurrences of item to the Sketch.
+func (s *Sketch) AddString(item string, count uint64) {
+	s.Add([]byte(item), count)
+}
+
+// Estimate returns an estimate of the number of occurrences of item, which is never less than the true number.
+func (s *Sketch) Estimate(item []byte) uint64 {
+	h1, h2 := hash(item)
+	estimate := uint64(math.MaxUint64)
+	for i := uint64(0); i < s.depth; i++ {
+		estimate = min(estimate, s.counts[i*s.width+(h1+i*h2)%s.width])
+	}
+	return estimate
+}
+
+// EstimateString returns an estimate of the number of occurrences of item.
+func (s *Sketch) EstimateString(item string) uint64 {
+	return s.Estimate([]byte(item))
+}
+
+// Merge adds the counts of other to the Sketch, so that it estimates the combined stream.  It returns ErrIncompatible
+// if the Sketches differ in width or depth.
+func (s *Sketch) Merge(other *Sketch) error {
+	if s.width != other.width || s.depth != other.depth {
+		return ErrIncompatible
+	}
+	for i, count := range other.counts {
+		s.counts[i] += count
+	}
+	s.total += other.total
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The encoding holds the width, depth, total and every counter as
+// varints, so a sparse Sketch encodes compactly.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 1+3*binary.MaxVarintLen64+len(s.counts))
+	b = append(b, binaryVersion)
+	b = binary.AppendUvarint(b, s.width)
+	b = binary.AppendUvarint(b, s.depth)
+	b = binary.AppendUvarint(b, s.total)
+	for _, count := range s.counts {
+		b = binary.AppendUvarint(b, count)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler by replacing the Sketch with the one encoded in data by
+// MarshalBinary.  The Sketch is left unchanged if data cannot be decoded.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return errors.New("countminsketch: unsupported binary encoding")
+	}
+	corrupt := errors.New("countminsketch: corrupt binary encoding")
+	data = data[1:]
+	var header [3]uint64
+	for i := range header {
+		value, n := binary.Uvarint(data)
+		if n <= 0 {
+			return corrupt
+		}
+		header[i], data = value, data[n:]
+	}
+	width, depth, total := header[0], header[1], header[2]
+	// Every counter takes at least one byte, which bounds the allocation below by the size of data.
+	if width == 0 || depth == 0 || depth > uint64(len(data))/width {
+		return corrupt
+	}
+	counts := make([]uint64, width*depth)
+	for i := range counts {
+		count, n := binary.Uvarint(data)
+		if n <= 0 {
+			return corrupt
+		}
+		counts[i], data = count, data[n:]
+	}
+	if len(data) != 0 {
+		return corrupt
+	}
+	s.counts, s.width, s.depth, s.total = counts, width, depth, total
+	return nil
+}
+
+// hash returns two independent 64-bit hashes of item, the halves of its 128-bit FNV-1a hash, which are combined as
+// h1 + i*h2 to choose the counter of item in row i.
+func hash(item []byte) (uint64, uint64) {
+	h := fnv.New128a()
+	h.Write(item)
+	var sum [16]byte
+	h.Sum(sum[:0])
+	return binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:]) | 1
+}
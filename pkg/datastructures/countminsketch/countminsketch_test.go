@@ -0,0 +1,94 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package countminsketch_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/countminsketch"
+)
+
+func TestSketch(t *testing.T) {
+	s := countminsketch.New(0.001, 0.01)
+	if s.Width() != 2719 || s.Depth() != 5 {
+		t.Fatalf("Expected: %dx%d Actual: %dx%d", 2719, 5, s.Width(), s.Depth())
+	}
+	// Item i occurs i times, for a total of about 500,000.
+	for i := 1; i <= 1000; i++ {
+		s.AddString(strconv.Itoa(i), uint64(i))
+	}
+	bound := uint64(0.001 * float64(s.Total()))
+	exceeded := 0
+	for i := 1; i <= 1000; i++ {
+		estimate := s.EstimateString(strconv.Itoa(i))
+		if estimate < uint64(i) {
+			t.Fatalf("Expected at least %d Actual: %d", i, estimate)
+		}
+		if estimate > uint64(i)+bound {
+			exceeded++
+		}
+	}
+	// Each estimate exceeds the bound with probability at most 1%.
+	if exceeded > 20 {
+		t.Fatalf("Expected about %d estimates beyond the bound Actual: %d", 10, exceeded)
+	}
+	if estimate := s.EstimateString("absent"); estimate > bound {
+		t.Fatalf("Expected at most %d Actual: %d", bound, estimate)
+	}
+}
+
+func TestSketch_Merge(t *testing.T) {
+	a := countminsketch.NewWithSize(100, 4)
+	b := countminsketch.NewWithSize(100, 4)
+	a.AddString("apple", 3)
+	b.AddString("apple", 4)
+	b.AddString("banana", 1)
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if estimate := a.EstimateString("apple"); estimate < 7 {
+		t.Fatalf("Expected at least %d Actual: %d", 7, estimate)
+	}
+	if a.Total() != 8 {
+		t.Fatalf("Expected: %d Actual: %d", 8, a.Total())
+	}
+	if err := a.Merge(countminsketch.NewWithSize(100, 5)); !errors.Is(err, countminsketch.ErrIncompatible) {
+		t.Fatalf("Expected: %s Actual: %v", countminsketch.ErrIncompatible, err)
+	}
+}
+
+func TestSketch_Binary(t *testing.T) {
+	s := countminsketch.NewWithSize(64, 3)
+	s.AddString("apple", 1000)
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling: %s", err)
+	}
+	var restored countminsketch.Sketch
+	if err := restored.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Unexpected error unmarshaling: %s", err)
+	}
+	if estimate := restored.EstimateString("apple"); estimate != 1000 {
+		t.Fatalf("Expected: %d Actual: %d", 1000, estimate)
+	}
+	if restored.Total() != 1000 || restored.Width() != 64 || restored.Depth() != 3 {
+		t.Fatalf("Expected the restored Sketch to match the original")
+	}
+	for _, corrupt := range [][]byte{nil, {2}, b[:len(b)-1], append(b, 0), {1, 255, 255, 255, 255, 15, 255, 1, 0}} {
+		if err := restored.UnmarshalBinary(corrupt); err == nil {
+			t.Fatalf("Expected an error unmarshaling %v", corrupt)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package countminsketch implements a count-min Sketch, which estimates how often each item occurs in a stream using a
+fixed amount of memory.  Estimates never undercount, and overcount by at most a chosen fraction of the stream's total
+with a chosen probability.  Items are hashed with FNV-1a, as in package bloomfilter, so Sketches built by different
+processes can be marshaled and merged.
+*/
+
+package countminsketch
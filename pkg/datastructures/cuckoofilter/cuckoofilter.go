@@ -0,0 +1,250 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cuckoofilter
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math/bits"
+	"math/rand"
+)
+
+// binaryVersion is the version of the encoding produced by MarshalBinary, written as its first byte.
+const binaryVersion = 1
+
+const (
+	bucketSize = 4   // The number of fingerprints per bucket.
+	maxKicks   = 500 // The number of relocations Add attempts before giving up.
+)
+
+// ErrFull is returned by Add when no room can be made for an item.
+var ErrFull = errors.New("cuckoofilter: full")
+
+// A Filter is a cuckoo filter:  an array of buckets, each holding up to four fingerprints.  An item may be in either
+// of two buckets, i1 = hash(item) and i2 = i1 xor hash(fingerprint), so the alternate bucket of any stored fingerprint
+// can be found without the item itself.  A fingerprint of zero marks an empty slot.
+type Filter struct {
+	buckets [][bucketSize]uint16
+	bits    uint8  // The number of bits in a fingerprint.
+	count   uint64 // The number of fingerprints stored.
+	rand    *rand.Rand
+}
+
+// New returns an empty Filter with room for at least capacity items at the given load factor, the fraction of slots
+// expected to be filled before Add fails, with fingerprints of fingerprintBits bits.  Four-slot buckets typically reach
+// a load factor of 0.95.  The false positive rate is about 8 / 2^fingerprintBits.  It panics if capacity is not
+// positive, loadFactor is not in (0, 1] or fingerprintBits is not between 4 and 16.
+func New(capacity int, loadFactor float64, fingerprintBits uint8) *Filter {
+	if capacity <= 0 {
+		panic("cuckoofilter: capacity must be positive")
+	}
+	if !(loadFactor > 0 && loadFactor <= 1) {
+		panic("cuckoofilter: load factor must be in (0, 1]")
+	}
+	if fingerprintBits < 4 || fingerprintBits > 16 {
+		panic("cuckoofilter: fingerprint bits must be between 4 and 16")
+	}
+	buckets := uint64(float64(capacity) / loadFactor / bucketSize)
+	// The xor of bucket indexes stays in range only if the number of buckets is a power of two.
+	n := uint64(1) << bits.Len64(max(buckets, 1)-1)
+	return &Filter{
+		buckets: make([][bucketSize]uint16, n),
+		bits:    fingerprintBits,
+		rand:    rand.New(rand.NewSource(1)),
+	}
+}
+
+// Len returns the number of items in the Filter.
+func (f *Filter) Len() uint64 {
+	return f.count
+}
+
+// Capacity returns the number of slots in the Filter.
+func (f *Filter) Capacity() uint64 {
+	return uint64(len(f.buckets)) * bucketSize
+}
+
+// Add adds item to the Filter.  Adding an item more than once stores it more than once, so it must be deleted as many
+// times.  It returns ErrFull if no room could be made, in which case some other item may have been evicted from the
+// Filter, leaving it with a false negative.
+func (f *Filter) Add(item []byte) error {
+	fp, i1, i2 := f.locate(item)
+	if f.insert(i1, fp) || f.insert(i2, fp) {
+		f.count++
+		return nil
+	}
+	i := i1
+	if f.rand.Intn(2) == 0 {
+		i = i2
+	}
+	for kick := 0; kick < maxKicks; kick++ {
+		slot := f.rand.Intn(bucketSize)
+		fp, f.buckets[i][slot] = f.buckets[i][slot], fp
+		i = f.alternate(i, fp)
+		if f.insert(i, fp) {
+			f.count++
+			return nil
+		}
+	}
+	return ErrFull
+}
+
+// AddString adds item to the Filter.
+func (f *Filter) AddString(item string) error {
+	return f.Add([]byte(item))
+}
+
+// MayContain reports whether item may be in the Filter.  False means item is certainly not in the Filter, while true
+// may be a false positive.
+func (f *Filter) MayContain(item []byte) bool {
+	fp, i1, i2 := f.locate(item)
+	return f.find(i1, fp) >= 0 || f.find(i2, fp) >= 0
+}
+
+// MayContainString reports whether item may be in the Filter.
+func (f *Filter) MayContainString(item string) bool {
+	return f.MayContain([]byte(item))
+}
+
+// Delete removes one copy of item from the Filter.  It returns false if item was not found.  Deleting an item that was
+// never added may instead remove another item with the same fingerprint.
+func (f *Filter) Delete(item []byte) bool {
+	fp, i1, i2 := f.locate(item)
+	for _, i := range [2]uint64{i1, i2} {
+		if slot := f.find(i, fp); slot >= 0 {
+			f.buckets[i][slot] = 0
+			f.count--
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteString removes one copy of item from the Filter.
+func (f *Filter) DeleteString(item string) bool {
+	return f.Delete([]byte(item))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The encoding holds the number of buckets, the fingerprint size and
+// the number of items followed by every slot as a little-endian 16-bit word.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 2+2*binary.MaxVarintLen64+2*bucketSize*len(f.buckets))
+	b = append(b, binaryVersion)
+	b = binary.AppendUvarint(b, uint64(len(f.buckets)))
+	b = append(b, f.bits)
+	b = binary.AppendUvarint(b, f.count)
+	for _, bucket := range f.buckets {
+		for _, fp := range bucket {
+			b = binary.LittleEndian.AppendUint16(b, fp)
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler by replacing the Filter with the one encoded in data by
+// MarshalBinary.  The Filter is left unchanged if data cannot be decoded.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return errors.New("cuckoofilter: unsupported binary encoding")
+	}
+	corrupt := errors.New("cuckoofilter: corrupt binary encoding")
+	data = data[1:]
+	n, k := binary.Uvarint(data)
+	if k <= 0 || n == 0 || n&(n-1) != 0 || len(data) < k+1 {
+		return corrupt
+	}
+	fingerprintBits := data[k]
+	data = data[k+1:]
+	count, k := binary.Uvarint(data)
+	if k <= 0 || fingerprintBits < 4 || fingerprintBits > 16 {
+		return corrupt
+	}
+	data = data[k:]
+	if uint64(len(data))/(2*bucketSize) != n || uint64(len(data))%(2*bucketSize) != 0 {
+		return corrupt
+	}
+	buckets := make([][bucketSize]uint16, n)
+	stored := uint64(0)
+	for i := range buckets {
+		for slot := range buckets[i] {
+			fp := binary.LittleEndian.Uint16(data)
+			if fp>>fingerprintBits != 0 {
+				return corrupt
+			}
+			if fp != 0 {
+				stored++
+			}
+			buckets[i][slot], data = fp, data[2:]
+		}
+	}
+	if stored != count {
+		return corrupt
+	}
+	f.buckets, f.bits, f.count = buckets, fingerprintBits, count
+	if f.rand == nil {
+		f.rand = rand.New(rand.NewSource(1))
+	}
+	return nil
+}
+
+// locate returns the fingerprint of item and its two candidate buckets.
+func (f *Filter) locate(item []byte) (uint16, uint64, uint64) {
+	sum := hash(item)
+	// The low bits choose the bucket, and the high bits, which are independent of them, the fingerprint.  A zero
+	// fingerprint would look like an empty slot, so it is remapped.
+	fp := uint16(sum >> (64 - f.bits))
+	if fp == 0 {
+		fp = 1
+	}
+	i1 := sum & uint64(len(f.buckets)-1)
+	return fp, i1, f.alternate(i1, fp)
+}
+
+// alternate returns the other candidate bucket of fingerprint fp stored in bucket i.
+func (f *Filter) alternate(i uint64, fp uint16) uint64 {
+	return (i ^ hash([]byte{byte(fp), byte(fp >> 8)})) & uint64(len(f.buckets)-1)
+}
+
+// insert stores fp in an empty slot of bucket i.  It returns false if the bucket is full.
+func (f *Filter) insert(i uint64, fp uint16) bool {
+	if slot := f.find(i, 0); slot >= 0 {
+		f.buckets[i][slot] = fp
+		return true
+	}
+	return false
+}
+
+// find returns the slot of bucket i holding fp, or -1 if there is none.
+func (f *Filter) find(i uint64, fp uint16) int {
+	for slot, stored := range f.buckets[i] {
+		if stored == fp {
+			return slot
+		}
+	}
+	return -1
+}
+
+// hash returns the 64-bit FNV-1a hash of b, mixed by the MurmurHash3 finalizer so that its high and low bits are
+// independent; FNV-1a alone leaves the high bits of short inputs poorly mixed.
+func hash(b []byte) uint64 {
+	f := fnv.New64a()
+	f.Write(b)
+	h := f.Sum64()
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
@@ -0,0 +1,100 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cuckoofilter_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/cuckoofilter"
+)
+
+func TestFilter(t *testing.T) {
+	f := cuckoofilter.New(10000, 0.9, 12)
+	if f.Capacity() != 16384 {
+		t.Fatalf("Expected: %d Actual: %d", 16384, f.Capacity())
+	}
+	for i := 0; i < 10000; i++ {
+		if err := f.AddString(strconv.Itoa(i)); err != nil {
+			t.Fatalf("Unexpected error adding %d: %s", i, err)
+		}
+	}
+	for i := 0; i < 10000; i++ {
+		if !f.MayContainString(strconv.Itoa(i)) {
+			t.Fatalf("Expected %d to be in the Filter", i)
+		}
+	}
+	falsePositives := 0
+	for i := 10000; i < 20000; i++ {
+		if f.MayContainString(strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	// The false positive rate should be about 8 / 2^12, or 0.2%.
+	if falsePositives > 50 {
+		t.Fatalf("Expected about %d false positives Actual: %d", 20, falsePositives)
+	}
+	for i := 0; i < 5000; i++ {
+		if !f.DeleteString(strconv.Itoa(i)) {
+			t.Fatalf("Expected to delete %d", i)
+		}
+	}
+	if f.Len() != 5000 {
+		t.Fatalf("Expected: %d Actual: %d", 5000, f.Len())
+	}
+	for i := 5000; i < 10000; i++ {
+		if !f.MayContainString(strconv.Itoa(i)) {
+			t.Fatalf("Expected %d to remain in the Filter", i)
+		}
+	}
+}
+
+func TestFilter_Full(t *testing.T) {
+	f := cuckoofilter.New(8, 1, 16)
+	var err error
+	for i := 0; i < 100 && err == nil; i++ {
+		err = f.AddString(strconv.Itoa(i))
+	}
+	if !errors.Is(err, cuckoofilter.ErrFull) {
+		t.Fatalf("Expected: %s Actual: %v", cuckoofilter.ErrFull, err)
+	}
+}
+
+func TestFilter_Binary(t *testing.T) {
+	f := cuckoofilter.New(100, 0.95, 8)
+	f.AddString("apple")
+	f.AddString("banana")
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling: %s", err)
+	}
+	var restored cuckoofilter.Filter
+	if err := restored.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Unexpected error unmarshaling: %s", err)
+	}
+	if restored.Len() != 2 || !restored.MayContainString("apple") || !restored.MayContainString("banana") {
+		t.Fatalf("Expected the restored Filter to match the original")
+	}
+	if !restored.DeleteString("apple") || restored.MayContainString("apple") {
+		t.Fatalf("Expected to delete %s from the restored Filter", "apple")
+	}
+	if err := restored.AddString("cherry"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, corrupt := range [][]byte{nil, {2}, b[:len(b)-1], {1, 3, 8, 0}} {
+		if err := restored.UnmarshalBinary(corrupt); err == nil {
+			t.Fatalf("Expected an error unmarshaling %v", corrupt)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package cuckoofilter implements a cuckoo Filter, an approximate set that, unlike a Bloom filter, supports Delete.  It
+stores a short fingerprint of each item in one of two candidate buckets, relocating fingerprints as in cuckoo hashing
+when both are full.  Like the other probabilistic structures in this module, items are hashed with FNV-1a and Filters
+are marshaled with a leading version byte.
+*/
+
+package cuckoofilter
@@ -0,0 +1,159 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deque
+
+import (
+	"encoding/json"
+	"iter"
+)
+
+// minCapacity is the capacity of the ring buffer of a Deque once it first grows.
+const minCapacity = 8
+
+// A Deque is a double-ended queue:  values may be pushed and popped at either end in amortized O(1), and accessed by
+// position in O(1).  The zero value is an empty Deque ready to use.
+type Deque[T any] struct {
+	buffer []T
+	head   int // The index in buffer of the front of the Deque.
+	n      int // The number of values in the Deque.
+}
+
+// New returns an empty Deque.
+func New[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// Len returns the number of values in the Deque.
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+// PushFront adds value to the front of the Deque.
+func (d *Deque[T]) PushFront(value T) {
+	if d.n == len(d.buffer) {
+		d.grow()
+	}
+	d.head = d.index(-1)
+	d.buffer[d.head] = value
+	d.n++
+}
+
+// PushBack adds value to the back of the Deque.
+func (d *Deque[T]) PushBack(value T) {
+	if d.n == len(d.buffer) {
+		d.grow()
+	}
+	d.buffer[d.index(d.n)] = value
+	d.n++
+}
+
+// PopFront removes and returns the value at the front of the Deque.  The second return value is false if the Deque is
+// empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	value := d.buffer[d.head]
+	d.buffer[d.head] = zero // avoid memory leak
+	d.head = d.index(1)
+	d.n--
+	return value, true
+}
+
+// PopBack removes and returns the value at the back of the Deque.  The second return value is false if the Deque is
+// empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	i := d.index(d.n - 1)
+	value := d.buffer[i]
+	d.buffer[i] = zero // avoid memory leak
+	d.n--
+	return value, true
+}
+
+// Front returns the value at the front of the Deque.  The second return value is false if the Deque is empty.
+func (d *Deque[T]) Front() (T, bool) {
+	return d.At(0)
+}
+
+// Back returns the value at the back of the Deque.  The second return value is false if the Deque is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	return d.At(d.n - 1)
+}
+
+// At returns the value at position i, counting from zero at the front of the Deque.  The second return value is false
+// if i is out of range.
+func (d *Deque[T]) At(i int) (T, bool) {
+	if i < 0 || i >= d.n {
+		var zero T
+		return zero, false
+	}
+	return d.buffer[d.index(i)], true
+}
+
+// All returns an iterator over the values of the Deque from front to back.  The Deque must not be modified during the
+// iteration.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.n; i++ {
+			if !yield(d.buffer[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalJSON marshals the Deque as an array from front to back.
+func (d *Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.values(d.n))
+}
+
+// UnmarshalJSON replaces the contents of the Deque with the values in data, which must be an array from front to back
+// as produced by MarshalJSON.  The Deque is left unchanged if data cannot be decoded.
+func (d *Deque[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*d = Deque[T]{buffer: values, n: len(values)}
+	return nil
+}
+
+// index returns the index in buffer of position i, which may be -1 for the position before the front.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i + len(d.buffer)) % len(d.buffer)
+}
+
+// grow doubles the capacity of the ring buffer, moving the values to the start of the new buffer.
+func (d *Deque[T]) grow() {
+	capacity := 2 * len(d.buffer)
+	if capacity < minCapacity {
+		capacity = minCapacity
+	}
+	d.buffer = d.values(capacity)
+	d.head = 0
+}
+
+// values returns the values of the Deque from front to back in a new slice of length n.
+func (d *Deque[T]) values(n int) []T {
+	values := make([]T, n)
+	if d.n > 0 {
+		copied := copy(values, d.buffer[d.head:min(d.head+d.n, len(d.buffer))])
+		copy(values[copied:d.n], d.buffer)
+	}
+	return values
+}
@@ -0,0 +1,116 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deque_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/deque"
+)
+
+func TestDeque(t *testing.T) {
+	d := deque.New[string]()
+	if _, ok := d.PopFront(); ok {
+		t.Fatalf("Expected an empty Deque")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Fatalf("Expected an empty Deque")
+	}
+	d.PushBack("banana")
+	d.PushFront("apple")
+	d.PushBack("carrot")
+	d.PushBack("danish")
+	if d.Len() != 4 {
+		t.Fatalf("Expected: %d Actual: %d", 4, d.Len())
+	}
+	if value, _ := d.At(2); value != "carrot" {
+		t.Fatalf("Expected: %s Actual: %s", "carrot", value)
+	}
+	if _, ok := d.At(4); ok {
+		t.Fatalf("Expected no value at: %d", 4)
+	}
+	if front, _ := d.Front(); front != "apple" {
+		t.Fatalf("Expected: %s Actual: %s", "apple", front)
+	}
+	if back, _ := d.Back(); back != "danish" {
+		t.Fatalf("Expected: %s Actual: %s", "danish", back)
+	}
+	expected := []string{"apple", "banana", "carrot", "danish"}
+	if actual := slices.Collect(d.All()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	jsonBytes, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	if string(jsonBytes) != `["apple","banana","carrot","danish"]` {
+		t.Fatalf("Expected: %s Actual: %s", `["apple","banana","carrot","danish"]`, jsonBytes)
+	}
+	var restored deque.Deque[string]
+	if err := json.Unmarshal(jsonBytes, &restored); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	if value, _ := restored.PopBack(); value != "danish" {
+		t.Fatalf("Expected: %s Actual: %s", "danish", value)
+	}
+	if value, _ := restored.PopFront(); value != "apple" {
+		t.Fatalf("Expected: %s Actual: %s", "apple", value)
+	}
+}
+
+// TestDeque_Random checks random operations at both ends against a slice, so that the ring buffer wraps around in both
+// directions and grows while wrapped.
+func TestDeque_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var d deque.Deque[int]
+	var reference []int
+	for i := 0; i < 10000; i++ {
+		switch r.Intn(5) {
+		case 0, 1:
+			d.PushFront(i)
+			reference = append([]int{i}, reference...)
+		case 2, 3:
+			d.PushBack(i)
+			reference = append(reference, i)
+		case 4:
+			if len(reference) == 0 {
+				if _, ok := d.PopFront(); ok {
+					t.Fatalf("Expected an empty Deque")
+				}
+				continue
+			}
+			var expected, actual int
+			if r.Intn(2) == 0 {
+				expected, reference = reference[0], reference[1:]
+				actual, _ = d.PopFront()
+			} else {
+				expected, reference = reference[len(reference)-1], reference[:len(reference)-1]
+				actual, _ = d.PopBack()
+			}
+			if expected != actual {
+				t.Fatalf("Expected: %d Actual: %d", expected, actual)
+			}
+		}
+		if d.Len() != len(reference) {
+			t.Fatalf("Expected: %d Actual: %d", len(reference), d.Len())
+		}
+	}
+	for i, expected := range reference {
+		if actual, _ := d.At(i); expected != actual {
+			t.Fatalf("Expected: %d Actual: %d", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package fenwick implements Fenwick trees, also called binary indexed trees, which maintain prefix sums of a sequence
+under updates in O(log n) each.  They are smaller and faster than a segment tree, but only support operations that can
+be undone by subtraction, such as sums.  Tree2D extends the same idea to sums over rectangles of a grid.
+*/
+
+package fenwick
@@ -0,0 +1,149 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fenwick
+
+// A Number is a type that can be summed.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 | ~float64
+}
+
+// A Tree is a Fenwick tree over a sequence of n numbers, all initially zero.  Node i, counting from 1, holds the sum of
+// the i&-i values ending at index i-1, so any prefix is the sum of O(log n) nodes.
+type Tree[T Number] struct {
+	nodes []T
+}
+
+// New returns a Tree of n zeros.
+func New[T Number](n int) *Tree[T] {
+	return &Tree[T]{nodes: make([]T, n+1)}
+}
+
+// NewFrom returns a Tree over a copy of values in O(n).
+func NewFrom[T Number](values []T) *Tree[T] {
+	t := &Tree[T]{nodes: make([]T, len(values)+1)}
+	copy(t.nodes[1:], values)
+	for i := 1; i < len(t.nodes); i++ {
+		if parent := i + i&-i; parent < len(t.nodes) {
+			t.nodes[parent] += t.nodes[i]
+		}
+	}
+	return t
+}
+
+// Len returns the number of values in the Tree.
+func (t *Tree[T]) Len() int {
+	return len(t.nodes) - 1
+}
+
+// Add adds delta to the value at index i in O(log n).  It panics if i is out of range.
+func (t *Tree[T]) Add(i int, delta T) {
+	if i < 0 || i >= t.Len() {
+		panic("fenwick: index out of range")
+	}
+	for i++; i < len(t.nodes); i += i & -i {
+		t.nodes[i] += delta
+	}
+}
+
+// PrefixSum returns the sum of the values with indexes in [0, i) in O(log n).  It panics if i is out of range.
+func (t *Tree[T]) PrefixSum(i int) T {
+	if i < 0 || i > t.Len() {
+		panic("fenwick: index out of range")
+	}
+	var sum T
+	for ; i > 0; i -= i & -i {
+		sum += t.nodes[i]
+	}
+	return sum
+}
+
+// RangeSum returns the sum of the values with indexes in [from, to) in O(log n).  It panics if the range is not within
+// the Tree.
+func (t *Tree[T]) RangeSum(from, to int) T {
+	if from > to {
+		panic("fenwick: invalid range")
+	}
+	return t.PrefixSum(to) - t.PrefixSum(from)
+}
+
+// Get returns the value at index i in O(log n).
+func (t *Tree[T]) Get(i int) T {
+	return t.RangeSum(i, i+1)
+}
+
+// Set sets the value at index i in O(log n).
+func (t *Tree[T]) Set(i int, value T) {
+	t.Add(i, value-t.Get(i))
+}
+
+// A Tree2D is a Fenwick tree over a grid of numbers, all initially zero.  Each row of nodes is itself a Fenwick tree
+// over the columns, so Add and RangeSum are O(log rows * log cols).
+type Tree2D[T Number] struct {
+	nodes      [][]T
+	rows, cols int
+}
+
+// New2D returns a Tree2D over a grid of rows by cols zeros.
+func New2D[T Number](rows, cols int) *Tree2D[T] {
+	nodes := make([][]T, rows+1)
+	for i := range nodes {
+		nodes[i] = make([]T, cols+1)
+	}
+	return &Tree2D[T]{nodes: nodes, rows: rows, cols: cols}
+}
+
+// Rows returns the number of rows in the grid.
+func (t *Tree2D[T]) Rows() int {
+	return t.rows
+}
+
+// Cols returns the number of columns in the grid.
+func (t *Tree2D[T]) Cols() int {
+	return t.cols
+}
+
+// Add adds delta to the value at row r and column c.  It panics if the cell is out of range.
+func (t *Tree2D[T]) Add(r, c int, delta T) {
+	if r < 0 || r >= t.rows || c < 0 || c >= t.cols {
+		panic("fenwick: index out of range")
+	}
+	for i := r + 1; i <= t.rows; i += i & -i {
+		for j := c + 1; j <= t.cols; j += j & -j {
+			t.nodes[i][j] += delta
+		}
+	}
+}
+
+// PrefixSum returns the sum of the values in rows [0, r) and columns [0, c).  It panics if the rectangle is not within
+// the grid.
+func (t *Tree2D[T]) PrefixSum(r, c int) T {
+	if r < 0 || r > t.rows || c < 0 || c > t.cols {
+		panic("fenwick: index out of range")
+	}
+	var sum T
+	for i := r; i > 0; i -= i & -i {
+		for j := c; j > 0; j -= j & -j {
+			sum += t.nodes[i][j]
+		}
+	}
+	return sum
+}
+
+// RangeSum returns the sum of the values in rows [r1, r2) and columns [c1, c2), by inclusion and exclusion of four
+// prefix sums.  It panics if the rectangle is not within the grid.
+func (t *Tree2D[T]) RangeSum(r1, c1, r2, c2 int) T {
+	if r1 > r2 || c1 > c2 {
+		panic("fenwick: invalid range")
+	}
+	return t.PrefixSum(r2, c2) - t.PrefixSum(r1, c2) - t.PrefixSum(r2, c1) + t.PrefixSum(r1, c1)
+}
@@ -0,0 +1,97 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fenwick_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/fenwick"
+)
+
+func TestTree(t *testing.T) {
+	tree := fenwick.NewFrom([]int{3, 2, -1, 6, 5, 4, -3, 3, 7, 2, 3})
+	if tree.Len() != 11 {
+		t.Fatalf("Expected: %d Actual: %d", 11, tree.Len())
+	}
+	if sum := tree.PrefixSum(11); sum != 31 {
+		t.Fatalf("Expected: %d Actual: %d", 31, sum)
+	}
+	if sum := tree.RangeSum(3, 7); sum != 12 {
+		t.Fatalf("Expected: %d Actual: %d", 12, sum)
+	}
+	tree.Add(4, 10)
+	tree.Set(0, 0)
+	if sum := tree.PrefixSum(5); sum != 22 {
+		t.Fatalf("Expected: %d Actual: %d", 22, sum)
+	}
+	if value := tree.Get(4); value != 15 {
+		t.Fatalf("Expected: %d Actual: %d", 15, value)
+	}
+}
+
+func TestTree_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 200
+	values := make([]float64, n)
+	tree := fenwick.New[float64](n)
+	for i := 0; i < 2000; i++ {
+		index := r.Intn(n)
+		delta := float64(r.Intn(100) - 50)
+		values[index] += delta
+		tree.Add(index, delta)
+		from := r.Intn(n)
+		to := from + r.Intn(n-from+1)
+		expected := 0.0
+		for _, value := range values[from:to] {
+			expected += value
+		}
+		if actual := tree.RangeSum(from, to); actual != expected {
+			t.Fatalf("Expected: %f Actual: %f", expected, actual)
+		}
+	}
+}
+
+func TestTree2D(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const rows, cols = 20, 30
+	var grid [rows][cols]int
+	tree := fenwick.New2D[int](rows, cols)
+	for i := 0; i < 1000; i++ {
+		row, col, delta := r.Intn(rows), r.Intn(cols), r.Intn(10)
+		grid[row][col] += delta
+		tree.Add(row, col, delta)
+	}
+	for i := 0; i < 200; i++ {
+		r1, c1 := r.Intn(rows), r.Intn(cols)
+		r2, c2 := r1+r.Intn(rows-r1+1), c1+r.Intn(cols-c1+1)
+		expected := 0
+		for row := r1; row < r2; row++ {
+			for col := c1; col < c2; col++ {
+				expected += grid[row][col]
+			}
+		}
+		if actual := tree.RangeSum(r1, c1, r2, c2); actual != expected {
+			t.Fatalf("Expected: %d Actual: %d", expected, actual)
+		}
+	}
+}
+
+func TestTree_OutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected a panic for index %d", 3)
+		}
+	}()
+	fenwick.New[int](3).Add(3, 1)
+}
@@ -0,0 +1,20 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package fibheap implements a Fibonacci heap of priorityqueue Items.  Insert and decrease-key are O(1) amortized and
+ExtractMin is O(log n) amortized, which suits workloads such as shortest-path searches over dense graphs where
+decrease-key dominates.  Heap satisfies priorityqueue.Interface, so it may replace a priorityqueue.Queue without code
+changes.
+*/
+
+package fibheap
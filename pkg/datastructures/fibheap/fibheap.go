@@ -0,0 +1,291 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fibheap
+
+import (
+	"errors"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+// ErrKeyIncreased is returned by DecreaseKey if the new priority is greater than the current one.
+var ErrKeyIncreased = errors.New("fibheap: priority increased")
+
+var _ priorityqueue.Interface = (*Heap)(nil)
+
+// A Heap is a priority queue backed by a Fibonacci heap.  A Heap created by New pops the highest Priority first, like
+// priorityqueue.Queue, while one created by NewMin pops the lowest Priority first.  Items of equal Priority are popped
+// in the order they were pushed.  Moving an Item towards the front through Update or DecreaseKey is O(1) amortized,
+// while moving it towards the back is O(log n) amortized.
+type Heap struct {
+	front    *node // The front of the circular root list, which is always the next Item to pop.
+	nodes    map[*priorityqueue.Item]*node
+	min      bool
+	sequence uint64 // The sequence of the next pushed Item.
+}
+
+// A node is a node of a Fibonacci heap.  Siblings, including the roots, form circular doubly-linked lists.
+type node struct {
+	item        *priorityqueue.Item
+	sequence    uint64
+	parent      *node
+	child       *node // Any one of the children.
+	left, right *node
+	degree      int  // The number of children.
+	mark        bool // Whether the node lost a child since it last became a child itself.
+}
+
+// New returns an empty Heap that pops the highest Priority first.
+func New() *Heap {
+	return &Heap{nodes: make(map[*priorityqueue.Item]*node)}
+}
+
+// NewMin returns an empty Heap that pops the lowest Priority first.
+func NewMin() *Heap {
+	return &Heap{nodes: make(map[*priorityqueue.Item]*node), min: true}
+}
+
+// Len returns the number of Items in the Heap.
+func (h *Heap) Len() int {
+	return len(h.nodes)
+}
+
+// Push adds item to the Heap in O(1).
+func (h *Heap) Push(item *priorityqueue.Item) {
+	x := &node{item: item, sequence: h.sequence}
+	h.sequence++
+	h.nodes[item] = x
+	h.addRoot(x)
+}
+
+// Insert creates an Item with the given value and priority, adds it to the Heap in O(1) and returns it, so that it may
+// later be passed to DecreaseKey, Update or Remove.
+func (h *Heap) Insert(value interface{}, priority float64) *priorityqueue.Item {
+	item := &priorityqueue.Item{Value: value, Priority: priority}
+	h.Push(item)
+	return item
+}
+
+// Pop removes and returns the front Item in O(log n) amortized, or returns priorityqueue.ErrEmpty if the Heap is empty.
+func (h *Heap) Pop() (*priorityqueue.Item, error) {
+	z := h.front
+	if z == nil {
+		return nil, priorityqueue.ErrEmpty
+	}
+	children := siblings(z.child)
+	z.child = nil
+	if z.right == z {
+		h.front = nil
+	} else {
+		h.front = z.right
+		unlink(z)
+	}
+	for _, child := range children {
+		child.parent = nil
+		child.mark = false
+		h.addRoot(child)
+	}
+	h.consolidate()
+	delete(h.nodes, z.item)
+	return z.item, nil
+}
+
+// ExtractMin is Pop under its textbook name.  It removes and returns the front Item, which is the one with the lowest
+// Priority for a Heap created by NewMin.
+func (h *Heap) ExtractMin() (*priorityqueue.Item, error) {
+	return h.Pop()
+}
+
+// Peek returns the front Item without removing it in O(1), or nil if the Heap is empty.
+func (h *Heap) Peek() *priorityqueue.Item {
+	if h.front == nil {
+		return nil
+	}
+	return h.front.item
+}
+
+// Update modifies the Value and Priority of item, which must be in the Heap.
+func (h *Heap) Update(item *priorityqueue.Item, value interface{}, priority float64) {
+	x := h.nodes[item]
+	forward := priority == item.Priority || (priority < item.Priority) == h.min
+	item.Value = value
+	item.Priority = priority
+	if forward {
+		h.promote(x)
+		return
+	}
+	h.remove(x)
+	h.nodes[item] = x
+	x.degree = 0
+	h.addRoot(x)
+}
+
+// DecreaseKey lowers the Priority of item, which must be in the Heap, to priority.  It returns ErrKeyIncreased and
+// leaves item unchanged if priority is greater than the current Priority.  This is O(1) amortized for a Heap created by
+// NewMin.
+func (h *Heap) DecreaseKey(item *priorityqueue.Item, priority float64) error {
+	if priority > item.Priority {
+		return ErrKeyIncreased
+	}
+	h.Update(item, item.Value, priority)
+	return nil
+}
+
+// Remove removes item from the Heap in O(log n) amortized.  It returns false if item is not in the Heap.
+func (h *Heap) Remove(item *priorityqueue.Item) bool {
+	x, ok := h.nodes[item]
+	if !ok {
+		return false
+	}
+	h.remove(x)
+	return true
+}
+
+// before reports whether a is popped before b.
+func (h *Heap) before(a, b *node) bool {
+	if a.item.Priority == b.item.Priority {
+		return a.sequence < b.sequence
+	}
+	return (a.item.Priority < b.item.Priority) == h.min
+}
+
+// addRoot adds the detached node x to the root list, moving the front to x if it is popped first.
+func (h *Heap) addRoot(x *node) {
+	if h.front == nil {
+		x.left, x.right = x, x
+		h.front = x
+		return
+	}
+	splice(h.front, x)
+	if h.before(x, h.front) {
+		h.front = x
+	}
+}
+
+// promote restores the heap order after x moved towards the front, by cutting it from its parent if need be.  Roots
+// are left in place, apart from updating the front.
+func (h *Heap) promote(x *node) {
+	if p := x.parent; p != nil && h.before(x, p) {
+		h.cut(x)
+		h.cascadingCut(p)
+	}
+	if x.parent == nil && h.before(x, h.front) {
+		h.front = x
+	}
+}
+
+// remove pops x by cutting it from its parent, if any, and making it the front.
+func (h *Heap) remove(x *node) {
+	if p := x.parent; p != nil {
+		h.cut(x)
+		h.cascadingCut(p)
+	}
+	h.front = x
+	h.Pop()
+}
+
+// cut moves x from the child list of its parent to the root list.
+func (h *Heap) cut(x *node) {
+	p := x.parent
+	if x.right == x {
+		p.child = nil
+	} else {
+		if p.child == x {
+			p.child = x.right
+		}
+		unlink(x)
+	}
+	p.degree--
+	x.parent = nil
+	x.mark = false
+	h.addRoot(x)
+}
+
+// cascadingCut cuts y from its parent if y already lost a child, and marks it otherwise.
+func (h *Heap) cascadingCut(y *node) {
+	for y.parent != nil {
+		if !y.mark {
+			y.mark = true
+			return
+		}
+		p := y.parent
+		h.cut(y)
+		y = p
+	}
+}
+
+// consolidate links roots of equal degree until all roots have distinct degrees, then rebuilds the root list.
+func (h *Heap) consolidate() {
+	var byDegree []*node
+	for _, x := range siblings(h.front) {
+		x.left, x.right = x, x
+		for x.degree < len(byDegree) && byDegree[x.degree] != nil {
+			y := byDegree[x.degree]
+			byDegree[x.degree] = nil
+			if h.before(y, x) {
+				x, y = y, x
+			}
+			h.link(y, x)
+		}
+		for x.degree >= len(byDegree) {
+			byDegree = append(byDegree, nil)
+		}
+		byDegree[x.degree] = x
+	}
+	h.front = nil
+	for _, x := range byDegree {
+		if x != nil {
+			h.addRoot(x)
+		}
+	}
+}
+
+// link makes the detached root y a child of x.
+func (h *Heap) link(y, x *node) {
+	y.parent = x
+	y.mark = false
+	if x.child == nil {
+		y.left, y.right = y, y
+		x.child = y
+	} else {
+		splice(x.child, y)
+	}
+	x.degree++
+}
+
+// siblings returns x and its siblings, or nil if x is nil.
+func siblings(x *node) []*node {
+	if x == nil {
+		return nil
+	}
+	result := []*node{x}
+	for y := x.right; y != x; y = y.right {
+		result = append(result, y)
+	}
+	return result
+}
+
+// splice inserts the detached node x to the left of at.
+func splice(at, x *node) {
+	x.right = at
+	x.left = at.left
+	at.left.right = x
+	at.left = x
+}
+
+// unlink removes x from its list of siblings.
+func unlink(x *node) {
+	x.left.right = x.right
+	x.right.left = x.left
+	x.left, x.right = x, x
+}
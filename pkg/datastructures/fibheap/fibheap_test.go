@@ -0,0 +1,99 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fibheap_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/fibheap"
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestHeap_DecreaseKey(t *testing.T) {
+	h := fibheap.NewMin()
+	apple := h.Insert("apple", 10.0)
+	h.Insert("banana", 5.0)
+	carrot := h.Insert("carrot", 11.0)
+	h.Insert("danish", 0.0)
+	if err := h.DecreaseKey(apple, 12.0); err != fibheap.ErrKeyIncreased {
+		t.Fatalf("Expected: %s Actual: %v", fibheap.ErrKeyIncreased, err)
+	}
+	if item, _ := h.ExtractMin(); item.Value != "danish" {
+		t.Fatalf("Expected: %s Actual: %s", "danish", item.Value)
+	}
+	if err := h.DecreaseKey(carrot, 1.0); err != nil {
+		t.Fatalf("Expected no error Actual: %s", err)
+	}
+	if item := h.Peek(); item != carrot {
+		t.Fatalf("Expected: %s Actual: %s", carrot.Value, item.Value)
+	}
+	expectedPopOrder := []string{"carrot", "banana", "apple"}
+	for _, expected := range expectedPopOrder {
+		item, _ := h.ExtractMin()
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+	if _, err := h.ExtractMin(); err != priorityqueue.ErrEmpty {
+		t.Fatalf("Expected: %s Actual: %v", priorityqueue.ErrEmpty, err)
+	}
+}
+
+// TestHeap_Random checks a long random sequence of operations against a PriorityQueue with the same ordering.
+func TestHeap_Random(t *testing.T) {
+	for _, min := range []bool{false, true} {
+		r := rand.New(rand.NewSource(1))
+		h, reference := fibheap.New(), &priorityqueue.PriorityQueue{}
+		if min {
+			h, reference = fibheap.NewMin(), priorityqueue.NewMin()
+		}
+		var items []*priorityqueue.Item
+		for i := 0; i < 5000; i++ {
+			priority := float64(r.Intn(100))
+			items = append(items, h.Insert(i, priority))
+			reference.Enqueue(&priorityqueue.Item{Value: i, Priority: priority})
+			switch r.Intn(4) {
+			case 0:
+				expected, _ := reference.Dequeue()
+				actual, _ := h.Pop()
+				if expected.Value != actual.Value {
+					t.Fatalf("Expected: %v Actual: %v", expected.Value, actual.Value)
+				}
+			case 1:
+				item := items[r.Intn(len(items))]
+				if h.Remove(item) {
+					removed, _ := reference.ItemAt(reference.IndexOf(item.Value))
+					reference.Remove(removed)
+				}
+			case 2:
+				item := items[r.Intn(len(items))]
+				if reference.IndexOf(item.Value) >= 0 {
+					priority := float64(r.Intn(100))
+					h.Update(item, item.Value, priority)
+					reference.UpdateByValue(item.Value, priority)
+				}
+			}
+		}
+		if h.Len() != reference.Len() {
+			t.Fatalf("Expected: %d Actual: %d", reference.Len(), h.Len())
+		}
+		for reference.Len() > 0 {
+			expected, _ := reference.Dequeue()
+			actual, _ := h.Pop()
+			if expected.Value != actual.Value {
+				t.Fatalf("Expected: %v Actual: %v", expected.Value, actual.Value)
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package graph implements a generic Graph of comparable node IDs, stored as adjacency lists, which may be directed or
+undirected and whose edges carry weights.  Nodes and the neighbors of each node are iterated in the order they were
+added, so traversals and the algorithms built on them are deterministic.
+*/
+
+package graph
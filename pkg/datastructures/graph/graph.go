@@ -0,0 +1,260 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"iter"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/orderedmap"
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/queue"
+)
+
+// An Edge is an edge of a Graph.  In an undirected Graph, From and To are interchangeable.
+type Edge[N comparable] struct {
+	From, To N
+	Weight   float64
+}
+
+// A Graph is a set of nodes and weighted edges between them, held as adjacency lists.  AddNode, AddEdge, RemoveEdge and
+// HasEdge are O(1), and RemoveNode is O(n).  An undirected Graph stores each edge in the adjacency lists of both of
+// its ends.
+type Graph[N comparable] struct {
+	directed bool
+	edges    int
+	adjacent orderedmap.OrderedMap[N, *orderedmap.OrderedMap[N, float64]] // From each node to its neighbors and weights.
+}
+
+// NewDirected returns an empty directed Graph.
+func NewDirected[N comparable]() *Graph[N] {
+	return &Graph[N]{directed: true}
+}
+
+// NewUndirected returns an empty undirected Graph.
+func NewUndirected[N comparable]() *Graph[N] {
+	return &Graph[N]{}
+}
+
+// Directed reports whether the Graph is directed.
+func (g *Graph[N]) Directed() bool {
+	return g.directed
+}
+
+// NodeCount returns the number of nodes in the Graph.
+func (g *Graph[N]) NodeCount() int {
+	return g.adjacent.Len()
+}
+
+// EdgeCount returns the number of edges in the Graph, counting each undirected edge once.
+func (g *Graph[N]) EdgeCount() int {
+	return g.edges
+}
+
+// AddNode adds node to the Graph.  It returns false if node was already in the Graph.
+func (g *Graph[N]) AddNode(node N) bool {
+	if g.HasNode(node) {
+		return false
+	}
+	g.adjacent.Set(node, orderedmap.New[N, float64]())
+	return true
+}
+
+// HasNode reports whether node is in the Graph.
+func (g *Graph[N]) HasNode(node N) bool {
+	_, ok := g.adjacent.Get(node)
+	return ok
+}
+
+// RemoveNode removes node and every edge to or from it.  It returns false if node was not in the Graph.
+func (g *Graph[N]) RemoveNode(node N) bool {
+	neighbors, ok := g.adjacent.Get(node)
+	if !ok {
+		return false
+	}
+	if g.directed {
+		g.edges -= neighbors.Len()
+		for _, other := range g.adjacent.All() {
+			if other.Delete(node) {
+				g.edges--
+			}
+		}
+	} else {
+		for neighbor := range neighbors.Keys() {
+			g.RemoveEdge(node, neighbor)
+		}
+	}
+	g.adjacent.Delete(node)
+	return true
+}
+
+// AddEdge adds an edge of weight 1 from one node to another, adding either node if it is not already in the Graph.  It
+// returns false if the edge was already in the Graph, in which case its weight is set to 1.
+func (g *Graph[N]) AddEdge(from, to N) bool {
+	return g.AddWeightedEdge(from, to, 1)
+}
+
+// AddWeightedEdge adds an edge of the given weight from one node to another, adding either node if it is not already
+// in the Graph.  It returns false if the edge was already in the Graph, in which case its weight is updated.
+func (g *Graph[N]) AddWeightedEdge(from, to N, weight float64) bool {
+	g.AddNode(from)
+	g.AddNode(to)
+	neighbors, _ := g.adjacent.Get(from)
+	added := neighbors.Set(to, weight)
+	if !g.directed {
+		neighbors, _ = g.adjacent.Get(to)
+		neighbors.Set(from, weight)
+	}
+	if added {
+		g.edges++
+	}
+	return added
+}
+
+// RemoveEdge removes the edge from one node to another.  It returns false if there was no such edge.
+func (g *Graph[N]) RemoveEdge(from, to N) bool {
+	neighbors, ok := g.adjacent.Get(from)
+	if !ok || !neighbors.Delete(to) {
+		return false
+	}
+	if !g.directed {
+		neighbors, _ = g.adjacent.Get(to)
+		neighbors.Delete(from)
+	}
+	g.edges--
+	return true
+}
+
+// HasEdge reports whether there is an edge from one node to another.
+func (g *Graph[N]) HasEdge(from, to N) bool {
+	_, ok := g.Weight(from, to)
+	return ok
+}
+
+// Weight returns the weight of the edge from one node to another.  The second return value is false if there is no
+// such edge.
+func (g *Graph[N]) Weight(from, to N) (float64, bool) {
+	neighbors, ok := g.adjacent.Get(from)
+	if !ok {
+		return 0, false
+	}
+	return neighbors.Get(to)
+}
+
+// Nodes returns an iterator over the nodes of the Graph in the order they were added.
+func (g *Graph[N]) Nodes() iter.Seq[N] {
+	return g.adjacent.Keys()
+}
+
+// Neighbors returns an iterator over the nodes that node has an edge to, and the weights of those edges, in the order
+// the edges were added.  The Graph must not be modified during iteration.
+func (g *Graph[N]) Neighbors(node N) iter.Seq2[N, float64] {
+	return func(yield func(N, float64) bool) {
+		if neighbors, ok := g.adjacent.Get(node); ok {
+			for neighbor, weight := range neighbors.All() {
+				if !yield(neighbor, weight) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Edges returns an iterator over the edges of the Graph, grouped by the node they leave.  Each undirected edge is
+// yielded once, from the end that was added to the Graph first.  The Graph must not be modified during iteration.
+func (g *Graph[N]) Edges() iter.Seq[Edge[N]] {
+	return func(yield func(Edge[N]) bool) {
+		seen := make(map[N]bool)
+		for from, neighbors := range g.adjacent.All() {
+			seen[from] = true
+			for to, weight := range neighbors.All() {
+				if !g.directed && seen[to] && to != from {
+					continue
+				}
+				if !yield(Edge[N]{From: from, To: to, Weight: weight}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// BFS visits the nodes reachable from start in breadth-first order, passing each node and its depth, the number of
+// edges on a shortest path from start, to visit.  It stops early if visit returns false.  Nothing is visited if start
+// is not in the Graph.
+func (g *Graph[N]) BFS(start N, visit func(node N, depth int) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+	type step struct {
+		node  N
+		depth int
+	}
+	visited := map[N]bool{start: true}
+	var frontier queue.Queue[step]
+	frontier.Enqueue(step{start, 0})
+	for !frontier.IsEmpty() {
+		s, _ := frontier.Dequeue()
+		if !visit(s.node, s.depth) {
+			return
+		}
+		for neighbor := range g.Neighbors(s.node) {
+			if !visited[neighbor] {
+				visited[neighbor] = true
+				frontier.Enqueue(step{neighbor, s.depth + 1})
+			}
+		}
+	}
+}
+
+// DFS visits the nodes reachable from start in depth-first pre-order, following the edges of each node in the order
+// they were added, as a recursive search would.  It stops early if visit returns false.  Nothing is visited if start is
+// not in the Graph.
+func (g *Graph[N]) DFS(start N, visit func(node N) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+	g.dfs(start, make(map[N]bool), visit)
+}
+
+// dfs visits node and then the unvisited nodes reachable from it.  It returns false if visit asked to stop.  The
+// search keeps an explicit stack rather than recursing, so deep graphs cannot overflow the goroutine stack.
+func (g *Graph[N]) dfs(node N, visited map[N]bool, visit func(N) bool) bool {
+	var stack [][]N // The neighbors that remain to be searched of each node on the current path.
+	push := func(node N) bool {
+		visited[node] = true
+		if !visit(node) {
+			return false
+		}
+		var neighbors []N
+		for neighbor := range g.Neighbors(node) {
+			neighbors = append(neighbors, neighbor)
+		}
+		stack = append(stack, neighbors)
+		return true
+	}
+	if !push(node) {
+		return false
+	}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if len(*top) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		neighbor := (*top)[0]
+		*top = (*top)[1:]
+		if !visited[neighbor] && !push(neighbor) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/graph"
+)
+
+// newDiamond returns the directed Graph a->b, a->c, b->d, c->d, d->e.
+func newDiamond() *graph.Graph[string] {
+	g := graph.NewDirected[string]()
+	g.AddEdge("a", "b")
+	g.AddEdge("a", "c")
+	g.AddEdge("b", "d")
+	g.AddEdge("c", "d")
+	g.AddEdge("d", "e")
+	return g
+}
+
+func TestGraph_Directed(t *testing.T) {
+	g := newDiamond()
+	if g.NodeCount() != 5 || g.EdgeCount() != 5 {
+		t.Fatalf("Expected: %d nodes and %d edges Actual: %d nodes and %d edges", 5, 5, g.NodeCount(), g.EdgeCount())
+	}
+	if !g.HasEdge("a", "b") || g.HasEdge("b", "a") {
+		t.Fatalf("Expected edges to be directed")
+	}
+	if g.AddWeightedEdge("a", "b", 2.5) {
+		t.Fatalf("Expected the edge %s->%s to exist", "a", "b")
+	}
+	if weight, _ := g.Weight("a", "b"); weight != 2.5 {
+		t.Fatalf("Expected: %f Actual: %f", 2.5, weight)
+	}
+	if !g.RemoveEdge("a", "b") || g.RemoveEdge("a", "b") || g.EdgeCount() != 4 {
+		t.Fatalf("Expected to remove %s->%s once", "a", "b")
+	}
+	if !g.RemoveNode("d") || g.RemoveNode("d") {
+		t.Fatalf("Expected to remove %s once", "d")
+	}
+	if g.EdgeCount() != 1 || g.HasNode("d") {
+		t.Fatalf("Expected: %d Actual: %d", 1, g.EdgeCount())
+	}
+	if expected, actual := []string{"a", "b", "c", "e"}, slices.Collect(g.Nodes()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
+
+func TestGraph_Undirected(t *testing.T) {
+	g := graph.NewUndirected[int]()
+	g.AddWeightedEdge(1, 2, 3)
+	g.AddWeightedEdge(3, 1, 4)
+	g.AddEdge(2, 2)
+	if g.AddEdge(2, 1) || g.EdgeCount() != 3 {
+		t.Fatalf("Expected %d-%d to be the same edge as %d-%d", 2, 1, 1, 2)
+	}
+	if weight, _ := g.Weight(1, 3); weight != 4 {
+		t.Fatalf("Expected: %f Actual: %f", 4.0, weight)
+	}
+	expected := []graph.Edge[int]{{From: 1, To: 2, Weight: 1}, {From: 1, To: 3, Weight: 4}, {From: 2, To: 2, Weight: 1}}
+	if actual := slices.Collect(g.Edges()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	var neighbors []int
+	for neighbor := range g.Neighbors(1) {
+		neighbors = append(neighbors, neighbor)
+	}
+	if !slices.Equal([]int{2, 3}, neighbors) {
+		t.Fatalf("Expected: %v Actual: %v", []int{2, 3}, neighbors)
+	}
+	g.RemoveNode(2)
+	if g.EdgeCount() != 1 || g.HasEdge(1, 2) {
+		t.Fatalf("Expected: %d Actual: %d", 1, g.EdgeCount())
+	}
+}
+
+func TestGraph_BFS(t *testing.T) {
+	g := newDiamond()
+	var nodes []string
+	var depths []int
+	g.BFS("a", func(node string, depth int) bool {
+		nodes = append(nodes, node)
+		depths = append(depths, depth)
+		return true
+	})
+	if expected := []string{"a", "b", "c", "d", "e"}; !slices.Equal(expected, nodes) {
+		t.Fatalf("Expected: %v Actual: %v", expected, nodes)
+	}
+	if expected := []int{0, 1, 1, 2, 3}; !slices.Equal(expected, depths) {
+		t.Fatalf("Expected: %v Actual: %v", expected, depths)
+	}
+	nodes = nil
+	g.BFS("a", func(node string, depth int) bool {
+		nodes = append(nodes, node)
+		return depth < 1
+	})
+	if expected := []string{"a", "b"}; !slices.Equal(expected, nodes) {
+		t.Fatalf("Expected: %v Actual: %v", expected, nodes)
+	}
+}
+
+func TestGraph_DFS(t *testing.T) {
+	g := newDiamond()
+	var nodes []string
+	g.DFS("a", func(node string) bool {
+		nodes = append(nodes, node)
+		return true
+	})
+	if expected := []string{"a", "b", "d", "e", "c"}; !slices.Equal(expected, nodes) {
+		t.Fatalf("Expected: %v Actual: %v", expected, nodes)
+	}
+	nodes = nil
+	g.DFS("c", func(node string) bool {
+		nodes = append(nodes, node)
+		return node != "d"
+	})
+	if expected := []string{"c", "d"}; !slices.Equal(expected, nodes) {
+		t.Fatalf("Expected: %v Actual: %v", expected, nodes)
+	}
+	g.DFS("z", func(node string) bool {
+		t.Fatalf("Expected nothing to be visited")
+		return false
+	})
+}
@@ -0,0 +1,88 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue/keyed"
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/unionfind"
+)
+
+// ErrDirected is returned by algorithms that need an undirected Graph.
+var ErrDirected = errors.New("graph: graph is directed")
+
+// Prim returns the edges of a minimum spanning forest of an undirected Graph, a minimum spanning tree of each of its
+// connected components, and their total weight.  It grows each tree from its first node by repeatedly adding the
+// lightest edge to a node outside it, keeping the lightest known edge to each such node in a keyed.PriorityQueue, in
+// O((n + m) log n).  It returns ErrDirected if the Graph is directed.
+func (g *Graph[N]) Prim() ([]Edge[N], float64, error) {
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+	var edges []Edge[N]
+	var total float64
+	inTree := make(map[N]bool, g.NodeCount())
+	lightest := make(map[N]Edge[N]) // The lightest known edge from the tree to each node in the frontier.
+	frontier := keyed.NewMin[N, float64]()
+	for root := range g.Nodes() {
+		if inTree[root] {
+			continue
+		}
+		frontier.Upsert(root, 0)
+		for frontier.Len() > 0 {
+			node, _, _ := frontier.Pop()
+			inTree[node] = true
+			if edge, ok := lightest[node]; ok {
+				edges = append(edges, edge)
+				total += edge.Weight
+				delete(lightest, node)
+			}
+			for neighbor, weight := range g.Neighbors(node) {
+				if inTree[neighbor] {
+					continue
+				}
+				if known, ok := frontier.Get(neighbor); ok && known <= weight {
+					continue
+				}
+				frontier.Upsert(neighbor, weight)
+				lightest[neighbor] = Edge[N]{From: node, To: neighbor, Weight: weight}
+			}
+		}
+	}
+	return edges, total, nil
+}
+
+// Kruskal returns the edges of a minimum spanning forest of an undirected Graph and their total weight, like Prim.  It
+// considers the edges from lightest to heaviest, keeping each one that joins two components not yet connected, which
+// a unionfind.Keyed tracks, in O(m log m).  Edges of equal weight are considered in the order of Edges.  It returns
+// ErrDirected if the Graph is directed.
+func (g *Graph[N]) Kruskal() ([]Edge[N], float64, error) {
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+	candidates := slices.Collect(g.Edges())
+	slices.SortStableFunc(candidates, func(a, b Edge[N]) int { return cmp.Compare(a.Weight, b.Weight) })
+	var edges []Edge[N]
+	var total float64
+	var components unionfind.Keyed[N]
+	for _, edge := range candidates {
+		if components.Union(edge.From, edge.To) {
+			edges = append(edges, edge)
+			total += edge.Weight
+		}
+	}
+	return edges, total, nil
+}
@@ -0,0 +1,66 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/graph"
+)
+
+func TestGraph_MinimumSpanningTree(t *testing.T) {
+	g := graph.NewUndirected[string]()
+	g.AddWeightedEdge("a", "b", 4)
+	g.AddWeightedEdge("a", "c", 1)
+	g.AddWeightedEdge("b", "c", 2)
+	g.AddWeightedEdge("b", "d", 5)
+	g.AddWeightedEdge("c", "d", 8)
+	g.AddWeightedEdge("d", "e", 3)
+	g.AddWeightedEdge("x", "y", 7) // a second component
+	algorithms := map[string]func() ([]graph.Edge[string], float64, error){"Prim": g.Prim, "Kruskal": g.Kruskal}
+	for name, mst := range algorithms {
+		edges, total, err := mst()
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %s", name, err)
+		}
+		if len(edges) != 5 || total != 1+2+5+3+7 {
+			t.Fatalf("%s: Expected: %d edges weighing %d Actual: %d edges weighing %f", name, 5, 18, len(edges), total)
+		}
+		for _, edge := range edges {
+			if weight, ok := g.Weight(edge.From, edge.To); !ok || weight != edge.Weight {
+				t.Fatalf("%s: Expected %v to be an edge of the Graph", name, edge)
+			}
+		}
+	}
+	if _, _, err := graph.NewDirected[int]().Prim(); !errors.Is(err, graph.ErrDirected) {
+		t.Fatalf("Expected: %s Actual: %v", graph.ErrDirected, err)
+	}
+	if _, _, err := graph.NewDirected[int]().Kruskal(); !errors.Is(err, graph.ErrDirected) {
+		t.Fatalf("Expected: %s Actual: %v", graph.ErrDirected, err)
+	}
+}
+
+func TestGraph_MinimumSpanningTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := graph.NewUndirected[int]()
+	for i := 0; i < 500; i++ {
+		g.AddWeightedEdge(r.Intn(100), r.Intn(100), float64(r.Intn(50)))
+	}
+	primEdges, primTotal, _ := g.Prim()
+	kruskalEdges, kruskalTotal, _ := g.Kruskal()
+	if primTotal != kruskalTotal || len(primEdges) != len(kruskalEdges) {
+		t.Fatalf("Expected: %f Actual: %f", kruskalTotal, primTotal)
+	}
+}
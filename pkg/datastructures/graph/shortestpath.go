@@ -0,0 +1,91 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue/keyed"
+)
+
+// ErrNodeNotFound is wrapped by the errors returned when a node passed to an algorithm is not in the Graph.
+var ErrNodeNotFound = errors.New("graph: node not found")
+
+// ErrNoPath is returned when there is no path between two nodes.
+var ErrNoPath = errors.New("graph: no path")
+
+// ErrNegativeWeight is wrapped by the errors returned when a shortest path search meets an edge of negative weight,
+// which Dijkstra's algorithm and A* cannot handle.
+var ErrNegativeWeight = errors.New("graph: negative edge weight")
+
+// Dijkstra returns a path of least total weight from one node to another, as the nodes along it from first to last, and
+// its total weight.  It runs in O((n + m) log n) using a keyed.PriorityQueue of tentative distances whose keys are
+// decreased as shorter paths are found.  It returns ErrNoPath if to is unreachable from from.
+func (g *Graph[N]) Dijkstra(from, to N) ([]N, float64, error) {
+	return g.AStar(from, to, func(N) float64 { return 0 })
+}
+
+// AStar returns a path of least total weight from one node to another and its total weight, like Dijkstra, but explores
+// the nodes in order of their distance from from plus heuristic(node), an estimate of their remaining distance to to.
+// A good heuristic steers the search towards to and explores far fewer nodes.  The path is a shortest path as long as
+// the heuristic is consistent:  it never overestimates the remaining distance, and never falls by more than the weight
+// of an edge as the search crosses it.  A heuristic of zero makes AStar Dijkstra.  It returns ErrNoPath if to is
+// unreachable from from.
+func (g *Graph[N]) AStar(from, to N, heuristic func(node N) float64) ([]N, float64, error) {
+	for _, node := range [2]N{from, to} {
+		if !g.HasNode(node) {
+			return nil, 0, fmt.Errorf("%w: %v", ErrNodeNotFound, node)
+		}
+	}
+	distance := map[N]float64{from: 0}
+	previous := make(map[N]N)
+	settled := make(map[N]bool)
+	frontier := keyed.NewMin[N, float64]()
+	frontier.Upsert(from, heuristic(from))
+	for frontier.Len() > 0 {
+		node, _, _ := frontier.Pop()
+		if node == to {
+			return path(previous, from, to), distance[to], nil
+		}
+		settled[node] = true
+		for neighbor, weight := range g.Neighbors(node) {
+			if weight < 0 {
+				return nil, 0, fmt.Errorf("%w: %v to %v", ErrNegativeWeight, node, neighbor)
+			}
+			if settled[neighbor] {
+				continue
+			}
+			d := distance[node] + weight
+			if known, ok := distance[neighbor]; ok && known <= d {
+				continue
+			}
+			distance[neighbor] = d
+			previous[neighbor] = node
+			frontier.Upsert(neighbor, d+heuristic(neighbor))
+		}
+	}
+	return nil, 0, ErrNoPath
+}
+
+// path follows previous back from to to from and returns the nodes visited in order from from.
+func path[N comparable](previous map[N]N, from, to N) []N {
+	nodes := []N{to}
+	for node := to; node != from; {
+		node = previous[node]
+		nodes = append(nodes, node)
+	}
+	slices.Reverse(nodes)
+	return nodes
+}
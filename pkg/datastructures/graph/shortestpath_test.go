@@ -0,0 +1,93 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/graph"
+)
+
+func TestGraph_Dijkstra(t *testing.T) {
+	g := graph.NewDirected[string]()
+	g.AddWeightedEdge("s", "a", 7)
+	g.AddWeightedEdge("s", "b", 2)
+	g.AddWeightedEdge("b", "a", 3)
+	g.AddWeightedEdge("a", "t", 1)
+	g.AddWeightedEdge("b", "t", 8)
+	g.AddNode("island")
+	nodes, cost, err := g.Dijkstra("s", "t")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if expected := []string{"s", "b", "a", "t"}; !slices.Equal(expected, nodes) || cost != 6 {
+		t.Fatalf("Expected: %v at %d Actual: %v at %f", expected, 6, nodes, cost)
+	}
+	if nodes, cost, _ := g.Dijkstra("s", "s"); !slices.Equal([]string{"s"}, nodes) || cost != 0 {
+		t.Fatalf("Expected: %v Actual: %v", []string{"s"}, nodes)
+	}
+	if _, _, err := g.Dijkstra("t", "s"); !errors.Is(err, graph.ErrNoPath) {
+		t.Fatalf("Expected: %s Actual: %v", graph.ErrNoPath, err)
+	}
+	if _, _, err := g.Dijkstra("s", "nowhere"); !errors.Is(err, graph.ErrNodeNotFound) {
+		t.Fatalf("Expected: %s Actual: %v", graph.ErrNodeNotFound, err)
+	}
+	g.AddWeightedEdge("s", "c", -1)
+	if _, _, err := g.Dijkstra("s", "t"); !errors.Is(err, graph.ErrNegativeWeight) {
+		t.Fatalf("Expected: %s Actual: %v", graph.ErrNegativeWeight, err)
+	}
+}
+
+// cell is a square of a grid.
+type cell struct {
+	x, y int
+}
+
+func TestGraph_AStar(t *testing.T) {
+	// A 20x20 grid with a wall across x = 10 that is open only at y = 19.
+	g := graph.NewUndirected[cell]()
+	for x := 0; x < 20; x++ {
+		for y := 0; y < 20; y++ {
+			if x == 10 && y != 19 {
+				continue
+			}
+			if x > 0 && (x != 11 || y == 19) {
+				g.AddEdge(cell{x - 1, y}, cell{x, y})
+			}
+			if y > 0 && x != 10 {
+				g.AddEdge(cell{x, y - 1}, cell{x, y})
+			}
+		}
+	}
+	start, goal := cell{0, 0}, cell{19, 0}
+	manhattan := func(c cell) float64 { return math.Abs(float64(goal.x-c.x)) + math.Abs(float64(goal.y-c.y)) }
+	nodes, cost, err := g.AStar(start, goal, manhattan)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	_, dijkstraCost, _ := g.Dijkstra(start, goal)
+	if cost != 19+2*19 || cost != dijkstraCost {
+		t.Fatalf("Expected: %d Actual: %f", 19+2*19, cost)
+	}
+	if len(nodes) != int(cost)+1 || nodes[0] != start || nodes[len(nodes)-1] != goal {
+		t.Fatalf("Expected a path of %d nodes from %v to %v Actual: %v", int(cost)+1, start, goal, nodes)
+	}
+	for i := 1; i < len(nodes); i++ {
+		if !g.HasEdge(nodes[i-1], nodes[i]) {
+			t.Fatalf("Expected an edge from %v to %v", nodes[i-1], nodes[i])
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue/keyed"
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/unionfind"
+)
+
+// ErrCycle is wrapped by a CycleError.
+var ErrCycle = errors.New("graph: cycle")
+
+// ErrUndirected is returned by algorithms that need a directed Graph.
+var ErrUndirected = errors.New("graph: graph is undirected")
+
+// A CycleError is returned by TopoSort when the Graph has a cycle, which it describes.
+type CycleError[N comparable] struct {
+	// Cycle holds the nodes of the cycle in order, starting and ending with the same node.
+	Cycle []N
+}
+
+// Error returns a description of the cycle, such as "graph: cycle a -> b -> a".
+func (e *CycleError[N]) Error() string {
+	nodes := make([]string, len(e.Cycle))
+	for i, node := range e.Cycle {
+		nodes[i] = fmt.Sprint(node)
+	}
+	return ErrCycle.Error() + " " + strings.Join(nodes, " -> ")
+}
+
+// Unwrap returns ErrCycle, so that errors.Is(err, ErrCycle) holds for a CycleError.
+func (e *CycleError[N]) Unwrap() error {
+	return ErrCycle
+}
+
+// TopoSort returns the nodes of a directed Graph in topological order, in which every edge leads from an earlier node to
+// a later one, using Kahn's algorithm in O((n + m) log n).  Among the nodes that may come next it prefers the one added
+// to the Graph first, which a keyed.PriorityQueue of the ready nodes by their position in Nodes provides.  It returns a *CycleError describing a cycle if the Graph has one, since then no such order exists, and
+// ErrUndirected if the Graph is undirected.
+func (g *Graph[N]) TopoSort() ([]N, error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+	indegree := make(map[N]int, g.NodeCount())
+	for edge := range g.Edges() {
+		indegree[edge.To]++
+	}
+	position := make(map[N]int, g.NodeCount())
+	ready := keyed.NewMin[N, int]()
+	for node := range g.Nodes() {
+		position[node] = len(position)
+		if indegree[node] == 0 {
+			ready.Upsert(node, position[node])
+		}
+	}
+	order := make([]N, 0, g.NodeCount())
+	for ready.Len() > 0 {
+		node, _, _ := ready.Pop()
+		order = append(order, node)
+		for neighbor := range g.Neighbors(node) {
+			if indegree[neighbor]--; indegree[neighbor] == 0 {
+				ready.Upsert(neighbor, position[neighbor])
+			}
+		}
+	}
+	if len(order) < g.NodeCount() {
+		return nil, &CycleError[N]{Cycle: g.findCycle()}
+	}
+	return order, nil
+}
+
+// HasCycle reports whether the Graph has a cycle in O(n + m).  A directed Graph is searched depth-first for an edge back
+// to a node on the current path.  In an undirected Graph an edge between two nodes that are already connected closes a
+// cycle, which a unionfind.Keyed detects; a self-loop is a cycle either way.
+func (g *Graph[N]) HasCycle() bool {
+	if g.directed {
+		return g.findCycle() != nil
+	}
+	var components unionfind.Keyed[N]
+	for edge := range g.Edges() {
+		if !components.Union(edge.From, edge.To) {
+			return true
+		}
+	}
+	return false
+}
+
+// findCycle returns the nodes of a cycle in a directed Graph, starting and ending with the same node, or nil if the
+// Graph is acyclic.
+func (g *Graph[N]) findCycle() []N {
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[N]int, g.NodeCount())
+	for root := range g.Nodes() {
+		if state[root] != unvisited {
+			continue
+		}
+		var path []N    // The nodes on the current path from root.
+		var stack [][]N // The neighbors that remain to be searched of each node on path.
+		push := func(node N) {
+			state[node] = onPath
+			path = append(path, node)
+			var neighbors []N
+			for neighbor := range g.Neighbors(node) {
+				neighbors = append(neighbors, neighbor)
+			}
+			stack = append(stack, neighbors)
+		}
+		push(root)
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if len(*top) == 0 {
+				state[path[len(path)-1]] = done
+				path, stack = path[:len(path)-1], stack[:len(stack)-1]
+				continue
+			}
+			neighbor := (*top)[0]
+			*top = (*top)[1:]
+			switch state[neighbor] {
+			case unvisited:
+				push(neighbor)
+			case onPath:
+				for i, node := range path {
+					if node == neighbor {
+						return append(path[i:], neighbor)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/graph"
+)
+
+func TestGraph_TopoSort(t *testing.T) {
+	g := graph.NewDirected[string]()
+	g.AddNode("app")
+	g.AddEdge("lib", "app")
+	g.AddEdge("core", "lib")
+	g.AddEdge("core", "util")
+	g.AddEdge("util", "app")
+	order, err := g.TopoSort()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if expected := []string{"core", "lib", "util", "app"}; !slices.Equal(expected, order) {
+		t.Fatalf("Expected: %v Actual: %v", expected, order)
+	}
+	if g.HasCycle() {
+		t.Fatalf("Expected no cycle")
+	}
+	g.AddEdge("app", "core")
+	if !g.HasCycle() {
+		t.Fatalf("Expected a cycle")
+	}
+	_, err = g.TopoSort()
+	var cycleErr *graph.CycleError[string]
+	if !errors.As(err, &cycleErr) || !errors.Is(err, graph.ErrCycle) {
+		t.Fatalf("Expected: %s Actual: %v", graph.ErrCycle, err)
+	}
+	if expected := "graph: cycle app -> core -> lib -> app"; err.Error() != expected {
+		t.Fatalf("Expected: %s Actual: %s", expected, err)
+	}
+	cycle := cycleErr.Cycle
+	for i := 1; i < len(cycle); i++ {
+		if !g.HasEdge(cycle[i-1], cycle[i]) {
+			t.Fatalf("Expected an edge from %s to %s", cycle[i-1], cycle[i])
+		}
+	}
+	if _, err := graph.NewUndirected[int]().TopoSort(); !errors.Is(err, graph.ErrUndirected) {
+		t.Fatalf("Expected: %s Actual: %v", graph.ErrUndirected, err)
+	}
+}
+
+func TestGraph_TopoSortInsertionOrder(t *testing.T) {
+	g := graph.NewDirected[string]()
+	g.AddNode("a")
+	g.AddNode("b")
+	g.AddNode("c")
+	g.AddEdge("b", "a")
+	order, err := g.TopoSort()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	// Once b is placed, a is ready again and was added before c.
+	if expected := []string{"b", "a", "c"}; !slices.Equal(expected, order) {
+		t.Fatalf("Expected: %v Actual: %v", expected, order)
+	}
+}
+
+func TestGraph_HasCycleUndirected(t *testing.T) {
+	g := graph.NewUndirected[int]()
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(4, 5)
+	if g.HasCycle() {
+		t.Fatalf("Expected a forest to have no cycle")
+	}
+	g.AddEdge(3, 1)
+	if !g.HasCycle() {
+		t.Fatalf("Expected a cycle")
+	}
+	g.RemoveEdge(3, 1)
+	g.AddEdge(5, 5)
+	if !g.HasCycle() {
+		t.Fatalf("Expected a self-loop to be a cycle")
+	}
+}
@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package hyperloglog implements a HyperLogLog Sketch, which estimates the number of distinct items in a stream using a
+few kilobytes of memory regardless of how many items it sees.  Sketches with the same precision can be merged to
+estimate the distinct items of the combined streams, and are marshaled in a compact encoding of six bits per register.
+*/
+
+package hyperloglog
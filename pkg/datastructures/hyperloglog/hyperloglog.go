@@ -0,0 +1,169 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hyperloglog
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// binaryVersion is the version of the encoding produced by MarshalBinary, written as its first byte.
+const binaryVersion = 1
+
+// The bounds on the precision of a Sketch.
+const (
+	MinPrecision = 4
+	MaxPrecision = 18
+)
+
+// ErrIncompatible is returned by Merge when the Sketches differ in precision.
+var ErrIncompatible = errors.New("hyperloglog: incompatible sketches")
+
+// A Sketch is a HyperLogLog sketch of 2^precision registers.  Each item is hashed to a register, which records the
+// longest run of leading zeros seen in the rest of the hashes sent to it; a run of r zeros hints at about 2^r distinct
+// items.  The standard error of Count is about 1.04 / sqrt(2^precision).
+type Sketch struct {
+	registers []uint8
+	precision uint8
+}
+
+// New returns an empty Sketch with 2^precision registers.  It panics if precision is not between MinPrecision and
+// MaxPrecision.
+func New(precision uint8) *Sketch {
+	if precision < MinPrecision || precision > MaxPrecision {
+		panic("hyperloglog: precision must be between 4 and 18")
+	}
+	return &Sketch{registers: make([]uint8, 1<<precision), precision: precision}
+}
+
+// Precision returns the precision of the Sketch.
+func (s *Sketch) Precision() uint8 {
+	return s.precision
+}
+
+// Add adds item to the Sketch.
+func (s *Sketch) Add(item []byte) {
+	h := hash(item)
+	register := h >> (64 - s.precision)
+	// The sentinel bit caps the run of zeros in the remaining 64-precision bits.
+	rank := uint8(bits.LeadingZeros64(h<<s.precision|1<<(s.precision-1))) + 1
+	s.registers[register] = max(s.registers[register], rank)
+}
+
+// AddString adds item to the Sketch.
+func (s *Sketch) AddString(item string) {
+	s.Add([]byte(item))
+}
+
+// Count returns an estimate of the number of distinct items added to the Sketch.  Small counts, for which the
+// HyperLogLog estimate is biased, are estimated by linear counting of the empty registers instead.
+func (s *Sketch) Count() uint64 {
+	m := float64(len(s.registers))
+	sum, zeros := 0.0, 0
+	for _, rank := range s.registers {
+		sum += math.Ldexp(1, -int(rank))
+		if rank == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha(len(s.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Merge adds the items of other to the Sketch, so that it estimates the distinct items of both.  It returns
+// ErrIncompatible if the Sketches differ in precision.
+func (s *Sketch) Merge(other *Sketch) error {
+	if s.precision != other.precision {
+		return ErrIncompatible
+	}
+	for i, rank := range other.registers {
+		s.registers[i] = max(s.registers[i], rank)
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The encoding holds the precision followed by the registers packed
+// six bits apiece, four to every three bytes, since a rank never exceeds 64 - MinPrecision + 1.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 2+len(s.registers)*3/4)
+	b = append(b, binaryVersion, s.precision)
+	for i := 0; i < len(s.registers); i += 4 {
+		r := s.registers[i : i+4]
+		packed := uint32(r[0])<<18 | uint32(r[1])<<12 | uint32(r[2])<<6 | uint32(r[3])
+		b = append(b, byte(packed>>16), byte(packed>>8), byte(packed))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler by replacing the Sketch with the one encoded in data by
+// MarshalBinary.  The Sketch is left unchanged if data cannot be decoded.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return errors.New("hyperloglog: unsupported binary encoding")
+	}
+	corrupt := errors.New("hyperloglog: corrupt binary encoding")
+	if len(data) < 2 || data[1] < MinPrecision || data[1] > MaxPrecision {
+		return corrupt
+	}
+	precision := data[1]
+	registers := make([]uint8, 1<<precision)
+	data = data[2:]
+	if len(data) != len(registers)*3/4 {
+		return corrupt
+	}
+	for i := 0; i < len(registers); i += 4 {
+		packed := uint32(data[0])<<16 | uint32(data[1])<<8 | uint32(data[2])
+		registers[i], registers[i+1] = uint8(packed>>18), uint8(packed>>12&0x3f)
+		registers[i+2], registers[i+3] = uint8(packed>>6&0x3f), uint8(packed&0x3f)
+		data = data[3:]
+	}
+	for _, rank := range registers {
+		if rank > 64-precision+1 {
+			return corrupt
+		}
+	}
+	s.registers, s.precision = registers, precision
+	return nil
+}
+
+// alpha returns the constant that corrects the bias of the raw estimate for m registers.
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(m))
+}
+
+// hash returns the 64-bit FNV-1a hash of item, mixed by the MurmurHash3 finalizer so that every bit of the result
+// depends on every byte of item; HyperLogLog relies on the leading bits being uniform.
+func hash(item []byte) uint64 {
+	f := fnv.New64a()
+	f.Write(item)
+	h := f.Sum64()
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
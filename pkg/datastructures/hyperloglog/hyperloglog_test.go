@@ -0,0 +1,89 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hyperloglog_test
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/hyperloglog"
+)
+
+// within reports whether estimate is within a fraction tolerance of actual.
+func within(estimate uint64, actual int, tolerance float64) bool {
+	return math.Abs(float64(estimate)-float64(actual)) <= tolerance*float64(actual)
+}
+
+func TestSketch(t *testing.T) {
+	s := hyperloglog.New(14)
+	if s.Count() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, s.Count())
+	}
+	for _, n := range []int{10, 1000, 100000} {
+		s := hyperloglog.New(14)
+		for i := 0; i < n; i++ {
+			s.AddString(strconv.Itoa(i))
+			s.AddString(strconv.Itoa(i)) // duplicates do not count
+		}
+		// The standard error at precision 14 is 0.8%, so 3% is well beyond chance.
+		if count := s.Count(); !within(count, n, 0.03) {
+			t.Fatalf("Expected: %d Actual: %d", n, count)
+		}
+	}
+}
+
+func TestSketch_Merge(t *testing.T) {
+	a := hyperloglog.New(12)
+	b := hyperloglog.New(12)
+	for i := 0; i < 20000; i++ {
+		a.AddString(strconv.Itoa(i))
+		b.AddString(strconv.Itoa(i + 10000))
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if count := a.Count(); !within(count, 30000, 0.05) {
+		t.Fatalf("Expected: %d Actual: %d", 30000, count)
+	}
+	if err := a.Merge(hyperloglog.New(13)); !errors.Is(err, hyperloglog.ErrIncompatible) {
+		t.Fatalf("Expected: %s Actual: %v", hyperloglog.ErrIncompatible, err)
+	}
+}
+
+func TestSketch_Binary(t *testing.T) {
+	s := hyperloglog.New(10)
+	for i := 0; i < 5000; i++ {
+		s.AddString(strconv.Itoa(i))
+	}
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling: %s", err)
+	}
+	if len(b) != 2+768 {
+		t.Fatalf("Expected: %d Actual: %d", 2+768, len(b))
+	}
+	var restored hyperloglog.Sketch
+	if err := restored.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Unexpected error unmarshaling: %s", err)
+	}
+	if restored.Count() != s.Count() || restored.Precision() != 10 {
+		t.Fatalf("Expected: %d Actual: %d", s.Count(), restored.Count())
+	}
+	for _, corrupt := range [][]byte{nil, {2}, {1, 3}, b[:len(b)-1]} {
+		if err := restored.UnmarshalBinary(corrupt); err == nil {
+			t.Fatalf("Expected an error unmarshaling %v", corrupt)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervaltree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// An Interval is a half-open interval [Start, End) and its value.
+type Interval[T cmp.Ordered, V any] struct {
+	Start, End T
+	Value      V
+}
+
+// A Tree is an interval tree:  an AVL tree of intervals ordered by Start and then End, in which every node also records
+// the greatest End in its subtree.  A search can then skip any subtree whose greatest End is not after the start of the
+// query.  Insert and Delete are O(log n).  The zero value is an empty Tree ready to use.
+type Tree[T cmp.Ordered, V any] struct {
+	root *node[T, V]
+	size int
+}
+
+// node is a node of the Tree.
+type node[T cmp.Ordered, V any] struct {
+	interval    Interval[T, V]
+	left, right *node[T, V]
+	height      int // The height of the subtree rooted at the node; a leaf has height 1.
+	maxEnd      T   // The greatest End in the subtree rooted at the node.
+}
+
+// New returns an empty Tree.
+func New[T cmp.Ordered, V any]() *Tree[T, V] {
+	return &Tree[T, V]{}
+}
+
+// Len returns the number of intervals in the Tree.
+func (t *Tree[T, V]) Len() int {
+	return t.size
+}
+
+// Insert adds the interval [start, end) with value.  If the interval is already in the Tree, its value is replaced
+// and Insert returns false.  It panics if the interval is empty, with end not after start.
+func (t *Tree[T, V]) Insert(start, end T, value V) bool {
+	if start >= end {
+		panic("intervaltree: empty interval")
+	}
+	var added bool
+	t.root = t.root.insert(Interval[T, V]{Start: start, End: end, Value: value}, &added)
+	if added {
+		t.size++
+	}
+	return added
+}
+
+// Get returns the value of the interval [start, end).  The second return value is false if the interval is not in the
+// Tree.
+func (t *Tree[T, V]) Get(start, end T) (V, bool) {
+	n := t.root
+	for n != nil {
+		c := n.compare(start, end)
+		if c == 0 {
+			return n.interval.Value, true
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes the interval [start, end).  It returns false if the interval was not in the Tree.
+func (t *Tree[T, V]) Delete(start, end T) bool {
+	var removed bool
+	t.root = t.root.delete(start, end, &removed)
+	if removed {
+		t.size--
+	}
+	return removed
+}
+
+// Stab returns an iterator over the intervals that contain point, in order of Start and then End.  The Tree must not
+// be modified during iteration.
+func (t *Tree[T, V]) Stab(point T) iter.Seq[Interval[T, V]] {
+	return func(yield func(Interval[T, V]) bool) {
+		t.root.search(func(i *Interval[T, V]) int {
+			switch {
+			case point >= i.End:
+				return 1
+			case point < i.Start:
+				return -1
+			}
+			return 0
+		}, point, yield)
+	}
+}
+
+// Overlapping returns an iterator over the intervals that overlap [start, end), in order of Start and then End.  The
+// Tree must not be modified during iteration.
+func (t *Tree[T, V]) Overlapping(start, end T) iter.Seq[Interval[T, V]] {
+	return func(yield func(Interval[T, V]) bool) {
+		t.root.search(func(i *Interval[T, V]) int {
+			switch {
+			case start >= i.End:
+				return 1
+			case end <= i.Start:
+				return -1
+			}
+			return 0
+		}, start, yield)
+	}
+}
+
+// All returns an iterator over the intervals of the Tree in order of Start and then End.  The Tree must not be
+// modified during iteration.
+func (t *Tree[T, V]) All() iter.Seq[Interval[T, V]] {
+	return func(yield func(Interval[T, V]) bool) {
+		t.root.each(yield)
+	}
+}
+
+// search yields the intervals in the subtree rooted at n for which match returns 0, in order.  match returns a positive
+// number for an interval that ends before the query and a negative number for one that starts after it.  Subtrees
+// whose greatest End is not after from, the start of the query, hold no matches and are skipped, as are the right
+// subtrees of intervals that start after the query.  It returns false if yield asked to stop.
+func (n *node[T, V]) search(match func(*Interval[T, V]) int, from T, yield func(Interval[T, V]) bool) bool {
+	if n == nil || n.maxEnd <= from {
+		return true
+	}
+	if !n.left.search(match, from, yield) {
+		return false
+	}
+	m := match(&n.interval)
+	if m < 0 {
+		return true
+	}
+	if m == 0 && !yield(n.interval) {
+		return false
+	}
+	return n.right.search(match, from, yield)
+}
+
+// each yields the subtree rooted at n in order.  It returns false if yield asked to stop.
+func (n *node[T, V]) each(yield func(Interval[T, V]) bool) bool {
+	return n == nil || n.left.each(yield) && yield(n.interval) && n.right.each(yield)
+}
+
+// compare compares the interval [start, end) with the interval of n, by Start and then End.
+func (n *node[T, V]) compare(start, end T) int {
+	if c := cmp.Compare(start, n.interval.Start); c != 0 {
+		return c
+	}
+	return cmp.Compare(end, n.interval.End)
+}
+
+// insert adds interval to the subtree rooted at n and returns its new, rebalanced root.
+func (n *node[T, V]) insert(interval Interval[T, V], added *bool) *node[T, V] {
+	if n == nil {
+		*added = true
+		return &node[T, V]{interval: interval, height: 1, maxEnd: interval.End}
+	}
+	c := n.compare(interval.Start, interval.End)
+	switch {
+	case c < 0:
+		n.left = n.left.insert(interval, added)
+	case c > 0:
+		n.right = n.right.insert(interval, added)
+	default:
+		n.interval.Value = interval.Value
+		return n
+	}
+	return n.balance()
+}
+
+// delete removes the interval [start, end) from the subtree rooted at n and returns its new, rebalanced root.
+func (n *node[T, V]) delete(start, end T, removed *bool) *node[T, V] {
+	if n == nil {
+		return nil
+	}
+	c := n.compare(start, end)
+	switch {
+	case c < 0:
+		n.left = n.left.delete(start, end, removed)
+	case c > 0:
+		n.right = n.right.delete(start, end, removed)
+	default:
+		*removed = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		n.interval = successor.interval
+		n.right = n.right.delete(successor.interval.Start, successor.interval.End, new(bool))
+	}
+	return n.balance()
+}
+
+// depth returns the height of the subtree rooted at n, which may be nil.
+func (n *node[T, V]) depth() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// update recomputes the height and greatest End of n from its children.
+func (n *node[T, V]) update() {
+	n.height = max(n.left.depth(), n.right.depth()) + 1
+	n.maxEnd = n.interval.End
+	if n.left != nil {
+		n.maxEnd = max(n.maxEnd, n.left.maxEnd)
+	}
+	if n.right != nil {
+		n.maxEnd = max(n.maxEnd, n.right.maxEnd)
+	}
+}
+
+// balance restores the AVL invariant at n, whose children are balanced and differ in height by at most 2, and returns
+// the new root of the subtree.
+func (n *node[T, V]) balance() *node[T, V] {
+	n.update()
+	switch skew := n.left.depth() - n.right.depth(); {
+	case skew > 1:
+		if n.left.left.depth() < n.left.right.depth() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case skew < -1:
+		if n.right.right.depth() < n.right.left.depth() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+// rotateLeft rotates the subtree rooted at n to the left and returns its new root.
+func (n *node[T, V]) rotateLeft() *node[T, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.update()
+	r.update()
+	return r
+}
+
+// rotateRight rotates the subtree rooted at n to the right and returns its new root.
+func (n *node[T, V]) rotateRight() *node[T, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.update()
+	l.update()
+	return l
+}
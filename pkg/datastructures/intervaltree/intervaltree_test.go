@@ -0,0 +1,102 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervaltree_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/intervaltree"
+)
+
+// names collects the values of the intervals in seq.
+func names(seq func(func(intervaltree.Interval[int, string]) bool)) []string {
+	var values []string
+	for interval := range seq {
+		values = append(values, interval.Value)
+	}
+	return values
+}
+
+func TestTree(t *testing.T) {
+	var tree intervaltree.Tree[int, string]
+	tree.Insert(9, 12, "standup")
+	tree.Insert(13, 14, "lunch")
+	tree.Insert(10, 11, "review")
+	tree.Insert(11, 15, "offsite")
+	if tree.Insert(9, 12, "planning") || tree.Len() != 4 {
+		t.Fatalf("Expected Insert of an existing interval to replace its value")
+	}
+	if value, ok := tree.Get(9, 12); !ok || value != "planning" {
+		t.Fatalf("Expected: %s Actual: %s", "planning", value)
+	}
+	if expected, actual := []string{"planning", "review"}, names(tree.Stab(10)); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	// Intervals are half-open, so 11 is outside [10, 11).
+	if expected, actual := []string{"planning", "offsite"}, names(tree.Stab(11)); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if expected, actual := []string{"offsite", "lunch"}, names(tree.Overlapping(12, 20)); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if actual := names(tree.Overlapping(15, 20)); actual != nil {
+		t.Fatalf("Expected no overlaps Actual: %v", actual)
+	}
+	if !tree.Delete(11, 15) || tree.Delete(11, 15) {
+		t.Fatalf("Expected to delete [%d, %d) once", 11, 15)
+	}
+	if expected, actual := []string{"planning", "review", "lunch"}, names(tree.All()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
+
+func TestTree_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := intervaltree.New[int, int]()
+	reference := map[[2]int]bool{}
+	for i := 0; i < 3000; i++ {
+		start := r.Intn(1000)
+		interval := [2]int{start, start + 1 + r.Intn(50)}
+		if r.Intn(3) == 0 {
+			if tree.Delete(interval[0], interval[1]) != reference[interval] {
+				t.Fatalf("Delete(%v) disagreed with the reference", interval)
+			}
+			delete(reference, interval)
+		} else {
+			tree.Insert(interval[0], interval[1], i)
+			reference[interval] = true
+		}
+	}
+	for i := 0; i < 200; i++ {
+		start := r.Intn(1100)
+		end := start + 1 + r.Intn(30)
+		expected := 0
+		for interval := range reference {
+			if interval[0] < end && start < interval[1] {
+				expected++
+			}
+		}
+		actual := 0
+		for interval := range tree.Overlapping(start, end) {
+			if !(interval.Start < end && start < interval.End) {
+				t.Fatalf("Expected [%d, %d) to overlap [%d, %d)", interval.Start, interval.End, start, end)
+			}
+			actual++
+		}
+		if actual != expected {
+			t.Fatalf("Expected: %d Actual: %d", expected, actual)
+		}
+	}
+}
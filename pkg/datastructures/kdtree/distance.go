@@ -0,0 +1,63 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kdtree
+
+import "math"
+
+// A Distance returns the distance between points a and b, which have the same number of dimensions.  The nearest
+// neighbor search prunes with it, so a Distance must not decrease as the difference between a and b along any one axis
+// grows; the Minkowski distances, such as Euclidean, Manhattan and Chebyshev, and squared Euclidean all qualify.
+type Distance func(a, b []float64) float64
+
+// Euclidean returns the straight-line distance between a and b.
+func Euclidean(a, b []float64) float64 {
+	return math.Sqrt(SquaredEuclidean(a, b))
+}
+
+// SquaredEuclidean returns the square of the Euclidean distance between a and b, which orders neighbors identically
+// and is cheaper to compute.
+func SquaredEuclidean(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum
+}
+
+// Manhattan returns the sum of the differences between a and b along each axis.
+func Manhattan(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += math.Abs(a[i] - b[i])
+	}
+	return sum
+}
+
+// Chebyshev returns the greatest difference between a and b along any axis.
+func Chebyshev(a, b []float64) float64 {
+	var greatest float64
+	for i := range a {
+		greatest = max(greatest, math.Abs(a[i]-b[i]))
+	}
+	return greatest
+}
+
+// planeDistance returns a lower bound on the distance from point to any point on the far side of the plane
+// perpendicular to axis at split:  the distance to point moved onto the plane.  scratch must have the same length as
+// point.
+func planeDistance(distance Distance, point []float64, axis int, split float64, scratch []float64) float64 {
+	copy(scratch, point)
+	scratch[axis] = split
+	return distance(point, scratch)
+}
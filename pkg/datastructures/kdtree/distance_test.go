@@ -0,0 +1,39 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kdtree_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/kdtree"
+)
+
+func TestDistance(t *testing.T) {
+	a, b := []float64{1, 2}, []float64{4, -2}
+	cases := []struct {
+		name     string
+		distance kdtree.Distance
+		expected float64
+	}{
+		{"Euclidean", kdtree.Euclidean, 5},
+		{"SquaredEuclidean", kdtree.SquaredEuclidean, 25},
+		{"Manhattan", kdtree.Manhattan, 7},
+		{"Chebyshev", kdtree.Chebyshev, 4},
+	}
+	for _, c := range cases {
+		if actual := c.distance(a, b); math.Abs(actual-c.expected) > 1e-9 {
+			t.Fatalf("%s Expected: %f Actual: %f", c.name, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kdtree
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// An Item is a point and its value.
+type Item[V any] struct {
+	Point []float64
+	Value V
+}
+
+// A Neighbor is an Item found by Nearest and its distance from the query.
+type Neighbor[V any] struct {
+	Item[V]
+	Distance float64
+}
+
+// A Tree is a k-d tree:  a binary tree of points in which each level splits space in two along the next axis in turn.
+// A Tree built by Build is balanced, so a nearest neighbor query visits O(log n) nodes on well-spread data, but Insert
+// does not rebalance, so a Tree grown point by point from sorted data degrades towards a list.  Points that are equal
+// are all kept.
+type Tree[V any] struct {
+	root     *node[V]
+	dims     int
+	size     int
+	distance Distance
+}
+
+// node is a node of the Tree, which splits its subtree along axis at the coordinate of its point.  Points with a
+// smaller coordinate are in left, and the rest in right.
+type node[V any] struct {
+	item        Item[V]
+	axis        int
+	left, right *node[V]
+}
+
+// An Option configures a Tree constructed by New or Build.
+type Option func(*options)
+
+// options holds the configuration set by Options.
+type options struct {
+	distance Distance
+}
+
+// WithDistance sets the Distance used by Nearest.  By default it is Euclidean.
+func WithDistance(distance Distance) Option {
+	return func(o *options) {
+		o.distance = distance
+	}
+}
+
+// New returns an empty Tree of points with dims dimensions.  It panics if dims is less than 1.
+func New[V any](dims int, opts ...Option) *Tree[V] {
+	if dims < 1 {
+		panic("kdtree: dimensions must be at least 1")
+	}
+	o := options{distance: Euclidean}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Tree[V]{dims: dims, distance: o.distance}
+}
+
+// Build returns a balanced Tree of items, whose points have dims dimensions, in O(n log² n).  It panics if dims is less
+// than 1 or any point has a different number of dimensions.
+func Build[V any](dims int, items []Item[V], opts ...Option) *Tree[V] {
+	t := New[V](dims, opts...)
+	items = slices.Clone(items)
+	for i := range items {
+		checkDims(items[i].Point, dims)
+		items[i].Point = slices.Clone(items[i].Point)
+	}
+	t.root = t.build(items, 0)
+	t.size = len(items)
+	return t
+}
+
+// build returns a balanced subtree of items, split first along axis.  It reorders items.
+func (t *Tree[V]) build(items []Item[V], axis int) *node[V] {
+	if len(items) == 0 {
+		return nil
+	}
+	slices.SortFunc(items, func(a, b Item[V]) int {
+		return cmp.Compare(a.Point[axis], b.Point[axis])
+	})
+	// Points equal to the median along axis must go right, so split at the first of them.
+	m := len(items) / 2
+	for m > 0 && items[m-1].Point[axis] == items[m].Point[axis] {
+		m--
+	}
+	next := (axis + 1) % t.dims
+	return &node[V]{
+		item:  items[m],
+		axis:  axis,
+		left:  t.build(items[:m], next),
+		right: t.build(items[m+1:], next),
+	}
+}
+
+// checkDims panics if point does not have dims dimensions.
+func checkDims(point []float64, dims int) {
+	if len(point) != dims {
+		panic("kdtree: point has the wrong number of dimensions")
+	}
+}
+
+// Dims returns the number of dimensions of the points in the Tree.
+func (t *Tree[V]) Dims() int {
+	return t.dims
+}
+
+// Len returns the number of points in the Tree.
+func (t *Tree[V]) Len() int {
+	return t.size
+}
+
+// Insert adds point with value to the Tree in O(depth).  It panics if point has the wrong number of dimensions.
+func (t *Tree[V]) Insert(point []float64, value V) {
+	checkDims(point, t.dims)
+	item := Item[V]{Point: slices.Clone(point), Value: value}
+	link, axis := &t.root, 0
+	for *link != nil {
+		n := *link
+		if point[n.axis] < n.item.Point[n.axis] {
+			link = &n.left
+		} else {
+			link = &n.right
+		}
+		axis = (n.axis + 1) % t.dims
+	}
+	*link = &node[V]{item: item, axis: axis}
+	t.size++
+}
+
+// Range returns an iterator over the Items whose points lie within the box from min to max, inclusive, along every
+// axis.  It panics if min or max has the wrong number of dimensions.  The Tree must not be modified during iteration.
+func (t *Tree[V]) Range(min, max []float64) iter.Seq[Item[V]] {
+	checkDims(min, t.dims)
+	checkDims(max, t.dims)
+	return func(yield func(Item[V]) bool) {
+		t.root.search(min, max, yield)
+	}
+}
+
+// search yields the Items of the subtree rooted at n within the box from min to max.  It returns false if yield asked
+// to stop.
+func (n *node[V]) search(min, max []float64, yield func(Item[V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	split := n.item.Point[n.axis]
+	if min[n.axis] < split && !n.left.search(min, max, yield) {
+		return false
+	}
+	if within(n.item.Point, min, max) && !yield(n.item) {
+		return false
+	}
+	return max[n.axis] < split || n.right.search(min, max, yield)
+}
+
+// within reports whether point lies within the box from min to max.
+func within(point, min, max []float64) bool {
+	for i, x := range point {
+		if x < min[i] || x > max[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over the Items of the Tree in no particular order.  The Tree must not be modified during
+// iteration.
+func (t *Tree[V]) All() iter.Seq[Item[V]] {
+	return func(yield func(Item[V]) bool) {
+		t.root.each(yield)
+	}
+}
+
+// each yields the subtree rooted at n in order.  It returns false if yield asked to stop.
+func (n *node[V]) each(yield func(Item[V]) bool) bool {
+	return n == nil || n.left.each(yield) && yield(n.item) && n.right.each(yield)
+}
+
+// Nearest returns the k Items closest to point, nearest first, or all of them if the Tree holds fewer than k.  Items at
+// equal distance are returned in no particular order.  It panics if point has the wrong number of dimensions.
+func (t *Tree[V]) Nearest(point []float64, k int) []Neighbor[V] {
+	checkDims(point, t.dims)
+	if k <= 0 {
+		return nil
+	}
+	s := nearest[V]{point: point, k: k, distance: t.distance, scratch: make([]float64, t.dims)}
+	s.visit(t.root)
+	slices.SortFunc(s.best, func(a, b Neighbor[V]) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+	return s.best
+}
+
+// nearest is the state of a nearest neighbor search.  best is a max-heap of the closest Neighbors found so far.
+type nearest[V any] struct {
+	point    []float64
+	k        int
+	distance Distance
+	scratch  []float64
+	best     []Neighbor[V]
+}
+
+// visit searches the subtree rooted at n, descending first into the side of the split holding the query, and then into
+// the other side only if it could hold a closer point than the furthest of the best so far.
+func (s *nearest[V]) visit(n *node[V]) {
+	if n == nil {
+		return
+	}
+	if d := s.distance(s.point, n.item.Point); len(s.best) < s.k {
+		heap.Push(s, Neighbor[V]{Item: n.item, Distance: d})
+	} else if d < s.best[0].Distance {
+		s.best[0] = Neighbor[V]{Item: n.item, Distance: d}
+		heap.Fix(s, 0)
+	}
+	split := n.item.Point[n.axis]
+	near, far := n.left, n.right
+	if s.point[n.axis] >= split {
+		near, far = far, near
+	}
+	s.visit(near)
+	if len(s.best) < s.k || planeDistance(s.distance, s.point, n.axis, split, s.scratch) < s.best[0].Distance {
+		s.visit(far)
+	}
+}
+
+// Len, Less, Swap, Push and Pop implement heap.Interface over best, with the furthest Neighbor at the top.
+func (s *nearest[V]) Len() int           { return len(s.best) }
+func (s *nearest[V]) Less(i, j int) bool { return s.best[i].Distance > s.best[j].Distance }
+func (s *nearest[V]) Swap(i, j int)      { s.best[i], s.best[j] = s.best[j], s.best[i] }
+func (s *nearest[V]) Push(x any)         { s.best = append(s.best, x.(Neighbor[V])) }
+func (s *nearest[V]) Pop() any {
+	last := s.best[len(s.best)-1]
+	s.best = s.best[:len(s.best)-1]
+	return last
+}
@@ -0,0 +1,103 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kdtree_test
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/kdtree"
+)
+
+func TestTree(t *testing.T) {
+	tree := kdtree.Build(2, []kdtree.Item[string]{
+		{Point: []float64{2, 3}, Value: "a"},
+		{Point: []float64{5, 4}, Value: "b"},
+		{Point: []float64{9, 6}, Value: "c"},
+		{Point: []float64{4, 7}, Value: "d"},
+		{Point: []float64{8, 1}, Value: "e"},
+	})
+	tree.Insert([]float64{7, 2}, "f")
+	if tree.Len() != 6 || tree.Dims() != 2 {
+		t.Fatalf("Expected: %d Actual: %d", 6, tree.Len())
+	}
+	neighbors := tree.Nearest([]float64{9, 2}, 2)
+	if len(neighbors) != 2 || neighbors[0].Value != "e" || neighbors[1].Value != "f" {
+		t.Fatalf("Expected: %s Actual: %v", "[e f]", neighbors)
+	}
+	var found []string
+	for item := range tree.Range([]float64{4, 2}, []float64{8, 7}) {
+		found = append(found, item.Value)
+	}
+	slices.Sort(found)
+	if expected := []string{"b", "d", "f"}; !slices.Equal(expected, found) {
+		t.Fatalf("Expected: %v Actual: %v", expected, found)
+	}
+	if neighbors := tree.Nearest([]float64{0, 0}, 10); len(neighbors) != 6 {
+		t.Fatalf("Expected: %d Actual: %d", 6, len(neighbors))
+	}
+}
+
+func TestTree_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, distance := range []kdtree.Distance{kdtree.Euclidean, kdtree.Manhattan, kdtree.Chebyshev} {
+		var points [][]float64
+		var items []kdtree.Item[int]
+		for i := 0; i < 500; i++ {
+			// Coarse coordinates exercise points equal along an axis.
+			point := []float64{float64(r.Intn(20)), float64(r.Intn(20)), float64(r.Intn(20))}
+			points = append(points, point)
+			items = append(items, kdtree.Item[int]{Point: point, Value: i})
+		}
+		built := kdtree.Build(3, items, kdtree.WithDistance(distance))
+		inserted := kdtree.New[int](3, kdtree.WithDistance(distance))
+		for i, point := range points {
+			inserted.Insert(point, i)
+		}
+		for i := 0; i < 100; i++ {
+			query := []float64{r.Float64() * 20, r.Float64() * 20, r.Float64() * 20}
+			distances := make([]float64, len(points))
+			for j, point := range points {
+				distances[j] = distance(query, point)
+			}
+			sort.Float64s(distances)
+			for _, tree := range []*kdtree.Tree[int]{built, inserted} {
+				neighbors := tree.Nearest(query, 5)
+				for j, neighbor := range neighbors {
+					if neighbor.Distance != distances[j] {
+						t.Fatalf("Expected: %f Actual: %f", distances[j], neighbor.Distance)
+					}
+				}
+			}
+			min, max := []float64{query[0] - 3, query[1] - 3, query[2] - 3}, []float64{query[0] + 3, query[1] + 3, query[2] + 3}
+			expected := 0
+			for _, point := range points {
+				if point[0] >= min[0] && point[0] <= max[0] && point[1] >= min[1] && point[1] <= max[1] &&
+					point[2] >= min[2] && point[2] <= max[2] {
+					expected++
+				}
+			}
+			for _, tree := range []*kdtree.Tree[int]{built, inserted} {
+				actual := 0
+				for range tree.Range(min, max) {
+					actual++
+				}
+				if actual != expected {
+					t.Fatalf("Expected: %d Actual: %d", expected, actual)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package lfucache implements a generic fixed-capacity Cache that evicts its least frequently used key to make room for
+a new one, breaking ties by recency, with optional eviction callbacks and hit and miss counters.  It has the same
+methods as lrucache.Cache, so either satisfies cache.Cache.
+*/
+
+package lfucache
@@ -0,0 +1,204 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfucache
+
+import (
+	"iter"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/linkedlist"
+)
+
+// A Cache maps keys to values, holding at most a fixed number of keys.  Putting a new key into a full Cache evicts
+// the least frequently used key, counting the calls to Get and Put for each key since it was added, and of those the
+// least recently used.  Keys are kept in buckets of equal frequency, each a linked list from most to least recently
+// used, and the buckets in a linked list of ascending frequency, so Get, Put and Delete are O(1).  A Cache is not safe
+// for concurrent use.
+type Cache[K comparable, V any] struct {
+	entries  map[K]*linkedlist.Element[entry[K, V]]
+	buckets  linkedlist.List[*bucket[K, V]]
+	capacity int
+	onEvict  func(key K, value V)
+	hits     uint64
+	misses   uint64
+}
+
+// bucket holds the keys used frequency times.
+type bucket[K comparable, V any] struct {
+	frequency int
+	entries   linkedlist.List[entry[K, V]]
+}
+
+// entry is a key in the Cache, its value and the Element of its bucket.
+type entry[K comparable, V any] struct {
+	key    K
+	value  V
+	bucket *linkedlist.Element[*bucket[K, V]]
+}
+
+// New returns an empty Cache holding at most capacity keys.  It panics if capacity is less than 1.
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	return NewWithEvict[K, V](capacity, nil)
+}
+
+// NewWithEvict returns an empty Cache holding at most capacity keys, which calls onEvict, if it is not nil, with each
+// key and value it evicts to make room for another.  Keys removed by Delete or Purge are not reported.  It panics if
+// capacity is less than 1.
+func NewWithEvict[K comparable, V any](capacity int, onEvict func(key K, value V)) *Cache[K, V] {
+	if capacity < 1 {
+		panic("lfucache: capacity must be at least 1")
+	}
+	return &Cache[K, V]{
+		entries:  make(map[K]*linkedlist.Element[entry[K, V]], capacity),
+		capacity: capacity,
+		onEvict:  onEvict,
+	}
+}
+
+// Len returns the number of keys in the Cache.
+func (c *Cache[K, V]) Len() int {
+	return len(c.entries)
+}
+
+// Cap returns the greatest number of keys the Cache holds.
+func (c *Cache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Get returns the value of key, counting a use of key, and counts a hit.  The second return value is false, and a miss
+// is counted, if key is not in the Cache.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	c.hits++
+	return c.use(e).Value.value, true
+}
+
+// Peek returns the value of key like Get, but neither counts a use of key nor a hit or miss.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// Contains reports whether key is in the Cache, without counting a use of it.
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Frequency returns the number of uses of key since it was added, or 0 if key is not in the Cache.
+func (c *Cache[K, V]) Frequency(key K) int {
+	e, ok := c.entries[key]
+	if !ok {
+		return 0
+	}
+	return e.Value.bucket.Value.frequency
+}
+
+// Put sets the value of key and counts a use of it.  If key is new and the Cache is full, the least frequently used
+// key is evicted first, so a new key always displaces an existing one.  It returns true if a key was evicted.
+func (c *Cache[K, V]) Put(key K, value V) bool {
+	if e, ok := c.entries[key]; ok {
+		c.use(e).Value.value = value
+		return false
+	}
+	evicted := false
+	if len(c.entries) == c.capacity {
+		b := c.buckets.Front()
+		victim := b.Value.entries.Back().Value
+		c.remove(c.entries[victim.key])
+		if c.onEvict != nil {
+			c.onEvict(victim.key, victim.value)
+		}
+		evicted = true
+	}
+	b := c.buckets.Front()
+	if b == nil || b.Value.frequency != 1 {
+		b = c.buckets.PushFront(&bucket[K, V]{frequency: 1})
+	}
+	c.entries[key] = b.Value.entries.PushFront(entry[K, V]{key: key, value: value, bucket: b})
+	return evicted
+}
+
+// use moves e into the bucket for one more use, creating it if need be, and returns the new Element for e.
+func (c *Cache[K, V]) use(e *linkedlist.Element[entry[K, V]]) *linkedlist.Element[entry[K, V]] {
+	b := e.Value.bucket
+	next := b.Next()
+	if next == nil || next.Value.frequency != b.Value.frequency+1 {
+		next = c.buckets.InsertAfter(&bucket[K, V]{frequency: b.Value.frequency + 1}, b)
+	}
+	moved := c.remove(e)
+	moved.bucket = next
+	e = next.Value.entries.PushFront(moved)
+	c.entries[moved.key] = e
+	return e
+}
+
+// remove removes e from the Cache, along with its bucket if it is left empty, and returns its entry.
+func (c *Cache[K, V]) remove(e *linkedlist.Element[entry[K, V]]) entry[K, V] {
+	b := e.Value.bucket
+	removed := b.Value.entries.Remove(e)
+	if b.Value.entries.Len() == 0 {
+		c.buckets.Remove(b)
+	}
+	delete(c.entries, removed.key)
+	return removed
+}
+
+// Delete removes key from the Cache.  It returns false if key was not in the Cache.
+func (c *Cache[K, V]) Delete(key K) bool {
+	e, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	c.remove(e)
+	return true
+}
+
+// Purge removes every key from the Cache.  The hit and miss counters are kept.
+func (c *Cache[K, V]) Purge() {
+	clear(c.entries)
+	c.buckets.Init()
+}
+
+// Hits returns the number of calls to Get that found their key.
+func (c *Cache[K, V]) Hits() uint64 {
+	return c.hits
+}
+
+// Misses returns the number of calls to Get that did not find their key.
+func (c *Cache[K, V]) Misses() uint64 {
+	return c.misses
+}
+
+// All returns an iterator over the keys of the Cache and their values, from the most to the least frequently used and
+// among equally used keys from the most to the least recently used, so the key to be evicted next comes last.  It does
+// not count uses of the keys.  The Cache must not be modified during iteration.
+func (c *Cache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for b := range c.buckets.Backward() {
+			for e := range b.Value.entries.All() {
+				if !yield(e.Value.key, e.Value.value) {
+					return
+				}
+			}
+		}
+	}
+}
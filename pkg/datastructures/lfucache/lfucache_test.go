@@ -0,0 +1,88 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfucache_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/lfucache"
+)
+
+func TestCache(t *testing.T) {
+	var evicted []string
+	c := lfucache.NewWithEvict(2, func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+	if !c.Put("c", 3) || !slices.Equal(evicted, []string{"b"}) {
+		t.Fatalf("Expected: %v Actual: %v", []string{"b"}, evicted)
+	}
+	if c.Frequency("a") != 2 || c.Frequency("c") != 1 || c.Frequency("b") != 0 {
+		t.Fatalf("Expected: %d, %d Actual: %d, %d", 2, 1, c.Frequency("a"), c.Frequency("c"))
+	}
+	// A new key displaces an existing one even if that was used more often.
+	c.Put("c", 4)
+	c.Put("c", 5)
+	c.Put("d", 6)
+	if !slices.Equal(evicted, []string{"b", "a"}) {
+		t.Fatalf("Expected: %v Actual: %v", []string{"b", "a"}, evicted)
+	}
+	if value, ok := c.Get("c"); !ok || value != 5 {
+		t.Fatalf("Expected: %d Actual: %d", 5, value)
+	}
+}
+
+func TestCache_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	c := lfucache.New[int, int](10)
+	// The reference records each key's frequency and the time of its last use.
+	type use struct{ frequency, time int }
+	reference := map[int]use{}
+	for i := 0; i < 10000; i++ {
+		key := r.Intn(30)
+		if r.Intn(2) == 0 {
+			_, ok := c.Get(key)
+			if _, expected := reference[key]; ok != expected {
+				t.Fatalf("Expected: %t Actual: %t", expected, ok)
+			}
+			if ok {
+				reference[key] = use{reference[key].frequency + 1, i}
+			}
+			continue
+		}
+		if u, ok := reference[key]; ok {
+			reference[key] = use{u.frequency + 1, i}
+		} else {
+			if len(reference) == c.Cap() {
+				victim, least := -1, use{}
+				for k, u := range reference {
+					if victim < 0 || u.frequency < least.frequency || u.frequency == least.frequency && u.time < least.time {
+						victim, least = k, u
+					}
+				}
+				delete(reference, victim)
+			}
+			reference[key] = use{1, i}
+		}
+		c.Put(key, i)
+		for k, u := range reference {
+			if c.Frequency(k) != u.frequency {
+				t.Fatalf("Expected: %d Actual: %d", u.frequency, c.Frequency(k))
+			}
+		}
+	}
+}
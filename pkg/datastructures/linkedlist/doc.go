@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package linkedlist implements a generic doubly linked List, adapted from https://golang.org/pkg/container/list/.
+Elements are handles into the List, so an Element may be moved or removed in O(1), which makes the List a building
+block for structures such as LRU caches and insertion-ordered maps.
+*/
+
+package linkedlist
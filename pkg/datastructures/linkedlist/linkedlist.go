@@ -0,0 +1,221 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linkedlist
+
+import "iter"
+
+// An Element is an element of a List.
+type Element[T any] struct {
+	next, prev *Element[T]
+	list       *List[T] // The List the Element belongs to, or nil once it is removed.
+	Value      T
+}
+
+// Next returns the next Element of the List, or nil if e is the last Element or has been removed.
+func (e *Element[T]) Next() *Element[T] {
+	if p := e.next; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// Prev returns the previous Element of the List, or nil if e is the first Element or has been removed.
+func (e *Element[T]) Prev() *Element[T] {
+	if p := e.prev; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// A List is a doubly linked list.  The zero value is an empty List ready to use.
+type List[T any] struct {
+	root Element[T] // A sentinel, whose next is the first Element and whose prev is the last.
+	len  int
+}
+
+// New returns an empty List.
+func New[T any]() *List[T] {
+	return new(List[T]).Init()
+}
+
+// Init empties the List and returns it.
+func (l *List[T]) Init() *List[T] {
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.len = 0
+	return l
+}
+
+// lazyInit initializes a zero List.
+func (l *List[T]) lazyInit() {
+	if l.root.next == nil {
+		l.Init()
+	}
+}
+
+// Len returns the number of Elements in the List in O(1).
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// Front returns the first Element of the List, or nil if the List is empty.
+func (l *List[T]) Front() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last Element of the List, or nil if the List is empty.
+func (l *List[T]) Back() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// PushFront inserts value at the front of the List and returns its Element.
+func (l *List[T]) PushFront(value T) *Element[T] {
+	l.lazyInit()
+	return l.insert(&Element[T]{Value: value}, &l.root)
+}
+
+// PushBack inserts value at the back of the List and returns its Element.
+func (l *List[T]) PushBack(value T) *Element[T] {
+	l.lazyInit()
+	return l.insert(&Element[T]{Value: value}, l.root.prev)
+}
+
+// InsertBefore inserts value immediately before mark and returns its Element.  If mark is not an Element of the List,
+// the List is not modified and nil is returned.
+func (l *List[T]) InsertBefore(value T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insert(&Element[T]{Value: value}, mark.prev)
+}
+
+// InsertAfter inserts value immediately after mark and returns its Element.  If mark is not an Element of the List,
+// the List is not modified and nil is returned.
+func (l *List[T]) InsertAfter(value T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insert(&Element[T]{Value: value}, mark)
+}
+
+// Remove removes e from the List if it is an Element of the List, and returns its Value.
+func (l *List[T]) Remove(e *Element[T]) T {
+	if e.list == l {
+		l.remove(e)
+	}
+	return e.Value
+}
+
+// MoveToFront moves e to the front of the List.  If e is not an Element of the List, the List is not modified.
+func (l *List[T]) MoveToFront(e *Element[T]) {
+	if e.list != l || l.root.next == e {
+		return
+	}
+	l.move(e, &l.root)
+}
+
+// MoveToBack moves e to the back of the List.  If e is not an Element of the List, the List is not modified.
+func (l *List[T]) MoveToBack(e *Element[T]) {
+	if e.list != l || l.root.prev == e {
+		return
+	}
+	l.move(e, l.root.prev)
+}
+
+// MoveBefore moves e immediately before mark.  If e or mark is not an Element of the List, or e == mark, the List is
+// not modified.
+func (l *List[T]) MoveBefore(e, mark *Element[T]) {
+	if e.list != l || mark.list != l || e == mark {
+		return
+	}
+	l.move(e, mark.prev)
+}
+
+// MoveAfter moves e immediately after mark.  If e or mark is not an Element of the List, or e == mark, the List is not
+// modified.
+func (l *List[T]) MoveAfter(e, mark *Element[T]) {
+	if e.list != l || mark.list != l || e == mark {
+		return
+	}
+	l.move(e, mark)
+}
+
+// All returns an iterator over the Elements of the List from front to back.  The next Element is found before each
+// Element is yielded, so the yielded Element may be removed or moved during the iteration; Elements inserted after it
+// may or may not be visited.
+func (l *List[T]) All() iter.Seq[*Element[T]] {
+	return func(yield func(*Element[T]) bool) {
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if !yield(e) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Backward returns an iterator over the Elements of the List from back to front.  As for All, the yielded Element may
+// be removed or moved during the iteration.
+func (l *List[T]) Backward() iter.Seq[*Element[T]] {
+	return func(yield func(*Element[T]) bool) {
+		for e := l.Back(); e != nil; {
+			prev := e.Prev()
+			if !yield(e) {
+				return
+			}
+			e = prev
+		}
+	}
+}
+
+// insert inserts e after at and returns e.
+func (l *List[T]) insert(e, at *Element[T]) *Element[T] {
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+	e.list = l
+	l.len++
+	return e
+}
+
+// remove removes e from the List.
+func (l *List[T]) remove(e *Element[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.next = nil // avoid memory leaks
+	e.prev = nil // avoid memory leaks
+	e.list = nil
+	l.len--
+}
+
+// move moves e after at.
+func (l *List[T]) move(e, at *Element[T]) {
+	if e == at {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+}
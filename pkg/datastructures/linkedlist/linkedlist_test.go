@@ -0,0 +1,112 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linkedlist_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/linkedlist"
+)
+
+// values returns the Values of l from front to back, checking that iterating backwards agrees.
+func values(t *testing.T, l *linkedlist.List[string]) []string {
+	var forward, backward []string
+	for e := range l.All() {
+		forward = append(forward, e.Value)
+	}
+	for e := range l.Backward() {
+		backward = append(backward, e.Value)
+	}
+	slices.Reverse(backward)
+	if !slices.Equal(forward, backward) || len(forward) != l.Len() {
+		t.Fatalf("Expected: %v Actual: %v", forward, backward)
+	}
+	return forward
+}
+
+func TestList(t *testing.T) {
+	var l linkedlist.List[string]
+	if l.Front() != nil || l.Back() != nil {
+		t.Fatalf("Expected an empty List")
+	}
+	banana := l.PushBack("banana")
+	apple := l.PushFront("apple")
+	danish := l.PushBack("danish")
+	carrot := l.InsertBefore("carrot", danish)
+	eclair := l.InsertAfter("eclair", danish)
+	expected := []string{"apple", "banana", "carrot", "danish", "eclair"}
+	if actual := values(t, &l); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+
+	l.MoveToFront(danish)
+	l.MoveToBack(apple)
+	l.MoveBefore(eclair, banana)
+	l.MoveAfter(carrot, danish)
+	expected = []string{"danish", "carrot", "eclair", "banana", "apple"}
+	if actual := values(t, &l); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if l.Front() != danish || l.Back() != apple || danish.Prev() != nil || apple.Next() != nil {
+		t.Fatalf("Unexpected ends of the List")
+	}
+
+	if value := l.Remove(carrot); value != "carrot" {
+		t.Fatalf("Expected: %s Actual: %s", "carrot", value)
+	}
+	if carrot.Next() != nil || carrot.Prev() != nil {
+		t.Fatalf("Expected a removed Element to have no neighbours")
+	}
+	// Elements of another List, or removed Elements, leave the List unchanged.
+	other := linkedlist.New[string]()
+	fig := other.PushBack("fig")
+	l.Remove(fig)
+	l.Remove(carrot)
+	l.MoveToFront(fig)
+	if l.InsertBefore("grape", fig) != nil || l.InsertAfter("grape", carrot) != nil {
+		t.Fatalf("Expected no insert relative to a foreign Element")
+	}
+	expected = []string{"danish", "eclair", "banana", "apple"}
+	if actual := values(t, &l); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if other.Len() != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, other.Len())
+	}
+}
+
+func TestList_RemoveWhileIterating(t *testing.T) {
+	l := linkedlist.New[int]()
+	for i := 0; i < 10; i++ {
+		l.PushBack(i)
+	}
+	for e := range l.All() {
+		if e.Value%2 == 0 {
+			l.Remove(e)
+		}
+	}
+	for e := range l.Backward() {
+		if e.Value == 5 {
+			l.MoveToFront(e)
+		}
+	}
+	var actual []int
+	for e := range l.All() {
+		actual = append(actual, e.Value)
+	}
+	expected := []int{5, 1, 3, 7, 9}
+	if !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,157 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lrucache
+
+import (
+	"iter"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/linkedlist"
+)
+
+// A Cache maps keys to values, holding at most a fixed number of keys.  Putting a new key into a full Cache evicts
+// the least recently used key, that is the one least recently passed to Get or Put.  It is backed by a map from each
+// key to its Element in a linked list ordered from most to least recently used, so Get, Put and Delete are O(1).  A
+// Cache is not safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	entries  map[K]*linkedlist.Element[entry[K, V]]
+	order    linkedlist.List[entry[K, V]]
+	capacity int
+	onEvict  func(key K, value V)
+	hits     uint64
+	misses   uint64
+}
+
+// entry is a key in the Cache and its value.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// New returns an empty Cache holding at most capacity keys.  It panics if capacity is less than 1.
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	return NewWithEvict[K, V](capacity, nil)
+}
+
+// NewWithEvict returns an empty Cache holding at most capacity keys, which calls onEvict, if it is not nil, with each
+// key and value it evicts to make room for another.  Keys removed by Delete or Purge are not reported.  It panics if
+// capacity is less than 1.
+func NewWithEvict[K comparable, V any](capacity int, onEvict func(key K, value V)) *Cache[K, V] {
+	if capacity < 1 {
+		panic("lrucache: capacity must be at least 1")
+	}
+	return &Cache[K, V]{
+		entries:  make(map[K]*linkedlist.Element[entry[K, V]], capacity),
+		capacity: capacity,
+		onEvict:  onEvict,
+	}
+}
+
+// Len returns the number of keys in the Cache.
+func (c *Cache[K, V]) Len() int {
+	return len(c.entries)
+}
+
+// Cap returns the greatest number of keys the Cache holds.
+func (c *Cache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Get returns the value of key, marking key as the most recently used, and counts a hit.  The second return value is
+// false, and a miss is counted, if key is not in the Cache.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	c.hits++
+	c.order.MoveToFront(e)
+	return e.Value.value, true
+}
+
+// Peek returns the value of key like Get, but neither marks key as used nor counts a hit or miss.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// Contains reports whether key is in the Cache, without marking it as used.
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Put sets the value of key and marks it as the most recently used.  If key is new and the Cache is full, the least
+// recently used key is evicted first.  It returns true if a key was evicted.
+func (c *Cache[K, V]) Put(key K, value V) bool {
+	if e, ok := c.entries[key]; ok {
+		e.Value.value = value
+		c.order.MoveToFront(e)
+		return false
+	}
+	evicted := false
+	if len(c.entries) == c.capacity {
+		oldest := c.order.Remove(c.order.Back())
+		delete(c.entries, oldest.key)
+		if c.onEvict != nil {
+			c.onEvict(oldest.key, oldest.value)
+		}
+		evicted = true
+	}
+	c.entries[key] = c.order.PushFront(entry[K, V]{key: key, value: value})
+	return evicted
+}
+
+// Delete removes key from the Cache.  It returns false if key was not in the Cache.
+func (c *Cache[K, V]) Delete(key K) bool {
+	e, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	delete(c.entries, key)
+	c.order.Remove(e)
+	return true
+}
+
+// Purge removes every key from the Cache.  The hit and miss counters are kept.
+func (c *Cache[K, V]) Purge() {
+	clear(c.entries)
+	c.order.Init()
+}
+
+// Hits returns the number of calls to Get that found their key.
+func (c *Cache[K, V]) Hits() uint64 {
+	return c.hits
+}
+
+// Misses returns the number of calls to Get that did not find their key.
+func (c *Cache[K, V]) Misses() uint64 {
+	return c.misses
+}
+
+// All returns an iterator over the keys of the Cache and their values, from the most to the least recently used,
+// without marking them as used.  The Cache must not be modified during iteration.
+func (c *Cache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := range c.order.All() {
+			if !yield(e.Value.key, e.Value.value) {
+				return
+			}
+		}
+	}
+}
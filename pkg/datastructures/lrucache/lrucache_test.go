@@ -0,0 +1,72 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lrucache_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/lrucache"
+)
+
+// keys returns the keys of c from the most to the least recently used.
+func keys(c *lrucache.Cache[string, int]) []string {
+	var keys []string
+	for key := range c.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestCache(t *testing.T) {
+	var evicted []string
+	c := lrucache.NewWithEvict(2, func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if value, ok := c.Get("a"); !ok || value != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, value)
+	}
+	if !c.Put("c", 3) {
+		t.Fatalf("Expected Put into a full Cache to evict")
+	}
+	if expected := []string{"b"}; !slices.Equal(expected, evicted) {
+		t.Fatalf("Expected: %v Actual: %v", expected, evicted)
+	}
+	if expected, actual := []string{"c", "a"}, keys(c); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("Expected %s to have been evicted", "b")
+	}
+	if c.Hits() != 1 || c.Misses() != 1 {
+		t.Fatalf("Expected: %d hits and %d misses Actual: %d hits and %d misses", 1, 1, c.Hits(), c.Misses())
+	}
+	// Peek does not mark a key as used, so a is still evicted next.
+	c.Peek("a")
+	if c.Put("c", 4) {
+		t.Fatalf("Expected Put of an existing key not to evict")
+	}
+	c.Put("d", 5)
+	if expected := []string{"b", "a"}; !slices.Equal(expected, evicted) {
+		t.Fatalf("Expected: %v Actual: %v", expected, evicted)
+	}
+	if !c.Delete("c") || c.Delete("c") || c.Len() != 1 {
+		t.Fatalf("Expected to delete %s once", "c")
+	}
+	c.Purge()
+	if c.Len() != 0 || c.Cap() != 2 || len(evicted) != 2 {
+		t.Fatalf("Expected Purge to empty the Cache without evicting")
+	}
+}
@@ -0,0 +1,150 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multimap
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/orderedmap"
+)
+
+// A MultiMap maps each key to one or more values.  Keys iterate in the order they were first put, and the values of a
+// key in the order of its Storage.  A key is removed once its last value is.  The zero value is an empty MultiMap ready
+// to use, which keeps its values in SliceStorage.
+type MultiMap[K, V comparable] struct {
+	values     orderedmap.OrderedMap[K, Storage[V]]
+	size       int
+	newStorage func() Storage[V]
+}
+
+// New returns an empty MultiMap that keeps every value put, duplicates included.
+func New[K, V comparable]() *MultiMap[K, V] {
+	return NewWithStorage[K](SliceStorage[V])
+}
+
+// NewSet returns an empty MultiMap that discards a value put again under the same key.
+func NewSet[K, V comparable]() *MultiMap[K, V] {
+	return NewWithStorage[K](SetStorage[V])
+}
+
+// NewWithStorage returns an empty MultiMap that keeps the values of each key in a Storage returned by newStorage.
+func NewWithStorage[K, V comparable](newStorage func() Storage[V]) *MultiMap[K, V] {
+	return &MultiMap[K, V]{newStorage: newStorage}
+}
+
+// Len returns the number of values in the MultiMap, counting those of every key.
+func (m *MultiMap[K, V]) Len() int {
+	return m.size
+}
+
+// KeyCount returns the number of keys in the MultiMap.
+func (m *MultiMap[K, V]) KeyCount() int {
+	return m.values.Len()
+}
+
+// Put adds value to the values of key.  It returns false if the Storage of key discarded value as a duplicate.
+func (m *MultiMap[K, V]) Put(key K, value V) bool {
+	values, ok := m.values.Get(key)
+	if !ok {
+		if m.newStorage == nil {
+			m.newStorage = SliceStorage[V]
+		}
+		values = m.newStorage()
+		m.values.Set(key, values)
+	}
+	if !values.Add(value) {
+		return false
+	}
+	m.size++
+	return true
+}
+
+// Get returns the values of key in a new slice, or nil if key is not in the MultiMap.
+func (m *MultiMap[K, V]) Get(key K) []V {
+	values, ok := m.values.Get(key)
+	if !ok {
+		return nil
+	}
+	return slices.AppendSeq(make([]V, 0, values.Len()), values.All())
+}
+
+// Contains reports whether key is in the MultiMap.
+func (m *MultiMap[K, V]) Contains(key K) bool {
+	_, ok := m.values.Get(key)
+	return ok
+}
+
+// ContainsValue reports whether value is one of the values of key.
+func (m *MultiMap[K, V]) ContainsValue(key K, value V) bool {
+	values, ok := m.values.Get(key)
+	return ok && values.Contains(value)
+}
+
+// RemoveValue removes one occurrence of value from the values of key, and key itself if that was its last value.  It
+// returns false if value was not one of the values of key.
+func (m *MultiMap[K, V]) RemoveValue(key K, value V) bool {
+	values, ok := m.values.Get(key)
+	if !ok || !values.Remove(value) {
+		return false
+	}
+	m.size--
+	if values.Len() == 0 {
+		m.values.Delete(key)
+	}
+	return true
+}
+
+// Delete removes key and all of its values from the MultiMap, and returns the number of values removed.
+func (m *MultiMap[K, V]) Delete(key K) int {
+	values, ok := m.values.Get(key)
+	if !ok {
+		return 0
+	}
+	m.values.Delete(key)
+	m.size -= values.Len()
+	return values.Len()
+}
+
+// Keys returns an iterator over the keys of the MultiMap in the order they were first put.  The MultiMap must not be
+// modified during iteration.
+func (m *MultiMap[K, V]) Keys() iter.Seq[K] {
+	return m.values.Keys()
+}
+
+// Values returns an iterator over the values of key.  The MultiMap must not be modified during iteration.
+func (m *MultiMap[K, V]) Values(key K) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if values, ok := m.values.Get(key); ok {
+			for value := range values.All() {
+				if !yield(value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// All returns an iterator over every key and value of the MultiMap, yielding a key once for each of its values.  Keys
+// are in the order they were first put.  The MultiMap must not be modified during iteration.
+func (m *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, values := range m.values.All() {
+			for value := range values.All() {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
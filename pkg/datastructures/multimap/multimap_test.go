@@ -0,0 +1,67 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multimap_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/multimap"
+)
+
+func TestMultiMap(t *testing.T) {
+	var m multimap.MultiMap[string, int]
+	m.Put("b", 1)
+	m.Put("a", 2)
+	m.Put("b", 3)
+	m.Put("b", 1)
+	if expected, actual := []int{1, 3, 1}, m.Get("b"); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if m.Len() != 4 || m.KeyCount() != 2 {
+		t.Fatalf("Expected: %d, %d Actual: %d, %d", 4, 2, m.Len(), m.KeyCount())
+	}
+	if expected, actual := []string{"b", "a"}, slices.Collect(m.Keys()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	var pairs []string
+	for key, value := range m.All() {
+		pairs = append(pairs, key+string(rune('0'+value)))
+	}
+	if expected := []string{"b1", "b3", "b1", "a2"}; !slices.Equal(expected, pairs) {
+		t.Fatalf("Expected: %v Actual: %v", expected, pairs)
+	}
+	if !m.RemoveValue("a", 2) || m.RemoveValue("a", 2) || m.Contains("a") {
+		t.Fatalf("Expected removing the last value of %s to remove it", "a")
+	}
+	if !m.ContainsValue("b", 3) || m.ContainsValue("b", 2) || m.Get("a") != nil {
+		t.Fatalf("Expected: %v Actual: %v", []int{1, 3, 1}, m.Get("b"))
+	}
+	if n := m.Delete("b"); n != 3 || m.Len() != 0 || m.KeyCount() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 3, n)
+	}
+}
+
+func TestNewSet(t *testing.T) {
+	m := multimap.NewSet[string, string]()
+	for _, tag := range []string{"go", "data", "go", "structures"} {
+		m.Put("tags", tag)
+	}
+	expected, actual := []string{"go", "data", "structures"}, slices.Collect(m.Values("tags"))
+	if !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if m.Put("tags", "go") || m.Len() != 3 {
+		t.Fatalf("Expected Put of a duplicate to be discarded")
+	}
+}
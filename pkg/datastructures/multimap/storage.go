@@ -0,0 +1,106 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multimap
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/orderedmap"
+)
+
+// A Storage holds the values of one key of a MultiMap.  *set.Set satisfies Storage, but iterates in no particular
+// order.
+type Storage[V comparable] interface {
+	// Len returns the number of values in the Storage.
+	Len() int
+	// Add adds value to the Storage.  It returns false if the Storage discarded value as a duplicate.
+	Add(value V) bool
+	// Remove removes one occurrence of value from the Storage.  It returns false if value was not in the Storage.
+	Remove(value V) bool
+	// Contains reports whether value is in the Storage.
+	Contains(value V) bool
+	// All returns an iterator over the values of the Storage.
+	All() iter.Seq[V]
+}
+
+// SliceStorage returns an empty Storage that keeps every value added, duplicates included, in the order they were
+// added.  Add is O(1), while Remove and Contains are O(n).
+func SliceStorage[V comparable]() Storage[V] {
+	return &sliceStorage[V]{}
+}
+
+// sliceStorage is the Storage returned by SliceStorage.
+type sliceStorage[V comparable] struct {
+	values []V
+}
+
+// Len, Add, Remove, Contains and All implement Storage.
+func (s *sliceStorage[V]) Len() int {
+	return len(s.values)
+}
+
+func (s *sliceStorage[V]) Add(value V) bool {
+	s.values = append(s.values, value)
+	return true
+}
+
+func (s *sliceStorage[V]) Remove(value V) bool {
+	i := slices.Index(s.values, value)
+	if i < 0 {
+		return false
+	}
+	s.values = slices.Delete(s.values, i, i+1)
+	return true
+}
+
+func (s *sliceStorage[V]) Contains(value V) bool {
+	return slices.Contains(s.values, value)
+}
+
+func (s *sliceStorage[V]) All() iter.Seq[V] {
+	return slices.Values(s.values)
+}
+
+// SetStorage returns an empty Storage that discards duplicate values, keeping the others in the order they were first
+// added.  Add, Remove and Contains are O(1).
+func SetStorage[V comparable]() Storage[V] {
+	return &setStorage[V]{}
+}
+
+// setStorage is the Storage returned by SetStorage.
+type setStorage[V comparable] struct {
+	values orderedmap.OrderedMap[V, struct{}]
+}
+
+// Len, Add, Remove, Contains and All implement Storage.
+func (s *setStorage[V]) Len() int {
+	return s.values.Len()
+}
+
+func (s *setStorage[V]) Add(value V) bool {
+	return s.values.Set(value, struct{}{})
+}
+
+func (s *setStorage[V]) Remove(value V) bool {
+	return s.values.Delete(value)
+}
+
+func (s *setStorage[V]) Contains(value V) bool {
+	_, ok := s.values.Get(value)
+	return ok
+}
+
+func (s *setStorage[V]) All() iter.Seq[V] {
+	return s.values.Keys()
+}
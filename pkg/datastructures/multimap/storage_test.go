@@ -0,0 +1,53 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multimap_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/multimap"
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/set"
+)
+
+func TestStorage(t *testing.T) {
+	cases := []struct {
+		name     string
+		storage  multimap.Storage[string]
+		expected []string // The values after adding a, b, a and c and removing b.
+	}{
+		{"SliceStorage", multimap.SliceStorage[string](), []string{"a", "a", "c"}},
+		{"SetStorage", multimap.SetStorage[string](), []string{"a", "c"}},
+	}
+	for _, c := range cases {
+		for _, value := range []string{"a", "b", "a", "c"} {
+			c.storage.Add(value)
+		}
+		if !c.storage.Remove("b") || c.storage.Remove("b") || c.storage.Contains("b") || !c.storage.Contains("a") {
+			t.Fatalf("%s Expected to remove %s once", c.name, "b")
+		}
+		if actual := slices.Collect(c.storage.All()); !slices.Equal(c.expected, actual) || c.storage.Len() != len(actual) {
+			t.Fatalf("%s Expected: %v Actual: %v", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestStorage_Set(t *testing.T) {
+	m := multimap.NewWithStorage[string](func() multimap.Storage[int] {
+		return set.New[int]()
+	})
+	m.Put("a", 1)
+	if m.Put("a", 1) || m.Len() != 1 {
+		t.Fatalf("Expected set.Set to discard duplicates")
+	}
+}
@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package orderedmap implements a generic OrderedMap that remembers the order in which its keys were first set.
+Iteration and JSON marshaling follow that order, unlike Go's built in map, which makes it suitable for configuration and
+API payloads whose field order matters to people reading them.
+*/
+
+package orderedmap
@@ -0,0 +1,228 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orderedmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/linkedlist"
+)
+
+// A Pair is a key and its value.
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// An OrderedMap is a map that iterates over its keys in insertion order.  It is backed by a map from each key to its
+// Element in a linked list, so Get, Set and Delete are O(1).  The zero value is an empty OrderedMap ready to use.
+type OrderedMap[K comparable, V any] struct {
+	entries map[K]*linkedlist.Element[Pair[K, V]]
+	order   linkedlist.List[Pair[K, V]]
+}
+
+// New returns an empty OrderedMap.
+func New[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{entries: make(map[K]*linkedlist.Element[Pair[K, V]])}
+}
+
+// Len returns the number of keys in the OrderedMap.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Get returns the value of key.  The second return value is false if key is not in the OrderedMap.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	e, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.Value, true
+}
+
+// Set sets the value of key.  A new key is added after all other keys, while an existing key keeps its position.  It
+// returns true if key was added.
+func (m *OrderedMap[K, V]) Set(key K, value V) bool {
+	if e, ok := m.entries[key]; ok {
+		e.Value.Value = value
+		return false
+	}
+	if m.entries == nil {
+		m.entries = make(map[K]*linkedlist.Element[Pair[K, V]])
+	}
+	m.entries[key] = m.order.PushBack(Pair[K, V]{Key: key, Value: value})
+	return true
+}
+
+// Delete removes key from the OrderedMap.  It returns false if key was not in the OrderedMap.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	delete(m.entries, key)
+	m.order.Remove(e)
+	return true
+}
+
+// Oldest returns the first key in insertion order and its value.  The second return value is false if the OrderedMap is
+// empty.
+func (m *OrderedMap[K, V]) Oldest() (Pair[K, V], bool) {
+	if e := m.order.Front(); e != nil {
+		return e.Value, true
+	}
+	return Pair[K, V]{}, false
+}
+
+// Newest returns the last key in insertion order and its value.  The second return value is false if the OrderedMap is
+// empty.
+func (m *OrderedMap[K, V]) Newest() (Pair[K, V], bool) {
+	if e := m.order.Back(); e != nil {
+		return e.Value, true
+	}
+	return Pair[K, V]{}, false
+}
+
+// All returns an iterator over the keys and values of the OrderedMap in insertion order.  Deleting the current key
+// during iteration is safe.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := range m.order.All() {
+			if !yield(e.Value.Key, e.Value.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the OrderedMap in insertion order.
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the OrderedMap in the insertion order of their keys.
+func (m *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range m.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalJSON marshals the OrderedMap as an object whose members are in insertion order.  As with Go's map, keys must be
+// strings, integers or implement encoding.TextMarshaler.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for key, value := range m.All() {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		keyBytes, err := marshalKey(key)
+		if err != nil {
+			return nil, err
+		}
+		valueBytes, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyBytes)
+		buf.WriteByte(':')
+		buf.Write(valueBytes)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON replaces the contents of the OrderedMap with the members of the object in data, in the order they
+// appear.  A key that appears more than once keeps its first position and its last value.  The OrderedMap is left
+// unchanged if data cannot be decoded.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if token, err := dec.Token(); err != nil {
+		return err
+	} else if token != json.Delim('{') {
+		return fmt.Errorf("orderedmap: cannot unmarshal %v into an OrderedMap", token)
+	}
+	var pairs []Pair[K, V]
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := unmarshalKey[K](token.(string))
+		if err != nil {
+			return err
+		}
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		pairs = append(pairs, Pair[K, V]{Key: key, Value: value})
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	m.entries = make(map[K]*linkedlist.Element[Pair[K, V]], len(pairs))
+	m.order.Init()
+	for _, pair := range pairs {
+		m.Set(pair.Key, pair.Value)
+	}
+	return nil
+}
+
+// marshalKey marshals key as a JSON string, following the rules encoding/json applies to map keys.
+func marshalKey[K comparable](key K) ([]byte, error) {
+	keyBytes, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(keyBytes) > 0 && keyBytes[0] == '"' {
+		return keyBytes, nil
+	}
+	switch reflect.ValueOf(key).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return json.Marshal(string(keyBytes))
+	}
+	return nil, fmt.Errorf("orderedmap: unsupported key type %T", key)
+}
+
+// unmarshalKey unmarshals a key that was marshaled by marshalKey.
+func unmarshalKey[K comparable](s string) (K, error) {
+	var key K
+	quoted, err := json.Marshal(s)
+	if err != nil {
+		return key, err
+	}
+	if err := json.Unmarshal(quoted, &key); err == nil {
+		return key, nil
+	}
+	if err := json.Unmarshal([]byte(s), &key); err != nil {
+		return key, fmt.Errorf("orderedmap: cannot unmarshal key %q: %w", s, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,116 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orderedmap_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/orderedmap"
+)
+
+func TestOrderedMap(t *testing.T) {
+	var m orderedmap.OrderedMap[string, int]
+	for i, key := range []string{"zebra", "apple", "mango"} {
+		if !m.Set(key, i) {
+			t.Fatalf("Expected to add %s", key)
+		}
+	}
+	if m.Set("zebra", 10) {
+		t.Fatalf("Expected %s to already be present", "zebra")
+	}
+	if value, ok := m.Get("zebra"); !ok || value != 10 {
+		t.Fatalf("Expected: %d Actual: %d", 10, value)
+	}
+	if _, ok := m.Get("kiwi"); ok {
+		t.Fatalf("Expected %s to be absent", "kiwi")
+	}
+	if expected, actual := []string{"zebra", "apple", "mango"}, slices.Collect(m.Keys()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if !m.Delete("apple") || m.Delete("apple") {
+		t.Fatalf("Expected to delete %s once", "apple")
+	}
+	m.Set("apple", 3)
+	if expected, actual := []int{10, 2, 3}, slices.Collect(m.Values()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if oldest, _ := m.Oldest(); oldest.Key != "zebra" {
+		t.Fatalf("Expected: %s Actual: %s", "zebra", oldest.Key)
+	}
+	if newest, _ := m.Newest(); newest.Key != "apple" {
+		t.Fatalf("Expected: %s Actual: %s", "apple", newest.Key)
+	}
+	for key := range m.All() {
+		m.Delete(key)
+	}
+	if m.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, m.Len())
+	}
+}
+
+func TestOrderedMap_JSON(t *testing.T) {
+	m := orderedmap.New[string, []int]()
+	m.Set("b", []int{1})
+	m.Set("a", nil)
+	m.Set("c", []int{2, 3})
+	jsonBytes, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	if expected := `{"b":[1],"a":null,"c":[2,3]}`; string(jsonBytes) != expected {
+		t.Fatalf("Expected: %s Actual: %s", expected, jsonBytes)
+	}
+	restored := orderedmap.New[string, []int]()
+	if err := json.Unmarshal([]byte(`{"y": [1], "x": [], "y": [2]}`), restored); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	if expected, actual := []string{"y", "x"}, slices.Collect(restored.Keys()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if value, _ := restored.Get("y"); !slices.Equal([]int{2}, value) {
+		t.Fatalf("Expected: %v Actual: %v", []int{2}, value)
+	}
+	if err := json.Unmarshal([]byte(`[1]`), restored); err == nil {
+		t.Fatalf("Expected an error unmarshaling an array")
+	}
+}
+
+func TestOrderedMap_JSONIntegerKeys(t *testing.T) {
+	m := orderedmap.New[int, string]()
+	m.Set(3, "three")
+	m.Set(-1, "minus one")
+	jsonBytes, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	if expected := `{"3":"three","-1":"minus one"}`; string(jsonBytes) != expected {
+		t.Fatalf("Expected: %s Actual: %s", expected, jsonBytes)
+	}
+	restored := orderedmap.New[int, string]()
+	if err := json.Unmarshal(jsonBytes, restored); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	if expected, actual := []int{3, -1}, slices.Collect(restored.Keys()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if _, err := json.Marshal(orderedmap.New[bool, int]()); err != nil {
+		t.Fatalf("Unexpected error marshaling an empty OrderedMap: %s", err)
+	}
+	bools := orderedmap.New[bool, int]()
+	bools.Set(true, 1)
+	if _, err := json.Marshal(bools); err == nil {
+		t.Fatalf("Expected an error marshaling bool keys")
+	}
+}
@@ -0,0 +1,82 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/gob"
+	"errors"
+	"time"
+)
+
+// binaryVersion is the version of the encoding produced by MarshalBinary, written as its first byte.
+const binaryVersion = 1
+
+// binaryQueue is the layout encoded by MarshalBinary.
+type binaryQueue struct {
+	Reversed bool
+	Sequence uint64
+	Items    []binaryItem
+}
+
+// binaryItem is the layout of an Item encoded by MarshalBinary.
+type binaryItem struct {
+	Value    interface{}
+	Priority float64
+	Expiry   time.Time
+	Sequence uint64
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, and hence gob.GobEncoder.  Unlike MarshalJSON, the encoding holds
+// the Items in heap order along with the insertion sequence used to order Items of equal priority, and Priorities are
+// encoded exactly, so UnmarshalBinary restores the PriorityQueue byte-for-byte in O(n).  Values are encoded with gob,
+// so the concrete type of every Value must be registered with gob.Register.  The comparator, if any, is not encoded.
+func (pq *PriorityQueue) MarshalBinary() ([]byte, error) {
+	b := binaryQueue{Reversed: pq.reversed, Sequence: pq.sequence, Items: make([]binaryItem, len(pq.items))}
+	for i, item := range pq.items {
+		b.Items[i] = binaryItem{Value: item.Value, Priority: item.Priority, Expiry: item.Expiry, Sequence: item.sequence}
+	}
+	buffer := bytes.NewBuffer([]byte{binaryVersion})
+	if err := gob.NewEncoder(buffer).Encode(b); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, and hence gob.GobDecoder, by replacing the contents of the
+// PriorityQueue with those encoded in data by MarshalBinary.  The comparator of the PriorityQueue is kept, and should
+// order Items as the comparator of the encoded PriorityQueue did; otherwise the heap invariant is re-established in
+// O(n).  The PriorityQueue is left unchanged if data cannot be decoded.
+func (pq *PriorityQueue) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return errors.New("priorityqueue: unsupported binary encoding")
+	}
+	var b binaryQueue
+	if err := gob.NewDecoder(bytes.NewReader(data[1:])).Decode(&b); err != nil {
+		return err
+	}
+	for _, item := range pq.items {
+		item.index = -1
+	}
+	pq.items = make([]*Item, len(b.Items))
+	for i, r := range b.Items {
+		pq.items[i] = &Item{Value: r.Value, Priority: r.Priority, Expiry: r.Expiry, index: i, sequence: r.Sequence}
+	}
+	pq.reversed = b.Reversed
+	pq.sequence = b.Sequence
+	if pq.Verify() != nil {
+		heap.Init(pq)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestPriorityQueue_MarshalBinary(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	pq.Enqueue(&priorityqueue.Item{Value: "eclair", Priority: 5.0})
+	pq.Enqueue(&priorityqueue.Item{Value: "fig", Priority: 0.1 + 0.2, Expiry: time.Unix(1600000000, 0).UTC()})
+	data, err := pq.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected no error Actual: %s", err)
+	}
+	restored := &priorityqueue.PriorityQueue{}
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Expected no error Actual: %s", err)
+	}
+	again, _ := restored.MarshalBinary()
+	if !bytes.Equal(data, again) {
+		t.Fatalf("Expected: %v Actual: %v", data, again)
+	}
+	for pq.Len() > 0 {
+		expected, _ := pq.Dequeue()
+		actual, _ := restored.Dequeue()
+		if expected.Value != actual.Value || expected.Priority != actual.Priority || !expected.Expiry.Equal(actual.Expiry) {
+			t.Fatalf("Expected: %v Actual: %v", *expected, *actual)
+		}
+	}
+}
+
+func TestPriorityQueue_UnmarshalBinary_Invalid(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	for _, data := range [][]byte{nil, {0}, {1, 2, 3}} {
+		if err := pq.UnmarshalBinary(data); err == nil {
+			t.Fatalf("Expected an error for %v", data)
+		}
+	}
+	if pq.Len() != len(smallRaw) {
+		t.Fatalf("Expected: %d Actual: %d", len(smallRaw), pq.Len())
+	}
+}
+
+func TestQueue_Gob(t *testing.T) {
+	type checkpoint struct {
+		Name  string
+		Queue *priorityqueue.Queue
+	}
+	q := priorityqueue.NewQueue()
+	for value, priority := range smallRaw {
+		q.Push(&priorityqueue.Item{Value: value, Priority: priority})
+	}
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(checkpoint{Name: "pastries", Queue: q}); err != nil {
+		t.Fatalf("Expected no error Actual: %s", err)
+	}
+	var restored checkpoint
+	if err := gob.NewDecoder(&buffer).Decode(&restored); err != nil {
+		t.Fatalf("Expected no error Actual: %s", err)
+	}
+	expectedPopOrder := []string{"carrot", "apple", "banana", "danish"}
+	for _, expected := range expectedPopOrder {
+		item, _ := restored.Queue.Pop()
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+}
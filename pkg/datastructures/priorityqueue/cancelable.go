@@ -0,0 +1,123 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import "container/heap"
+
+var _ Interface = (*Cancelable)(nil)
+
+// DefaultCompactionThreshold is the fraction of cancelled Items at which a Cancelable created with a threshold of zero
+// compacts itself.
+const DefaultCompactionThreshold = 0.5
+
+// A Cancelable is a priority queue with lazy deletion, for uses such as schedulers that cancel far more Items than they
+// pop.  Cancel marks an Item dead in O(1) rather than removing it in O(log n); Pop and Peek discard dead Items as they
+// reach the front, and the whole heap is compacted in O(n) once the fraction of dead Items exceeds a threshold, so
+// cancelling costs O(1) amortized.  The zero value is an empty Cancelable that uses DefaultCompactionThreshold.
+type Cancelable struct {
+	pq        PriorityQueue
+	dead      map[*Item]struct{} // The cancelled Items still in the heap.
+	threshold float64
+}
+
+// NewCancelable returns an empty Cancelable that compacts itself once more than threshold of its Items are cancelled.
+// A threshold of zero means DefaultCompactionThreshold.  It panics if threshold is not between 0 and 1.
+func NewCancelable(threshold float64) *Cancelable {
+	if !(threshold >= 0 && threshold <= 1) {
+		panic("priorityqueue: compaction threshold must be between 0 and 1")
+	}
+	return &Cancelable{threshold: threshold}
+}
+
+// Len returns the number of Items in the Cancelable that have not been cancelled.
+func (q *Cancelable) Len() int {
+	return q.pq.Len() - len(q.dead)
+}
+
+// Push adds item to the Cancelable in O(log n).
+func (q *Cancelable) Push(item *Item) {
+	q.pq.Enqueue(item)
+}
+
+// Pop removes and returns the highest priority Item that has not been cancelled, discarding any cancelled Items ahead of
+// it.  It returns ErrEmpty if no such Item remains.
+func (q *Cancelable) Pop() (*Item, error) {
+	q.discardDead()
+	return q.pq.Dequeue()
+}
+
+// Peek returns the highest priority Item that has not been cancelled without removing it, discarding any cancelled
+// Items ahead of it.  It returns nil if no such Item remains.
+func (q *Cancelable) Peek() *Item {
+	q.discardDead()
+	return q.pq.Peek()
+}
+
+// Update modifies the Value and Priority of item, which must be in the Cancelable and not cancelled, in O(log n).
+func (q *Cancelable) Update(item *Item, value interface{}, priority float64) {
+	q.pq.Update(item, value, priority)
+}
+
+// Remove eagerly removes item from the Cancelable in O(log n).  It returns false if item is not in the Cancelable,
+// including if it was cancelled.
+func (q *Cancelable) Remove(item *Item) bool {
+	if _, ok := q.dead[item]; ok {
+		return false
+	}
+	return q.pq.Remove(item)
+}
+
+// Cancel marks item dead in O(1) amortized, so that it is never popped.  It returns false if item is not in the
+// Cancelable or was already cancelled.
+func (q *Cancelable) Cancel(item *Item) bool {
+	if _, ok := q.dead[item]; ok {
+		return false
+	}
+	if item.index < 0 || item.index >= q.pq.Len() || q.pq.items[item.index] != item {
+		return false
+	}
+	if q.dead == nil {
+		q.dead = make(map[*Item]struct{})
+	}
+	q.dead[item] = struct{}{}
+	threshold := q.threshold
+	if threshold == 0 {
+		threshold = DefaultCompactionThreshold
+	}
+	if float64(len(q.dead)) > threshold*float64(q.pq.Len()) {
+		q.Compact()
+	}
+	return true
+}
+
+// Compact removes every cancelled Item from the heap in O(n).  Compacted Items have their index set to -1.
+func (q *Cancelable) Compact() {
+	if len(q.dead) == 0 {
+		return
+	}
+	q.pq.retain(func(item *Item) bool {
+		_, ok := q.dead[item]
+		return !ok
+	})
+	clear(q.dead)
+}
+
+// discardDead pops cancelled Items from the front of the heap.
+func (q *Cancelable) discardDead() {
+	for q.pq.Len() > 0 {
+		if _, ok := q.dead[q.pq.items[0]]; !ok {
+			return
+		}
+		delete(q.dead, heap.Pop(&q.pq).(*Item))
+	}
+}
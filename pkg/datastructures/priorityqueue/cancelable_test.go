@@ -0,0 +1,77 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestCancelable(t *testing.T) {
+	q := priorityqueue.NewCancelable(0)
+	items := map[string]*priorityqueue.Item{}
+	for value, priority := range smallRaw {
+		items[value] = &priorityqueue.Item{Value: value, Priority: priority}
+		q.Push(items[value])
+	}
+	if !q.Cancel(items["carrot"]) {
+		t.Fatalf("Expected to cancel: %s", "carrot")
+	}
+	if q.Cancel(items["carrot"]) {
+		t.Fatalf("Expected not to cancel again: %s", "carrot")
+	}
+	if q.Remove(items["carrot"]) {
+		t.Fatalf("Expected not to remove: %s", "carrot")
+	}
+	if q.Len() != 3 {
+		t.Fatalf("Expected: %d Actual: %d", 3, q.Len())
+	}
+	if item := q.Peek(); item.Value != "apple" {
+		t.Fatalf("Expected: %s Actual: %s", "apple", item.Value)
+	}
+	q.Cancel(items["banana"])
+	expectedPopOrder := []string{"apple", "danish"}
+	for _, expected := range expectedPopOrder {
+		item, _ := q.Pop()
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+	if _, err := q.Pop(); err != priorityqueue.ErrEmpty {
+		t.Fatalf("Expected: %s Actual: %v", priorityqueue.ErrEmpty, err)
+	}
+}
+
+func TestCancelable_Compact(t *testing.T) {
+	var q priorityqueue.Cancelable
+	var items []*priorityqueue.Item
+	for i := 0; i < 1000; i++ {
+		item := &priorityqueue.Item{Value: i, Priority: float64(i)}
+		items = append(items, item)
+		q.Push(item)
+	}
+	// The heap is compacted whenever more than half of its Items are cancelled.
+	for _, item := range items[1:] {
+		q.Cancel(item)
+	}
+	if q.Len() != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, q.Len())
+	}
+	if item, _ := q.Pop(); item.Value != 0 {
+		t.Fatalf("Expected: %d Actual: %v", 0, item.Value)
+	}
+	if q.Cancel(items[999]) {
+		t.Fatalf("Expected not to cancel again: %d", 999)
+	}
+}
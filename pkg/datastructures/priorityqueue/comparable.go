@@ -0,0 +1,41 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import (
+	"container/heap"
+)
+
+// A Comparable is a Value that knows how to order itself, for use with NewFromComparable.
+type Comparable interface {
+	// ComparePriority returns a positive number if the receiver has higher priority than other, a negative number if it
+	// has lower priority, and zero if they have equal priority.
+	ComparePriority(other interface{}) int
+}
+
+// NewFromComparable returns a PriorityQueue holding values that is ordered by their ComparePriority methods rather than
+// by Priority, popping the highest priority value first.  Every Value subsequently pushed must also implement
+// Comparable.  The PriorityQueue is heapified in O(n).
+func NewFromComparable(values ...Comparable) *PriorityQueue {
+	pq := &PriorityQueue{
+		items: make([]*Item, 0, len(values)),
+		less: func(a, b *Item) bool {
+			return a.Value.(Comparable).ComparePriority(b.Value) > 0
+		},
+	}
+	for _, value := range values {
+		pq.append(&Item{Value: value})
+	}
+	heap.Init(pq)
+	return pq
+}
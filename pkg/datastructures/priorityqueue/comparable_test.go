@@ -0,0 +1,55 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"container/heap"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+// A ticket is ordered by highest severity, then by earliest deadline.
+type ticket struct {
+	name     string
+	severity int
+	deadline int
+}
+
+func (t ticket) ComparePriority(other interface{}) int {
+	o := other.(ticket)
+	if t.severity != o.severity {
+		return t.severity - o.severity
+	}
+	return o.deadline - t.deadline
+}
+
+func TestNewFromComparable(t *testing.T) {
+	pq := priorityqueue.NewFromComparable(
+		ticket{name: "outage", severity: 3, deadline: 5},
+		ticket{name: "typo", severity: 1, deadline: 1},
+		ticket{name: "breach", severity: 3, deadline: 2},
+		ticket{name: "slowness", severity: 2, deadline: 9},
+	)
+	heap.Push(pq, &priorityqueue.Item{Value: ticket{name: "crash", severity: 2, deadline: 4}})
+	expectedPopOrder := []string{"breach", "outage", "crash", "slowness", "typo"}
+	if pq.Len() != len(expectedPopOrder) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedPopOrder), pq.Len())
+	}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value.(ticket).name
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+// A DelayQueue holds Items until a ready-at time given when each is pushed, and releases them in order of that time.
+// It is safe for concurrent use, and is a building block for retry and backoff systems.  The zero value is an empty
+// DelayQueue ready to use.  A DelayQueue must not be copied after first use.
+type DelayQueue struct {
+	mu      sync.Mutex
+	changed sync.Cond // Broadcast whenever the earliest ready-at time may have changed; use cond to access.
+	pq      *priorityqueue.PriorityQueue
+}
+
+// delayed is the Value of the Items in the underlying PriorityQueue, pairing a pushed Item with its ready-at time.
+type delayed struct {
+	item    *priorityqueue.Item
+	readyAt time.Time
+}
+
+// NewDelayQueue returns an empty DelayQueue.
+func NewDelayQueue() *DelayQueue {
+	return &DelayQueue{}
+}
+
+// init returns the underlying PriorityQueue, creating it on first use.  q.mu must be held.
+func (q *DelayQueue) init() *priorityqueue.PriorityQueue {
+	if q.pq == nil {
+		q.pq = priorityqueue.NewWithComparator(func(a, b *priorityqueue.Item) bool {
+			return a.Value.(delayed).readyAt.Before(b.Value.(delayed).readyAt)
+		}, priorityqueue.Stable())
+	}
+	return q.pq
+}
+
+// cond returns the condition variable that is broadcast whenever the earliest ready-at time may have changed.  q.mu must
+// be held.
+func (q *DelayQueue) cond() *sync.Cond {
+	if q.changed.L == nil {
+		q.changed.L = &q.mu
+	}
+	return &q.changed
+}
+
+// broadcast wakes every goroutine blocked in Take so that it re-examines the DelayQueue.
+func (q *DelayQueue) broadcast() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.cond().Broadcast()
+}
+
+// Len returns the number of Items in the DelayQueue, whether ready or not.
+func (q *DelayQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.init().Len()
+}
+
+// Push adds item to the DelayQueue, to be released no earlier than readyAt.  Items with the same ready-at time are
+// released in the order they were pushed.
+func (q *DelayQueue) Push(item *priorityqueue.Item, readyAt time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.init().Enqueue(&priorityqueue.Item{Value: delayed{item: item, readyAt: readyAt}})
+	q.cond().Broadcast()
+}
+
+// Poll removes and returns the Item with the earliest ready-at time if that time has arrived, without blocking.  The
+// second return value is false if no Item is ready.
+func (q *DelayQueue) Poll() (*priorityqueue.Item, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item, _ := q.ready()
+	return item, item != nil
+}
+
+// Take removes and returns the Item with the earliest ready-at time, sleeping until that time arrives, or until ctx is
+// done, in which case the context's error is returned.  An Item pushed while Take sleeps with an earlier ready-at time
+// is taken in its place.
+func (q *DelayQueue) Take(ctx context.Context) (*priorityqueue.Item, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	stop := context.AfterFunc(ctx, q.broadcast)
+	defer stop()
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		item, wait := q.ready()
+		if item != nil {
+			return item, nil
+		}
+		if wait == 0 {
+			q.cond().Wait()
+			continue
+		}
+		timer := time.AfterFunc(wait, q.broadcast)
+		q.cond().Wait()
+		timer.Stop()
+	}
+}
+
+// ready pops the Item with the earliest ready-at time if that time has arrived.  Otherwise it returns how long remains
+// until that time, or zero if the DelayQueue is empty.  q.mu must be held.
+func (q *DelayQueue) ready() (*priorityqueue.Item, time.Duration) {
+	head := q.init().Peek()
+	if head == nil {
+		return nil, 0
+	}
+	d := head.Value.(delayed)
+	if wait := time.Until(d.readyAt); wait > 0 {
+		return nil, wait
+	}
+	q.pq.Dequeue()
+	return d.item, 0
+}
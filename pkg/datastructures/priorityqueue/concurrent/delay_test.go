@@ -0,0 +1,90 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrent_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue/concurrent"
+)
+
+func TestDelayQueue(t *testing.T) {
+	q := concurrent.NewDelayQueue()
+	start := time.Now()
+	q.Push(&priorityqueue.Item{Value: "apple"}, start.Add(40*time.Millisecond))
+	q.Push(&priorityqueue.Item{Value: "banana"}, start.Add(20*time.Millisecond))
+	q.Push(&priorityqueue.Item{Value: "carrot"}, start.Add(-time.Second))
+	q.Push(&priorityqueue.Item{Value: "danish"}, start.Add(20*time.Millisecond))
+	if item, ok := q.Poll(); !ok || item.Value != "carrot" {
+		t.Fatalf("Expected: %s Actual: %v", "carrot", item)
+	}
+	if item, ok := q.Poll(); ok {
+		t.Fatalf("Expected no ready Item Actual: %s", item.Value)
+	}
+	expectedTakeOrder := []struct {
+		value string
+		delay time.Duration
+	}{{"banana", 20 * time.Millisecond}, {"danish", 20 * time.Millisecond}, {"apple", 40 * time.Millisecond}}
+	for _, expected := range expectedTakeOrder {
+		item, err := q.Take(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error taking: %s", err)
+		}
+		if expected.value != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected.value, item.Value)
+		}
+		if elapsed := time.Since(start); elapsed < expected.delay {
+			t.Fatalf("Expected at least: %s Actual: %s", expected.delay, elapsed)
+		}
+	}
+	if q.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, q.Len())
+	}
+}
+
+func TestDelayQueue_EarlierPush(t *testing.T) {
+	var q concurrent.DelayQueue
+	q.Push(&priorityqueue.Item{Value: "apple"}, time.Now().Add(time.Hour))
+	notReady, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Take(notReady); err != context.DeadlineExceeded {
+		t.Fatalf("Expected: %s Actual: %v", context.DeadlineExceeded, err)
+	}
+	// A Take waiting for apple must be woken by an earlier Item, well before apple is ready.
+	go q.Push(&priorityqueue.Item{Value: "banana"}, time.Now().Add(10*time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	item, err := q.Take(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error taking: %s", err)
+	}
+	if item.Value != "banana" {
+		t.Fatalf("Expected: %s Actual: %s", "banana", item.Value)
+	}
+}
+
+func TestDelayQueue_TakeCancel(t *testing.T) {
+	q := concurrent.NewDelayQueue()
+	q.Push(&priorityqueue.Item{Value: "apple"}, time.Now().Add(time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Take(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Expected: %s Actual: %v", context.DeadlineExceeded, err)
+	}
+	if q.Len() != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, q.Len())
+	}
+}
@@ -0,0 +1,218 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrent
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+// A PriorityQueue is a priorityqueue.PriorityQueue that is safe for concurrent use.  The zero value is an empty
+// PriorityQueue ready to use.  A PriorityQueue must not be copied after first use.
+type PriorityQueue struct {
+	mu     sync.Mutex
+	pushed sync.Cond // Broadcast whenever Items are pushed; use cond to access.
+	pq     priorityqueue.PriorityQueue
+}
+
+// New returns an empty PriorityQueue.
+func New() *PriorityQueue {
+	return &PriorityQueue{}
+}
+
+// cond returns the condition variable that is broadcast whenever Items are pushed.  q.mu must be held.
+func (q *PriorityQueue) cond() *sync.Cond {
+	if q.pushed.L == nil {
+		q.pushed.L = &q.mu
+	}
+	return &q.pushed
+}
+
+// Len returns the number of Items in the PriorityQueue.
+func (q *PriorityQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Len()
+}
+
+// Push adds item to the PriorityQueue in O(log n).
+func (q *PriorityQueue) Push(item *priorityqueue.Item) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pq.Enqueue(item)
+	q.cond().Broadcast()
+}
+
+// requeue adds item, which was popped from the PriorityQueue, back in its original place.  See
+// priorityqueue.PriorityQueue.Requeue.
+func (q *PriorityQueue) requeue(item *priorityqueue.Item) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pq.Requeue(item)
+	q.cond().Broadcast()
+}
+
+// PushBatch adds items to the PriorityQueue, acquiring the lock once for the whole batch.  See
+// priorityqueue.PriorityQueue.PushAll.
+func (q *PriorityQueue) PushBatch(items []*priorityqueue.Item) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.cond().Broadcast()
+	q.pq.PushAll(items...)
+}
+
+// Peek returns a copy of the highest priority Item without removing it.  A copy is returned since the Item itself may
+// be modified by other goroutines once the lock is released.  The second return value is false if the PriorityQueue is
+// empty.
+func (q *PriorityQueue) Peek() (priorityqueue.Item, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item := q.pq.Peek()
+	if item == nil {
+		return priorityqueue.Item{}, false
+	}
+	return *item, true
+}
+
+// PopN pops up to n Items in priority order under a single acquisition of the lock.
+func (q *PriorityQueue) PopN(n int) []*priorityqueue.Item {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.PopN(n)
+}
+
+// Drain removes and returns every Item in priority order under a single acquisition of the lock.
+func (q *PriorityQueue) Drain() []*priorityqueue.Item {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Drain()
+}
+
+// PeekPriority returns the Priority of the highest priority Item.  The second return value is false if the
+// PriorityQueue is empty.
+func (q *PriorityQueue) PeekPriority() (float64, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.PeekPriority()
+}
+
+// UpdateByValue sets the Priority of the Item holding value to priority.  It returns false if no such Item exists.  See
+// priorityqueue.PriorityQueue.UpdateByValue.
+func (q *PriorityQueue) UpdateByValue(value interface{}, priority float64) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.UpdateByValue(value, priority)
+}
+
+// CompareAndPop atomically pops the highest priority Item only if its Priority equals expectedPriority.  It returns
+// false, leaving the PriorityQueue unchanged, if the PriorityQueue is empty or the Priority differs.  This allows a
+// consumer to act on the result of PeekPriority without racing other goroutines between the peek and the pop.
+func (q *PriorityQueue) CompareAndPop(expectedPriority float64) (*priorityqueue.Item, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item := q.pq.Peek()
+	if item == nil || item.Priority != expectedPriority {
+		return nil, false
+	}
+	item, _ = q.pq.Dequeue()
+	return item, true
+}
+
+// MarshalJSON marshals the PriorityQueue in priority order while holding the lock, so it is safe to call while other
+// goroutines modify the PriorityQueue.  See priorityqueue.PriorityQueue.MarshalJSON.
+func (q *PriorityQueue) MarshalJSON() ([]byte, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.MarshalJSON()
+}
+
+// Clone returns an independent copy of the PriorityQueue, taken while holding the lock, so that it may be analyzed while
+// other goroutines keep modifying the original.  See priorityqueue.PriorityQueue.Clone.
+func (q *PriorityQueue) Clone() *priorityqueue.PriorityQueue {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Clone()
+}
+
+// WaitForLen blocks until the PriorityQueue holds at least n Items, or until ctx is done, in which case the context's
+// error is returned.  It is useful for consumers that drain in batches.
+func (q *PriorityQueue) WaitForLen(ctx context.Context, n int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.wait(ctx, n)
+}
+
+// PopWait removes and returns the highest priority Item, blocking until one is available or until ctx is done, in
+// which case the context's error is returned.  This lets the PriorityQueue serve directly as a work dispatcher between
+// producer and consumer goroutines.
+func (q *PriorityQueue) PopWait(ctx context.Context) (*priorityqueue.Item, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.wait(ctx, 1); err != nil {
+		return nil, err
+	}
+	return q.pq.Dequeue()
+}
+
+// wait blocks until the PriorityQueue holds at least n Items, or until ctx is done, in which case the context's error
+// is returned.  q.mu must be held.
+func (q *PriorityQueue) wait(ctx context.Context, n int) error {
+	cond := q.cond()
+	stop := context.AfterFunc(ctx, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		cond.Broadcast()
+	})
+	defer stop()
+	for q.pq.Len() < n {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		cond.Wait()
+	}
+	return nil
+}
+
+// Pop removes and returns the highest priority Item in O(log n), or returns priorityqueue.ErrEmpty if the
+// PriorityQueue is empty.
+func (q *PriorityQueue) Pop() (*priorityqueue.Item, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Dequeue()
+}
+
+// DrainChan returns a channel that receives the Items of the PriorityQueue in priority order as they are popped.  The
+// channel is closed once the PriorityQueue is empty or ctx is done.  An Item popped but not yet received when ctx is done
+// is requeued in its original place among Items of equal priority, so no Item is lost or reordered; Items pushed
+// concurrently may also be received if they arrive before the PriorityQueue empties.
+func (q *PriorityQueue) DrainChan(ctx context.Context) <-chan *priorityqueue.Item {
+	items := make(chan *priorityqueue.Item)
+	go func() {
+		defer close(items)
+		for ctx.Err() == nil {
+			item, err := q.Pop()
+			if err != nil {
+				return
+			}
+			select {
+			case items <- item:
+			case <-ctx.Done():
+				q.requeue(item)
+				return
+			}
+		}
+	}()
+	return items
+}
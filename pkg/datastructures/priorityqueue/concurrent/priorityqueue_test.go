@@ -0,0 +1,513 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrent_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue/concurrent"
+)
+
+const (
+	producers = 8
+	batchSize = 100
+	batches   = 10
+)
+
+func generateBatch(producer, batch int) []*priorityqueue.Item {
+	items := make([]*priorityqueue.Item, batchSize)
+	for i := range items {
+		items[i] = &priorityqueue.Item{
+			Value:    producer,
+			Priority: float64((producer*batches+batch)*batchSize + i),
+		}
+	}
+	return items
+}
+
+// drain pops every Item and fails unless they come out in non-increasing Priority order.
+func drain(t *testing.T, q *concurrent.PriorityQueue) int {
+	count := 0
+	previous := 0.0
+	for {
+		item, err := q.Pop()
+		if err == priorityqueue.ErrEmpty {
+			return count
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error popping: %s", err)
+		}
+		if count > 0 && item.Priority > previous {
+			t.Fatalf("Out of order: %f popped after %f", item.Priority, previous)
+		}
+		previous = item.Priority
+		count++
+	}
+}
+
+func TestPriorityQueue_PushBatch(t *testing.T) {
+	q := concurrent.New()
+	var wg sync.WaitGroup
+	for producer := 0; producer < producers; producer++ {
+		wg.Add(1)
+		go func(producer int) {
+			defer wg.Done()
+			for batch := 0; batch < batches; batch++ {
+				q.PushBatch(generateBatch(producer, batch))
+			}
+		}(producer)
+	}
+	wg.Wait()
+
+	expectedLength := producers * batches * batchSize
+	if actual := q.Len(); expectedLength != actual {
+		t.Fatalf("Expected: %d Actual: %d", expectedLength, actual)
+	}
+	if actual := drain(t, q); expectedLength != actual {
+		t.Fatalf("Expected: %d Actual: %d", expectedLength, actual)
+	}
+}
+
+func TestPriorityQueue_ProducersConsumers(t *testing.T) {
+	const consumers = 4
+	q := concurrent.New()
+	expectedLength := producers * batches * batchSize
+	var producersDone sync.WaitGroup
+	for producer := 0; producer < producers; producer++ {
+		producersDone.Add(1)
+		go func(producer int) {
+			defer producersDone.Done()
+			for batch := 0; batch < batches; batch++ {
+				for _, item := range generateBatch(producer, batch) {
+					q.Push(item)
+				}
+			}
+		}(producer)
+	}
+	// Consumers block in PopWait until an Item arrives.  Once the producers finish, ctx is cancelled, and PopWait only
+	// reports that once the PriorityQueue is empty, so every Item is consumed.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	counts := make(chan int)
+	for consumer := 0; consumer < consumers; consumer++ {
+		go func() {
+			count := 0
+			for {
+				if _, err := q.PopWait(ctx); err != nil {
+					counts <- count
+					return
+				}
+				count++
+			}
+		}()
+	}
+	producersDone.Wait()
+	cancel()
+	total := 0
+	for consumer := 0; consumer < consumers; consumer++ {
+		total += <-counts
+	}
+	if expectedLength != total {
+		t.Fatalf("Expected: %d Actual: %d", expectedLength, total)
+	}
+}
+
+func TestPriorityQueue_PopNDrain(t *testing.T) {
+	q := concurrent.New()
+	for producer := 0; producer < producers; producer++ {
+		q.PushBatch(generateBatch(producer, 0))
+	}
+	var wg sync.WaitGroup
+	counts := make([]int, producers)
+	for consumer := range counts {
+		wg.Add(1)
+		go func(consumer int) {
+			defer wg.Done()
+			popped := q.PopN(batchSize / 2)
+			for i := 1; i < len(popped); i++ {
+				if popped[i].Priority > popped[i-1].Priority {
+					t.Errorf("Out of order: %f popped after %f", popped[i].Priority, popped[i-1].Priority)
+				}
+			}
+			counts[consumer] = len(popped)
+		}(consumer)
+	}
+	wg.Wait()
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	total += len(q.Drain())
+	if expected := producers * batchSize; expected != total {
+		t.Fatalf("Expected: %d Actual: %d", expected, total)
+	}
+	if actual := q.Len(); actual != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, actual)
+	}
+}
+
+func TestPriorityQueue_Peek(t *testing.T) {
+	q := concurrent.New()
+	if _, ok := q.Peek(); ok {
+		t.Fatalf("Expected no Item for an empty PriorityQueue")
+	}
+	q.Push(&priorityqueue.Item{Value: "apple", Priority: 10.0})
+	q.Push(&priorityqueue.Item{Value: "carrot", Priority: 11.0})
+	item, ok := q.Peek()
+	if !ok || item.Value != "carrot" || item.Priority != 11.0 {
+		t.Fatalf("Expected: %s Actual: %v", "carrot", item.Value)
+	}
+	if actual := q.Len(); actual != 2 {
+		t.Fatalf("Expected: %d Actual: %d", 2, actual)
+	}
+}
+
+func TestPriorityQueue_MarshalJSON(t *testing.T) {
+	q := concurrent.New()
+	var wg sync.WaitGroup
+	for producer := 0; producer < producers; producer++ {
+		wg.Add(1)
+		go func(producer int) {
+			defer wg.Done()
+			q.PushBatch(generateBatch(producer, 0))
+		}(producer)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			jsonBytes, err := json.Marshal(q)
+			if err != nil {
+				t.Errorf("Unexpected error marshaling JSON: %s", err)
+			}
+			var items []priorityqueue.Item
+			if err := json.Unmarshal(jsonBytes, &items); err != nil {
+				t.Errorf("Unexpected error unmarshaling JSON: %s", err)
+			}
+			if len(items)%batchSize != 0 {
+				t.Errorf("Expected whole batches, Actual: %d Items", len(items))
+			}
+		}()
+	}
+	wg.Wait()
+	if expected, actual := producers*batchSize, drain(t, q); expected != actual {
+		t.Fatalf("Expected: %d Actual: %d", expected, actual)
+	}
+}
+
+func TestPriorityQueue_Clone(t *testing.T) {
+	q := concurrent.New()
+	var wg sync.WaitGroup
+	for producer := 0; producer < producers; producer++ {
+		wg.Add(1)
+		go func(producer int) {
+			defer wg.Done()
+			q.PushBatch(generateBatch(producer, 0))
+		}(producer)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clone := q.Clone()
+			if clone.Len()%batchSize != 0 {
+				t.Errorf("Expected whole batches, Actual: %d Items", clone.Len())
+			}
+			clone.Drain()
+		}()
+	}
+	wg.Wait()
+	if expected, actual := producers*batchSize, drain(t, q); expected != actual {
+		t.Fatalf("Expected: %d Actual: %d", expected, actual)
+	}
+}
+
+func TestPriorityQueue_WaitForLen(t *testing.T) {
+	const threshold = 5
+	q := concurrent.New()
+	proceed := make(chan struct{})
+	pushed := make(chan int)
+	go func() {
+		for i := 0; i < threshold; i++ {
+			<-proceed
+			q.Push(&priorityqueue.Item{Value: i, Priority: float64(i)})
+			pushed <- i + 1
+		}
+	}()
+
+	done := make(chan error)
+	go func() {
+		done <- q.WaitForLen(context.Background(), threshold)
+	}()
+	for i := 0; i < threshold; i++ {
+		select {
+		case err := <-done:
+			t.Fatalf("Unblocked early with %d Items: %v", i, err)
+		default:
+		}
+		proceed <- struct{}{}
+		<-pushed
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected error waiting: %s", err)
+	}
+	if actual := q.Len(); actual != threshold {
+		t.Fatalf("Expected: %d Actual: %d", threshold, actual)
+	}
+}
+
+func TestPriorityQueue_WaitForLenCancel(t *testing.T) {
+	q := concurrent.New()
+	q.Push(&priorityqueue.Item{Value: "apple", Priority: 10.0})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- q.WaitForLen(ctx, 2)
+	}()
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Expected: %s Actual: %v", context.Canceled, err)
+	}
+	if err := q.WaitForLen(context.Background(), 1); err != nil {
+		t.Fatalf("Unexpected error waiting: %s", err)
+	}
+}
+
+func TestPriorityQueue_PopWait(t *testing.T) {
+	q := concurrent.New()
+	consumed := make(chan int, producers*batchSize)
+	ctx, cancel := context.WithCancel(context.Background())
+	var consumers sync.WaitGroup
+	for consumer := 0; consumer < producers; consumer++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				item, err := q.PopWait(ctx)
+				if err != nil {
+					if err != context.Canceled {
+						t.Errorf("Expected: %s Actual: %s", context.Canceled, err)
+					}
+					return
+				}
+				consumed <- int(item.Priority)
+			}
+		}()
+	}
+	var wg sync.WaitGroup
+	for producer := 0; producer < producers; producer++ {
+		wg.Add(1)
+		go func(producer int) {
+			defer wg.Done()
+			for _, item := range generateBatch(producer, 0) {
+				q.Push(item)
+			}
+		}(producer)
+	}
+	wg.Wait()
+	seen := make(map[int]bool)
+	for len(seen) < producers*batchSize {
+		priority := <-consumed
+		if seen[priority] {
+			t.Fatalf("Popped twice: %d", priority)
+		}
+		seen[priority] = true
+	}
+	cancel()
+	consumers.Wait()
+	if actual := q.Len(); actual != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, actual)
+	}
+}
+
+func TestPriorityQueue_CompareAndPop(t *testing.T) {
+	q := concurrent.New()
+	q.Push(&priorityqueue.Item{Value: "apple", Priority: 10.0})
+	q.Push(&priorityqueue.Item{Value: "carrot", Priority: 11.0})
+
+	peeked := make(chan float64)
+	updated := make(chan struct{})
+	go func() {
+		<-peeked
+		q.UpdateByValue("carrot", 1.0)
+		close(updated)
+	}()
+	priority, _ := q.PeekPriority()
+	peeked <- priority
+	<-updated
+	if item, ok := q.CompareAndPop(priority); ok {
+		t.Fatalf("Expected a refused pop, Actual: %v", item.Value)
+	}
+	priority, _ = q.PeekPriority()
+	item, ok := q.CompareAndPop(priority)
+	if !ok || item.Value != "apple" {
+		t.Fatalf("Expected: %s Actual: %v", "apple", item)
+	}
+}
+
+func TestPriorityQueue_CompareAndPopConcurrent(t *testing.T) {
+	const consumers = 8
+	q := concurrent.New()
+	expectedLength := producers * batches * batchSize
+	for producer := 0; producer < producers; producer++ {
+		for batch := 0; batch < batches; batch++ {
+			q.PushBatch(generateBatch(producer, batch))
+		}
+	}
+	var mu sync.Mutex
+	popped := map[float64]bool{}
+	var wg sync.WaitGroup
+	for consumer := 0; consumer < consumers; consumer++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				priority, ok := q.PeekPriority()
+				if !ok {
+					return
+				}
+				item, ok := q.CompareAndPop(priority)
+				if !ok {
+					continue
+				}
+				mu.Lock()
+				if popped[item.Priority] {
+					t.Errorf("Popped twice: %f", item.Priority)
+				}
+				popped[item.Priority] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(popped) != expectedLength {
+		t.Fatalf("Expected: %d Actual: %d", expectedLength, len(popped))
+	}
+}
+
+func TestPriorityQueue_DrainChan(t *testing.T) {
+	q := concurrent.New()
+	for producer := 0; producer < producers; producer++ {
+		q.PushBatch(generateBatch(producer, 0))
+	}
+	expectedLength := producers * batchSize
+	count := 0
+	previous := 0.0
+	for item := range q.DrainChan(context.Background()) {
+		if count > 0 && item.Priority > previous {
+			t.Fatalf("Out of order: %f received after %f", item.Priority, previous)
+		}
+		previous = item.Priority
+		count++
+	}
+	if expectedLength != count {
+		t.Fatalf("Expected: %d Actual: %d", expectedLength, count)
+	}
+	if actual := q.Len(); actual != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, actual)
+	}
+	if _, ok := <-q.DrainChan(context.Background()); ok {
+		t.Fatalf("Expected a closed channel for an empty PriorityQueue")
+	}
+}
+
+func TestPriorityQueue_DrainChanCancel(t *testing.T) {
+	q := concurrent.New()
+	q.PushBatch(generateBatch(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	items := q.DrainChan(ctx)
+	<-items
+	cancel()
+	received := 1
+	timeout := time.After(time.Second)
+	for closed := false; !closed; {
+		select {
+		case _, ok := <-items:
+			if ok {
+				received++
+			} else {
+				closed = true
+			}
+		case <-timeout:
+			t.Fatalf("Expected the channel to close after cancellation")
+		}
+	}
+	if actual := received + q.Len(); actual != batchSize {
+		t.Fatalf("Expected: %d Actual: %d", batchSize, actual)
+	}
+}
+
+func TestPriorityQueue_DrainChanCancelOrder(t *testing.T) {
+	// Whether DrainChan pops first before the cancellation is left to the scheduler, so the scenario is repeated to
+	// exercise the requeue.  Every interleaving must yield the Items in push order.
+	for i := 0; i < 100; i++ {
+		q := concurrent.New()
+		for _, value := range []string{"first", "second"} {
+			q.Push(&priorityqueue.Item{Value: value, Priority: 1.0})
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		items := q.DrainChan(ctx)
+		q.Push(&priorityqueue.Item{Value: "third", Priority: 1.0})
+		cancel()
+		var order []interface{}
+		for item := range items {
+			order = append(order, item.Value)
+		}
+		// A requeued Item keeps its place ahead of the one pushed after it.
+		for _, item := range q.Drain() {
+			order = append(order, item.Value)
+		}
+		expected := []interface{}{"first", "second", "third"}
+		if !slices.Equal(expected, order) {
+			t.Fatalf("Expected: %v Actual: %v", expected, order)
+		}
+	}
+}
+
+func BenchmarkPriorityQueue_Push(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		q := concurrent.New()
+		var wg sync.WaitGroup
+		for producer := 0; producer < producers; producer++ {
+			wg.Add(1)
+			go func(producer int) {
+				defer wg.Done()
+				for batch := 0; batch < batches; batch++ {
+					for _, item := range generateBatch(producer, batch) {
+						q.Push(item)
+					}
+				}
+			}(producer)
+		}
+		wg.Wait()
+	}
+}
+
+func BenchmarkPriorityQueue_PushBatch(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		q := concurrent.New()
+		var wg sync.WaitGroup
+		for producer := 0; producer < producers; producer++ {
+			wg.Add(1)
+			go func(producer int) {
+				defer wg.Done()
+				for batch := 0; batch < batches; batch++ {
+					q.PushBatch(generateBatch(producer, batch))
+				}
+			}(producer)
+		}
+		wg.Wait()
+	}
+}
@@ -0,0 +1,48 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteCSV writes the PriorityQueue to w as CSV in Pop order.  The first row is the header "value,priority", followed by
+// one row per Item.  Since Value is arbitrary, it is formatted using fmt.Sprint, so complex values are written using
+// their default formatting.  The PriorityQueue is not modified.
+func (pq *PriorityQueue) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"value", "priority"}); err != nil {
+		return err
+	}
+	for _, item := range pq.sorted() {
+		record := []string{fmt.Sprint(item.Value), strconv.FormatFloat(item.Priority, 'g', -1, 64)}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// MarshalCSV returns the CSV encoding of the PriorityQueue, as written by WriteCSV.
+func (pq *PriorityQueue) MarshalCSV() ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := pq.WriteCSV(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
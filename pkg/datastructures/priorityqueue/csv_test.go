@@ -0,0 +1,33 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"testing"
+)
+
+func TestPriorityQueue_MarshalCSV(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	csvBytes, err := pq.MarshalCSV()
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling CSV: %s", err)
+	}
+	expected := "value,priority\ncarrot,11\napple,10\nbanana,5\ndanish,0\n"
+	actual := string(csvBytes)
+	if expected != actual {
+		t.Fatalf("Expected: %q Actual: %q", expected, actual)
+	}
+	if pq.Len() != len(smallRaw) {
+		t.Fatalf("Expected: %d Actual: %d", len(smallRaw), pq.Len())
+	}
+}
@@ -0,0 +1,125 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+// A DAry is a priority queue backed by a d-ary rather than a binary heap.  A wider heap is shallower, so a Push sifts
+// through fewer levels, and its children are adjacent in memory, which is friendlier to the cache for large queues.  The
+// ordering matches the default PriorityQueue:  highest Priority first, and Items of equal Priority in the order they
+// were pushed.  Since container/heap only supports binary heaps, a DAry maintains the heap invariant itself and does not
+// implement heap.Interface.
+type DAry struct {
+	pq PriorityQueue // Holds the Items and their ordering.
+	d  int           // The arity of the heap.
+}
+
+// NewDAry returns an empty DAry with arity d.  It panics if d is less than 2.
+func NewDAry(d int) *DAry {
+	if d < 2 {
+		panic("priorityqueue: arity must be at least 2")
+	}
+	return &DAry{d: d}
+}
+
+// Len returns the number of Items in the DAry.
+func (h *DAry) Len() int {
+	return h.pq.Len()
+}
+
+// Push adds item to the DAry in O(log_d n).
+func (h *DAry) Push(item *Item) {
+	h.pq.append(item)
+	h.up(h.pq.Len() - 1)
+}
+
+// Pop removes and returns the highest priority Item in O(d log_d n), or returns ErrEmpty if the DAry is empty.
+func (h *DAry) Pop() (*Item, error) {
+	if h.pq.Len() == 0 {
+		return nil, ErrEmpty
+	}
+	n := h.pq.Len() - 1
+	h.pq.Swap(0, n)
+	h.down(0, n)
+	return h.pq.Pop().(*Item), nil
+}
+
+// Peek returns the highest priority Item without removing it in O(1), or nil if the DAry is empty.
+func (h *DAry) Peek() *Item {
+	return h.pq.Peek()
+}
+
+// Update modifies the Value and Priority of item, which must be in the DAry, and re-establishes the heap invariant.
+func (h *DAry) Update(item *Item, value interface{}, priority float64) {
+	item.Value = value
+	item.Priority = priority
+	h.fix(item.index)
+}
+
+// Remove removes item from the DAry.  It returns false if item is not in the DAry.
+func (h *DAry) Remove(item *Item) bool {
+	i := item.index
+	if i < 0 || i >= h.pq.Len() || h.pq.items[i] != item {
+		return false
+	}
+	n := h.pq.Len() - 1
+	if n != i {
+		h.pq.Swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	h.pq.Pop()
+	return true
+}
+
+// fix re-establishes the heap invariant after the Item at index i has changed.
+func (h *DAry) fix(i int) {
+	if !h.down(i, h.pq.Len()) {
+		h.up(i)
+	}
+}
+
+// up sifts the Item at index j towards the root, as in container/heap.
+func (h *DAry) up(j int) {
+	for j > 0 {
+		i := (j - 1) / h.d // parent
+		if !h.pq.Less(j, i) {
+			break
+		}
+		h.pq.Swap(i, j)
+		j = i
+	}
+}
+
+// down sifts the Item at index i0 towards the leaves of the first n Items, and reports whether it moved, as in
+// container/heap.
+func (h *DAry) down(i0, n int) bool {
+	i := i0
+	for {
+		first := h.d*i + 1
+		if first >= n || first < 0 { // first < 0 after int overflow
+			break
+		}
+		best := first
+		for c := first + 1; c < first+h.d && c < n; c++ {
+			if h.pq.Less(c, best) {
+				best = c
+			}
+		}
+		if !h.pq.Less(best, i) {
+			break
+		}
+		h.pq.Swap(i, best)
+		i = best
+	}
+	return i > i0
+}
@@ -0,0 +1,117 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"container/heap"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestDAry(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		h := priorityqueue.NewDAry(d)
+		for value, priority := range smallRaw {
+			h.Push(&priorityqueue.Item{Value: value, Priority: priority})
+		}
+		expectedPopOrder := []string{"carrot", "apple", "banana", "danish"}
+		for _, expected := range expectedPopOrder {
+			if actual := h.Peek().Value; expected != actual {
+				t.Fatalf("%d: Expected: %s Actual: %s", d, expected, actual)
+			}
+			item, err := h.Pop()
+			if err != nil {
+				t.Fatalf("%d: Unexpected error popping: %s", d, err)
+			}
+			if expected != item.Value {
+				t.Fatalf("%d: Expected: %s Actual: %s", d, expected, item.Value)
+			}
+		}
+		if _, err := h.Pop(); err != priorityqueue.ErrEmpty {
+			t.Fatalf("%d: Expected: %s Actual: %v", d, priorityqueue.ErrEmpty, err)
+		}
+	}
+}
+
+func TestDAry_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, d := range []int{2, 3, 4, 8} {
+		h := priorityqueue.NewDAry(d)
+		reference := &priorityqueue.PriorityQueue{}
+		var items []*priorityqueue.Item
+		for i := 0; i < 2000; i++ {
+			priority := float64(r.Intn(100))
+			item := &priorityqueue.Item{Value: i, Priority: priority}
+			items = append(items, item)
+			h.Push(item)
+			heap.Push(reference, &priorityqueue.Item{Value: i, Priority: priority})
+		}
+		for i := 0; i < 200; i++ {
+			item := items[r.Intn(len(items))]
+			if h.Remove(item) {
+				removed, _ := reference.ItemAt(reference.IndexOf(item.Value))
+				reference.Remove(removed)
+			}
+			item = items[r.Intn(len(items))]
+			if index := reference.IndexOf(item.Value); index >= 0 {
+				priority := float64(r.Intn(100))
+				h.Update(item, item.Value, priority)
+				reference.UpdateByValue(item.Value, priority)
+			}
+		}
+		if h.Len() != reference.Len() {
+			t.Fatalf("%d: Expected: %d Actual: %d", d, reference.Len(), h.Len())
+		}
+		for reference.Len() > 0 {
+			expected := heap.Pop(reference).(*priorityqueue.Item)
+			actual, _ := h.Pop()
+			if expected.Priority != actual.Priority {
+				t.Fatalf("%d: Expected: %f Actual: %f", d, expected.Priority, actual.Priority)
+			}
+		}
+	}
+}
+
+func benchmarkPushPop(b *testing.B, push func(*priorityqueue.Item), pop func()) {
+	r := rand.New(rand.NewSource(1))
+	items := make([]*priorityqueue.Item, 100000)
+	for i := range items {
+		items[i] = &priorityqueue.Item{Value: i, Priority: r.Float64()}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, item := range items {
+			push(item)
+		}
+		for range items {
+			pop()
+		}
+	}
+}
+
+func BenchmarkPriorityQueue_PushPop(b *testing.B) {
+	pq := &priorityqueue.PriorityQueue{}
+	benchmarkPushPop(b, pq.Enqueue, func() { pq.Dequeue() })
+}
+
+func BenchmarkDAry_PushPop(b *testing.B) {
+	for _, d := range []int{4, 8} {
+		h := priorityqueue.NewDAry(d)
+		b.Run(strconv.Itoa(d), func(b *testing.B) {
+			benchmarkPushPop(b, h.Push, func() { h.Pop() })
+		})
+	}
+}
@@ -13,6 +13,16 @@
 /*
 Package priorityqueue implements a generic PriorityQueue.  This implementation was adapted from:
 https://golang.org/pkg/container/heap/.
+
+PriorityQueue implements heap.Interface, so it may be driven directly with container/heap.  In that case the usual
+container/heap behavior applies; in particular heap.Pop panics on an empty PriorityQueue.  The methods Enqueue, Dequeue,
+Peek and UpdateByValue never panic, and instead report an empty PriorityQueue or a missing value through ErrEmpty, a nil
+Item or a false return value.  UpdateByValue reports a value that is not comparable, such as a slice, as missing.  Queue wraps a PriorityQueue without exposing heap.Interface, for callers that do not need
+container/heap at all.
+
+Queue, DAry and Pairing all satisfy Interface, so callers written against Interface may switch between the binary heap,
+the d-ary heap and the pairing heap without code changes.  Expiring also satisfies Interface, and additionally drops
+Items once their Expiry has passed.
 */
 
 package priorityqueue
@@ -0,0 +1,103 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import (
+	"container/heap"
+	"time"
+)
+
+var _ Interface = (*Expiring)(nil)
+
+// minSweepLen is the smallest number of Items at which Push considers sweeping expired Items, so that small queues are
+// not swept on every Push.
+const minSweepLen = 64
+
+// An Expiring is a priority queue whose Items drop out once their Expiry has passed.  Expired Items are skipped by Pop
+// and Peek, which discard them as they reach the front.  Expired Items buried deeper in the heap are reclaimed by Sweep,
+// which Push also runs whenever the number of Items has doubled since the last sweep, so the cost of sweeping is O(1)
+// amortized per Push.  Items with a zero Expiry never expire.  The zero value is an empty Expiring that pops the
+// highest Priority first.
+type Expiring struct {
+	pq      PriorityQueue
+	sweepAt int // The number of Items at which Push next sweeps.
+}
+
+// NewExpiring returns an empty Expiring.
+func NewExpiring() *Expiring {
+	return &Expiring{}
+}
+
+// Len returns the number of Items in the Expiring, including expired Items that have not been reclaimed yet.
+func (q *Expiring) Len() int {
+	return q.pq.Len()
+}
+
+// Push adds item to the Expiring in O(log n) amortized.
+func (q *Expiring) Push(item *Item) {
+	q.pq.Enqueue(item)
+	if q.pq.Len() >= q.sweepAt {
+		q.Sweep()
+		q.sweepAt = 2 * q.pq.Len()
+		if q.sweepAt < minSweepLen {
+			q.sweepAt = minSweepLen
+		}
+	}
+}
+
+// Pop removes and returns the highest priority Item that has not expired, discarding any expired Items ahead of it.  It
+// returns ErrEmpty if no such Item remains.
+func (q *Expiring) Pop() (*Item, error) {
+	q.discardExpired(time.Now())
+	return q.pq.Dequeue()
+}
+
+// Peek returns the highest priority Item that has not expired without removing it, discarding any expired Items ahead
+// of it.  It returns nil if no such Item remains.
+func (q *Expiring) Peek() *Item {
+	q.discardExpired(time.Now())
+	return q.pq.Peek()
+}
+
+// Update modifies the Value and Priority of item, which must be in the Expiring, in O(log n).  The Expiry of item is
+// left unchanged.
+func (q *Expiring) Update(item *Item, value interface{}, priority float64) {
+	q.pq.Update(item, value, priority)
+}
+
+// Remove removes item from the Expiring in O(log n).  It returns false if item is not in the Expiring, including if it
+// expired and was discarded.
+func (q *Expiring) Remove(item *Item) bool {
+	return q.pq.Remove(item)
+}
+
+// Sweep discards every expired Item in O(n) and returns how many were discarded.  Discarded Items have their index set
+// to -1.
+func (q *Expiring) Sweep() int {
+	now := time.Now()
+	return q.pq.retain(func(item *Item) bool {
+		return !item.expired(now)
+	})
+}
+
+// discardExpired pops expired Items from the front of the heap.
+func (q *Expiring) discardExpired(now time.Time) {
+	for q.pq.Len() > 0 && q.pq.items[0].expired(now) {
+		heap.Pop(&q.pq)
+	}
+}
+
+// expired reports whether the Expiry of item has passed at now.
+func (item *Item) expired(now time.Time) bool {
+	return !item.Expiry.IsZero() && !now.Before(item.Expiry)
+}
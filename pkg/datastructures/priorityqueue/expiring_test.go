@@ -0,0 +1,70 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestExpiring(t *testing.T) {
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	q := priorityqueue.NewExpiring()
+	q.Push(&priorityqueue.Item{Value: "apple", Priority: 10.0, Expiry: future})
+	q.Push(&priorityqueue.Item{Value: "banana", Priority: 5.0})
+	q.Push(&priorityqueue.Item{Value: "carrot", Priority: 11.0, Expiry: past})
+	q.Push(&priorityqueue.Item{Value: "danish", Priority: 0.0, Expiry: past})
+	if q.Len() != 4 {
+		t.Fatalf("Expected: %d Actual: %d", 4, q.Len())
+	}
+	if item := q.Peek(); item.Value != "apple" {
+		t.Fatalf("Expected: %s Actual: %s", "apple", item.Value)
+	}
+	if q.Len() != 3 {
+		t.Fatalf("Expected: %d Actual: %d", 3, q.Len())
+	}
+	if swept := q.Sweep(); swept != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, swept)
+	}
+	expectedPopOrder := []string{"apple", "banana"}
+	for _, expected := range expectedPopOrder {
+		item, _ := q.Pop()
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+	if _, err := q.Pop(); err != priorityqueue.ErrEmpty {
+		t.Fatalf("Expected: %s Actual: %v", priorityqueue.ErrEmpty, err)
+	}
+}
+
+func TestExpiring_PushSweeps(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	var q priorityqueue.Expiring
+	q.Push(&priorityqueue.Item{Value: "apple", Priority: 10.0})
+	for i := 0; i < 1000; i++ {
+		q.Push(&priorityqueue.Item{Value: i, Priority: 5.0, Expiry: past})
+	}
+	// Expired Items are reclaimed whenever the Expiring doubles in size, so they never accumulate without bound.
+	if q.Len() > 128 {
+		t.Fatalf("Expected at most: %d Actual: %d", 128, q.Len())
+	}
+	if item, _ := q.Pop(); item.Value != "apple" {
+		t.Fatalf("Expected: %s Actual: %s", "apple", item.Value)
+	}
+	if q.Peek() != nil {
+		t.Fatalf("Expected no Item")
+	}
+}
@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package external implements a PriorityQueue that holds more Items than fit in memory.  It keeps at most a configured
+number of Items in memory, and spills the rest to disk as sorted runs, which are merged as Items are popped, and merged into fewer runs whenever
+there are too many to keep open.
+*/
+
+package external
@@ -0,0 +1,284 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+// A PriorityQueue is a priority queue that spills to disk.  Pushed Items are buffered in memory until the buffer holds
+// the configured budget of Items, at which point the buffer is written to a temporary file as a run sorted in Pop order.
+// Pop then merges the buffer with the heads of the runs.  Each run keeps its file open, with a 4 KiB read buffer, a gob
+// decoder and its decoded head, and once a spill leaves more than 16 runs the smallest runs are merged into one.  So a
+// PriorityQueue holds at most budget Items, 16 heads and 16 open files, whatever the number of Items on disk, at the
+// cost of rewriting each spilled Item about once for every 16-fold growth of the Items on disk.  Values are encoded
+// with gob, so the concrete type of every Value must be registered with gob.Register, and Items popped from a run are
+// copies of the Items pushed.  Items of equal Priority are popped in no particular order.  A PriorityQueue must be
+// closed to remove its remaining runs.
+type PriorityQueue struct {
+	dir    string
+	budget int
+	buffer priorityqueue.PriorityQueue // The Items not yet spilled.
+	runs   priorityqueue.PriorityQueue // The unexhausted runs as Values, each with the Priority of its head.
+	n      int                         // The number of Items, whether buffered or spilled.
+}
+
+// fanIn is the most runs a PriorityQueue keeps open.
+const fanIn = 16
+
+// A run is a temporary file holding Items in Pop order, of which only the head is decoded.
+type run struct {
+	file      *os.File
+	decoder   *gob.Decoder
+	head      *priorityqueue.Item
+	remaining int // The number of Items in the file after head.
+}
+
+// record is the layout of an Item in a run.
+type record struct {
+	Value    interface{}
+	Priority float64
+	Expiry   time.Time
+}
+
+// New returns an empty PriorityQueue that holds at most budget Items in memory, and writes its runs to dir.  If dir is
+// empty, the default directory for temporary files is used.  It panics if budget is less than 1.
+func New(dir string, budget int) *PriorityQueue {
+	if budget < 1 {
+		panic("external: budget must be at least 1")
+	}
+	return &PriorityQueue{dir: dir, budget: budget}
+}
+
+// Len returns the number of Items in the PriorityQueue, whether in memory or on disk.
+func (pq *PriorityQueue) Len() int {
+	return pq.n
+}
+
+// Push adds item to the PriorityQueue.  If the buffer is full, it is first spilled to disk as a new run, and any error
+// writing the run is returned without adding item.  If the spill leaves too many runs, the smallest are then merged,
+// and an error merging them is returned after adding item, along with the loss of the Items of the merged runs.
+func (pq *PriorityQueue) Push(item *priorityqueue.Item) error {
+	if pq.buffer.Len() >= pq.budget {
+		if err := pq.spill(); err != nil {
+			return err
+		}
+	}
+	pq.buffer.Enqueue(item)
+	pq.n++
+	if pq.runs.Len() > fanIn {
+		return pq.merge()
+	}
+	return nil
+}
+
+// Peek returns the highest priority Item without removing it, or nil if the PriorityQueue is empty.
+func (pq *PriorityQueue) Peek() *priorityqueue.Item {
+	if r := pq.nextRun(); r != nil {
+		return r.head
+	}
+	return pq.buffer.Peek()
+}
+
+// Pop removes and returns the highest priority Item, or returns priorityqueue.ErrEmpty if the PriorityQueue is empty.
+// An error reading the next Item of a run is returned along with the popped Item.
+func (pq *PriorityQueue) Pop() (*priorityqueue.Item, error) {
+	r := pq.nextRun()
+	if r == nil {
+		item, err := pq.buffer.Dequeue()
+		if err == nil {
+			pq.n--
+		}
+		return item, err
+	}
+	item := r.head
+	pq.n--
+	entry := pq.runs.Peek()
+	if r.remaining == 0 {
+		pq.runs.Dequeue()
+		return item, r.close()
+	}
+	if err := r.advance(); err != nil {
+		pq.runs.Dequeue()
+		pq.n -= r.remaining
+		r.close()
+		return item, err
+	}
+	pq.runs.Update(entry, r, r.head.Priority)
+	return item, nil
+}
+
+// Close removes every run from disk and empties the PriorityQueue.  It returns the first error encountered.
+func (pq *PriorityQueue) Close() error {
+	var err error
+	for _, entry := range pq.runs.Drain() {
+		if closeErr := entry.Value.(*run).close(); err == nil {
+			err = closeErr
+		}
+	}
+	pq.buffer.Clear()
+	pq.n = 0
+	return err
+}
+
+// nextRun returns the run whose head is to be popped before the highest priority buffered Item, or nil if there is
+// none.
+func (pq *PriorityQueue) nextRun() *run {
+	entry := pq.runs.Peek()
+	if entry == nil {
+		return nil
+	}
+	if buffered := pq.buffer.Peek(); buffered != nil && buffered.Priority >= entry.Priority {
+		return nil
+	}
+	return entry.Value.(*run)
+}
+
+// spill writes the buffer to a new run in Pop order and empties the buffer.  The buffer is restored if the run cannot
+// be written.
+func (pq *PriorityQueue) spill() error {
+	items := pq.buffer.Drain()
+	i := 0
+	r, err := pq.write(func() (*priorityqueue.Item, error) {
+		if i == len(items) {
+			return nil, nil
+		}
+		i++
+		return items[i-1], nil
+	})
+	if err != nil {
+		pq.buffer.PushAll(items...)
+		return err
+	}
+	pq.runs.Enqueue(&priorityqueue.Item{Value: r, Priority: r.head.Priority})
+	return nil
+}
+
+// merge merges the smallest runs into one, so that fanIn runs remain.  Merging the smallest runs keeps the runs of
+// similar sizes, so each Item is rewritten about once for every fanIn-fold growth of the runs.  If the merged run
+// cannot be written, the Items of the runs being merged are dropped and the error is returned.
+func (pq *PriorityQueue) merge() error {
+	entries := pq.runs.Drain()
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Value.(*run).remaining < entries[j].Value.(*run).remaining
+	})
+	merged := len(entries) - fanIn + 1
+	var sources priorityqueue.PriorityQueue
+	dropped := 0
+	for _, entry := range entries[:merged] {
+		sources.Enqueue(entry)
+		dropped += entry.Value.(*run).remaining + 1
+	}
+	for _, entry := range entries[merged:] {
+		pq.runs.Enqueue(entry)
+	}
+	r, err := pq.write(func() (*priorityqueue.Item, error) {
+		entry := sources.Peek()
+		if entry == nil {
+			return nil, nil
+		}
+		source := entry.Value.(*run)
+		item := source.head
+		if source.remaining == 0 {
+			sources.Dequeue()
+			return item, source.close()
+		}
+		if err := source.advance(); err != nil {
+			return nil, err
+		}
+		sources.Update(entry, source, source.head.Priority)
+		return item, nil
+	})
+	if err != nil {
+		for _, entry := range sources.Drain() {
+			entry.Value.(*run).close()
+		}
+		pq.n -= dropped
+		return err
+	}
+	pq.runs.Enqueue(&priorityqueue.Item{Value: r, Priority: r.head.Priority})
+	return nil
+}
+
+// write writes the Items returned by next to a new run, until next returns nil, and decodes the head of the run.  The
+// run is removed if it cannot be written.
+func (pq *PriorityQueue) write(next func() (*priorityqueue.Item, error)) (r *run, err error) {
+	file, err := os.CreateTemp(pq.dir, "run-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}()
+	writer := bufio.NewWriter(file)
+	encoder := gob.NewEncoder(writer)
+	n := 0
+	for {
+		item, err := next()
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			break
+		}
+		if err := encoder.Encode(record{Value: item.Value, Priority: item.Priority, Expiry: item.Expiry}); err != nil {
+			return nil, err
+		}
+		n++
+	}
+	if err := writer.Flush(); err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	r = &run{file: file, decoder: gob.NewDecoder(bufio.NewReader(file)), remaining: n}
+	if err := r.advance(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// advance decodes the next Item of the run into head.
+func (r *run) advance() error {
+	var rec record
+	if err := r.decoder.Decode(&rec); err != nil {
+		if err == io.EOF {
+			err = errors.New("external: run ended early")
+		}
+		return err
+	}
+	r.head = &priorityqueue.Item{Value: rec.Value, Priority: rec.Priority, Expiry: rec.Expiry}
+	r.remaining--
+	return nil
+}
+
+// close closes and removes the file of the run.
+func (r *run) close() error {
+	err := r.file.Close()
+	if removeErr := os.Remove(r.file.Name()); err == nil {
+		err = removeErr
+	}
+	return err
+}
@@ -0,0 +1,143 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external_test
+
+import (
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue/external"
+)
+
+var smallRaw = map[string]float64{"apple": 10.0, "banana": 5.0, "carrot": 11.0, "danish": 0.0}
+
+// runs returns the number of runs on disk in dir.
+func runs(t *testing.T, dir string) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error reading %s: %s", dir, err)
+	}
+	return len(entries)
+}
+
+func TestPriorityQueue(t *testing.T) {
+	dir := t.TempDir()
+	pq := external.New(dir, 2)
+	defer pq.Close()
+	for value, priority := range smallRaw {
+		if err := pq.Push(&priorityqueue.Item{Value: value, Priority: priority}); err != nil {
+			t.Fatalf("Unexpected error pushing: %s", err)
+		}
+	}
+	if actual := runs(t, dir); actual != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, actual)
+	}
+	if item := pq.Peek(); item.Value != "carrot" {
+		t.Fatalf("Expected: %s Actual: %s", "carrot", item.Value)
+	}
+	expectedPopOrder := []string{"carrot", "apple", "banana", "danish"}
+	if pq.Len() != len(expectedPopOrder) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedPopOrder), pq.Len())
+	}
+	for _, expected := range expectedPopOrder {
+		item, err := pq.Pop()
+		if err != nil {
+			t.Fatalf("Unexpected error popping: %s", err)
+		}
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+	if _, err := pq.Pop(); err != priorityqueue.ErrEmpty {
+		t.Fatalf("Expected: %s Actual: %v", priorityqueue.ErrEmpty, err)
+	}
+	if actual := runs(t, dir); actual != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, actual)
+	}
+}
+
+func TestPriorityQueue_Random(t *testing.T) {
+	dir := t.TempDir()
+	r := rand.New(rand.NewSource(1))
+	pq := external.New(dir, 100)
+	reference := &priorityqueue.PriorityQueue{}
+	for i := 0; i < 10000; i++ {
+		priority := r.Float64()
+		if err := pq.Push(&priorityqueue.Item{Value: i, Priority: priority}); err != nil {
+			t.Fatalf("Unexpected error pushing: %s", err)
+		}
+		reference.Enqueue(&priorityqueue.Item{Value: i, Priority: priority})
+		if i%3 == 0 {
+			expected, _ := reference.Dequeue()
+			actual, err := pq.Pop()
+			if err != nil {
+				t.Fatalf("Unexpected error popping: %s", err)
+			}
+			if expected.Value != actual.Value {
+				t.Fatalf("Expected: %v Actual: %v", expected.Value, actual.Value)
+			}
+		}
+	}
+	if pq.Len() != reference.Len() {
+		t.Fatalf("Expected: %d Actual: %d", reference.Len(), pq.Len())
+	}
+	for i := 0; i < 1000; i++ {
+		expected, _ := reference.Dequeue()
+		actual, _ := pq.Pop()
+		if expected.Value != actual.Value {
+			t.Fatalf("Expected: %v Actual: %v", expected.Value, actual.Value)
+		}
+	}
+	if err := pq.Close(); err != nil {
+		t.Fatalf("Unexpected error closing: %s", err)
+	}
+	if actual := runs(t, dir); actual != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, actual)
+	}
+	if pq.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, pq.Len())
+	}
+}
+
+func TestPriorityQueue_MergeRuns(t *testing.T) {
+	dir := t.TempDir()
+	pq := external.New(dir, 1)
+	defer pq.Close()
+	reference := &priorityqueue.PriorityQueue{}
+	for i := 0; i < 1000; i++ {
+		priority := float64((i * 7919) % 1000)
+		if err := pq.Push(&priorityqueue.Item{Value: i, Priority: priority}); err != nil {
+			t.Fatalf("Unexpected error pushing: %s", err)
+		}
+		reference.Enqueue(&priorityqueue.Item{Value: i, Priority: priority})
+		// Runs are merged once there are more than 16, so every Item spilled does not hold a file open.
+		if actual := runs(t, dir); actual > 16 {
+			t.Fatalf("Expected at most: %d Actual: %d", 16, actual)
+		}
+	}
+	for reference.Len() > 0 {
+		expected, _ := reference.Dequeue()
+		actual, err := pq.Pop()
+		if err != nil {
+			t.Fatalf("Unexpected error popping: %s", err)
+		}
+		if expected.Value != actual.Value {
+			t.Fatalf("Expected: %v Actual: %v", expected.Value, actual.Value)
+		}
+	}
+	if actual := runs(t, dir); actual != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, actual)
+	}
+}
@@ -0,0 +1,102 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import "errors"
+
+// ErrUnknownClass is returned by Fair.Push for a class that was not added.
+var ErrUnknownClass = errors.New("priorityqueue: unknown class")
+
+// A Fair is a multi-class priority queue that shares Pops between its classes in proportion to their weights, so that a
+// busy class cannot starve the others.  Within a class, Items are popped as from a PriorityQueue.  Between classes, Fair
+// uses weighted fair queueing:  each class has a virtual time that advances by the inverse of its weight on every Pop,
+// and the non-empty class with the earliest virtual time pops next.  A class that becomes non-empty resumes from the
+// current virtual time, so it cannot claim the share it did not use while empty.  The classes themselves are held in a
+// PriorityQueue ordered by virtual time, so Pop is O(log n + log c) for c classes.
+type Fair struct {
+	classes map[string]*fairClass
+	active  *PriorityQueue // The non-empty classes, lowest virtual time first.
+	now     float64        // The virtual time of the class that popped last.
+}
+
+// A fairClass is a class of a Fair.
+type fairClass struct {
+	weight float64
+	pass   float64 // The virtual time of the next Pop.
+	pq     PriorityQueue
+	entry  *Item // The Item holding the class in the active PriorityQueue, or nil if the class is empty.
+}
+
+// NewFair returns an empty Fair with no classes.
+func NewFair() *Fair {
+	return &Fair{classes: make(map[string]*fairClass), active: NewMin()}
+}
+
+// AddClass adds a class with the given weight, or changes the weight of an existing class.  A class of weight 2 pops
+// twice as often as a class of weight 1 while both are non-empty.  It panics if weight is not positive.
+func (f *Fair) AddClass(class string, weight float64) {
+	if !(weight > 0) {
+		panic("priorityqueue: weight must be positive")
+	}
+	if c, ok := f.classes[class]; ok {
+		c.weight = weight
+		return
+	}
+	f.classes[class] = &fairClass{weight: weight}
+}
+
+// Len returns the number of Items in every class of the Fair.
+func (f *Fair) Len() int {
+	n := 0
+	for _, c := range f.classes {
+		n += c.pq.Len()
+	}
+	return n
+}
+
+// Push adds item to class in O(log n + log c).  It returns ErrUnknownClass if class was not added.
+func (f *Fair) Push(class string, item *Item) error {
+	c, ok := f.classes[class]
+	if !ok {
+		return ErrUnknownClass
+	}
+	c.pq.Enqueue(item)
+	if c.entry == nil {
+		if c.pass < f.now {
+			c.pass = f.now
+		}
+		c.entry = &Item{Value: c, Priority: c.pass}
+		f.active.Enqueue(c.entry)
+	}
+	return nil
+}
+
+// Pop removes and returns the highest priority Item of the class whose turn it is, or returns ErrEmpty if every class
+// is empty.
+func (f *Fair) Pop() (*Item, error) {
+	entry := f.active.Peek()
+	if entry == nil {
+		return nil, ErrEmpty
+	}
+	c := entry.Value.(*fairClass)
+	item, _ := c.pq.Dequeue()
+	f.now = c.pass
+	c.pass += 1 / c.weight
+	if c.pq.Len() == 0 {
+		f.active.Remove(entry)
+		c.entry = nil
+	} else {
+		f.active.Update(entry, c, c.pass)
+	}
+	return item, nil
+}
@@ -0,0 +1,78 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestFair(t *testing.T) {
+	f := priorityqueue.NewFair()
+	f.AddClass("interactive", 3.0)
+	f.AddClass("batch", 1.0)
+	for i := 0; i < 100; i++ {
+		f.Push("interactive", &priorityqueue.Item{Value: "interactive", Priority: float64(i)})
+		f.Push("batch", &priorityqueue.Item{Value: "batch", Priority: float64(i)})
+	}
+	if err := f.Push("unknown", &priorityqueue.Item{}); err != priorityqueue.ErrUnknownClass {
+		t.Fatalf("Expected: %s Actual: %v", priorityqueue.ErrUnknownClass, err)
+	}
+	counts := map[interface{}]int{}
+	last := map[interface{}]float64{"interactive": 100.0, "batch": 100.0}
+	for i := 0; i < 40; i++ {
+		item, _ := f.Pop()
+		counts[item.Value]++
+		if item.Priority >= last[item.Value] {
+			t.Fatalf("Out of order: %f popped after %f", item.Priority, last[item.Value])
+		}
+		last[item.Value] = item.Priority
+	}
+	if counts["interactive"] != 30 || counts["batch"] != 10 {
+		t.Fatalf("Expected: %d:%d Actual: %d:%d", 30, 10, counts["interactive"], counts["batch"])
+	}
+	if f.Len() != 160 {
+		t.Fatalf("Expected: %d Actual: %d", 160, f.Len())
+	}
+}
+
+func TestFair_Idle(t *testing.T) {
+	f := priorityqueue.NewFair()
+	f.AddClass("busy", 1.0)
+	f.AddClass("idle", 1.0)
+	for i := 0; i < 100; i++ {
+		f.Push("busy", &priorityqueue.Item{Value: "busy", Priority: 1.0})
+	}
+	for i := 0; i < 50; i++ {
+		f.Pop()
+	}
+	// A class that was idle shares equally from now on, rather than catching up on the Pops it did not use.
+	for i := 0; i < 50; i++ {
+		f.Push("idle", &priorityqueue.Item{Value: "idle", Priority: 1.0})
+	}
+	counts := map[interface{}]int{}
+	for i := 0; i < 20; i++ {
+		item, _ := f.Pop()
+		counts[item.Value]++
+	}
+	if counts["busy"] != 10 || counts["idle"] != 10 {
+		t.Fatalf("Expected: %d:%d Actual: %d:%d", 10, 10, counts["busy"], counts["idle"])
+	}
+	for f.Len() > 0 {
+		f.Pop()
+	}
+	if _, err := f.Pop(); err != priorityqueue.ErrEmpty {
+		t.Fatalf("Expected: %s Actual: %v", priorityqueue.ErrEmpty, err)
+	}
+}
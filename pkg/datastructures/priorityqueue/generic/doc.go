@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package generic implements a PriorityQueue parameterized by its value and priority types.  Unlike the PriorityQueue in
+package priorityqueue, the priority is not limited to float64; any ordered type such as int64 or string may be used, and
+priorities are compared with the built-in comparison operators.
+*/
+
+package generic
@@ -0,0 +1,96 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"cmp"
+	"container/heap"
+)
+
+// An Item is something we manage in a Priority queue.
+type Item[V any, P cmp.Ordered] struct {
+	Value    V // The Value of the item; arbitrary.
+	Priority P // The Priority of the item in the queue.
+	// The index is needed by update and is maintained by the heap.Interface methods.
+	index int // The index of the item in the heap.
+}
+
+// A PriorityQueue holds Items and pops them highest Priority first.  The zero value is an empty PriorityQueue ready to
+// use.
+type PriorityQueue[V any, P cmp.Ordered] struct {
+	items items[V, P]
+}
+
+// New returns an empty PriorityQueue.
+func New[V any, P cmp.Ordered]() *PriorityQueue[V, P] {
+	return &PriorityQueue[V, P]{}
+}
+
+// Len returns the number of Items in the PriorityQueue.
+func (pq *PriorityQueue[V, P]) Len() int {
+	return pq.items.Len()
+}
+
+// Push adds item to the PriorityQueue in O(log n).
+func (pq *PriorityQueue[V, P]) Push(item *Item[V, P]) {
+	heap.Push(&pq.items, item)
+}
+
+// Peek returns the highest Priority Item without removing it in O(1), or nil if the PriorityQueue is empty.
+func (pq *PriorityQueue[V, P]) Peek() *Item[V, P] {
+	if pq.items.Len() == 0 {
+		return nil
+	}
+	return pq.items[0]
+}
+
+// Pop removes and returns the highest Priority Item in O(log n), or nil if the PriorityQueue is empty.
+func (pq *PriorityQueue[V, P]) Pop() *Item[V, P] {
+	if pq.items.Len() == 0 {
+		return nil
+	}
+	return heap.Pop(&pq.items).(*Item[V, P])
+}
+
+// items implements heap.Interface and holds Items.
+type items[V any, P cmp.Ordered] []*Item[V, P]
+
+func (pq items[V, P]) Len() int { return len(pq) }
+
+func (pq items[V, P]) Less(i, j int) bool {
+	// We want Pop to give us the highest, not lowest, Priority so we use greater than here.
+	return pq[i].Priority > pq[j].Priority
+}
+
+func (pq items[V, P]) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].index = i
+	pq[j].index = j
+}
+
+func (pq *items[V, P]) Push(x any) {
+	n := len(*pq)
+	item := x.(*Item[V, P])
+	item.index = n
+	*pq = append(*pq, item)
+}
+
+func (pq *items[V, P]) Pop() any {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil  // avoid memory leak
+	item.index = -1 // for safety
+	*pq = old[0 : n-1]
+	return item
+}
@@ -0,0 +1,66 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic_test
+
+import (
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue/generic"
+)
+
+func TestPriorityQueue_Int64Priority(t *testing.T) {
+	// These timestamps differ by less than the precision of a float64 at this magnitude.
+	raw := map[string]int64{
+		"first":  1<<62 + 1,
+		"second": 1<<62 + 2,
+		"third":  1<<62 + 3,
+	}
+	expectedPopOrder := []string{"third", "second", "first"}
+	pq := generic.New[string, int64]()
+	for value, priority := range raw {
+		pq.Push(&generic.Item[string, int64]{Value: value, Priority: priority})
+	}
+	for _, expected := range expectedPopOrder {
+		actual := pq.Pop().Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	if pq.Pop() != nil {
+		t.Fatalf("Expected an empty PriorityQueue")
+	}
+}
+
+func TestPriorityQueue_StringPriority(t *testing.T) {
+	raw := map[int]string{1: "v1.0.0", 2: "v1.2.0", 3: "v1.10.0", 4: "v0.9.9"}
+	expectedPopOrder := []int{2, 3, 1, 4}
+	var pq generic.PriorityQueue[int, string]
+	for value, priority := range raw {
+		pq.Push(&generic.Item[int, string]{Value: value, Priority: priority})
+	}
+	if pq.Len() != len(raw) {
+		t.Fatalf("Expected: %d Actual: %d", len(raw), pq.Len())
+	}
+	for _, expected := range expectedPopOrder {
+		if actual := pq.Peek().Value; expected != actual {
+			t.Fatalf("Expected: %d Actual: %d", expected, actual)
+		}
+		actual := pq.Pop().Value
+		if expected != actual {
+			t.Fatalf("Expected: %d Actual: %d", expected, actual)
+		}
+	}
+	if pq.Peek() != nil {
+		t.Fatalf("Expected an empty PriorityQueue")
+	}
+}
@@ -0,0 +1,45 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrInvalidPriority is returned by NewItem for a Priority that cannot be ordered, i.e. NaN.
+var ErrInvalidPriority = errors.New("priorityqueue: invalid priority")
+
+// An ItemOption configures an optional field of an Item constructed by NewItem.
+type ItemOption func(*Item)
+
+// WithExpiry sets the Expiry of an Item to t.
+func WithExpiry(t time.Time) ItemOption {
+	return func(item *Item) {
+		item.Expiry = t
+	}
+}
+
+// NewItem returns an Item holding value with priority, configured by opts.  It returns ErrInvalidPriority if priority
+// is NaN, since NaN compares false against every Priority and would silently corrupt the heap order.
+func NewItem(value interface{}, priority float64, opts ...ItemOption) (*Item, error) {
+	if math.IsNaN(priority) {
+		return nil, ErrInvalidPriority
+	}
+	item := &Item{Value: value, Priority: priority}
+	for _, opt := range opts {
+		opt(item)
+	}
+	return item, nil
+}
@@ -0,0 +1,72 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestNewItem(t *testing.T) {
+	item, err := priorityqueue.NewItem("apple", 10.0)
+	if err != nil {
+		t.Fatalf("Unexpected error creating Item: %s", err)
+	}
+	if item.Value != "apple" || item.Priority != 10.0 || !item.Expiry.IsZero() {
+		t.Fatalf("Unexpected Item: %v", item)
+	}
+	pq := &priorityqueue.PriorityQueue{}
+	pq.Enqueue(item)
+	if actual := pq.Peek(); item != actual {
+		t.Fatalf("Expected: %v Actual: %v", item, actual)
+	}
+}
+
+func TestNewItem_NaN(t *testing.T) {
+	item, err := priorityqueue.NewItem("apple", math.NaN())
+	if err != priorityqueue.ErrInvalidPriority {
+		t.Fatalf("Expected: %s Actual: %v", priorityqueue.ErrInvalidPriority, err)
+	}
+	if item != nil {
+		t.Fatalf("Expected no Item, Actual: %v", item)
+	}
+}
+
+func TestNewItem_WithExpiry(t *testing.T) {
+	expiry := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	item, err := priorityqueue.NewItem("apple", 10.0, priorityqueue.WithExpiry(expiry))
+	if err != nil {
+		t.Fatalf("Unexpected error creating Item: %s", err)
+	}
+	if !expiry.Equal(item.Expiry) {
+		t.Fatalf("Expected: %s Actual: %s", expiry, item.Expiry)
+	}
+
+	pq := &priorityqueue.PriorityQueue{}
+	pq.Enqueue(item)
+	jsonBytes, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	unmarshaled := &priorityqueue.PriorityQueue{}
+	if err := json.Unmarshal(jsonBytes, unmarshaled); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	if actual := unmarshaled.Peek().Expiry; !expiry.Equal(actual) {
+		t.Fatalf("Expected: %s Actual: %s", expiry, actual)
+	}
+}
@@ -0,0 +1,78 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// Items returns a copy of the underlying heap array, i.e. every Item in no particular order.  Modifying the returned
+// slice does not affect the PriorityQueue, but the Items themselves are shared and their Priorities must not be changed.
+func (pq *PriorityQueue) Items() []*Item {
+	items := make([]*Item, len(pq.items))
+	copy(items, pq.items)
+	return items
+}
+
+// SortedIter returns an iterator over the Items in Pop order that does not modify the PriorityQueue.  Items are
+// produced lazily by walking the heap, so yielding the first k Items is O(k log k), and breaking out of the loop early
+// is cheap.  The PriorityQueue must not be modified during iteration.
+func (pq *PriorityQueue) SortedIter() iter.Seq[*Item] {
+	return func(yield func(*Item) bool) {
+		if len(pq.items) == 0 {
+			return
+		}
+		f := &frontier{pq: pq, indexes: []int{0}}
+		for f.Len() > 0 {
+			i := heap.Pop(f).(int)
+			if !yield(pq.items[i]) {
+				return
+			}
+			for _, child := range []int{2*i + 1, 2*i + 2} {
+				if child < len(pq.items) {
+					heap.Push(f, child)
+				}
+			}
+		}
+	}
+}
+
+// frontier implements heap.Interface over indexes into a PriorityQueue whose parents have already been visited.  Since
+// each Item is popped no later than its children, the next Item in Pop order is always on the frontier.
+type frontier struct {
+	pq      *PriorityQueue
+	indexes []int
+}
+
+func (f *frontier) Len() int { return len(f.indexes) }
+
+func (f *frontier) Less(i, j int) bool {
+	return f.pq.before(f.pq.items[f.indexes[i]], f.pq.items[f.indexes[j]])
+}
+
+func (f *frontier) Swap(i, j int) {
+	f.indexes[i], f.indexes[j] = f.indexes[j], f.indexes[i]
+}
+
+func (f *frontier) Push(x interface{}) {
+	f.indexes = append(f.indexes, x.(int))
+}
+
+func (f *frontier) Pop() interface{} {
+	old := f.indexes
+	n := len(old)
+	index := old[n-1]
+	f.indexes = old[0 : n-1]
+	return index
+}
@@ -0,0 +1,74 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"container/heap"
+	"strconv"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestPriorityQueue_Items(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	items := pq.Items()
+	if len(items) != len(smallRaw) {
+		t.Fatalf("Expected: %d Actual: %d", len(smallRaw), len(items))
+	}
+	for _, item := range items {
+		if expected := smallRaw[item.Value.(string)]; expected != item.Priority {
+			t.Fatalf("Expected: %f Actual: %f", expected, item.Priority)
+		}
+	}
+	items[0] = nil
+	if pq.Peek() == nil {
+		t.Fatalf("Expected the PriorityQueue to be unmodified")
+	}
+}
+
+func TestPriorityQueue_SortedIter(t *testing.T) {
+	raw := map[string]float64{}
+	for i := 0; i < 1000; i++ {
+		raw[strconv.Itoa(i)] = float64(i % 100)
+	}
+	pq := generatePriorityQueue(raw)
+	var iterated []*priorityqueue.Item
+	for item := range pq.SortedIter() {
+		iterated = append(iterated, item)
+	}
+	if pq.Len() != len(raw) {
+		t.Fatalf("Expected: %d Actual: %d", len(raw), pq.Len())
+	}
+	for i, expected := range iterated {
+		actual := heap.Pop(pq).(*priorityqueue.Item)
+		if expected != actual {
+			t.Fatalf("%d: Expected: %v Actual: %v", i, expected.Value, actual.Value)
+		}
+	}
+
+	pq = generatePriorityQueue(smallRaw)
+	var visited []string
+	for item := range pq.SortedIter() {
+		visited = append(visited, item.Value.(string))
+		if len(visited) == 2 {
+			break
+		}
+	}
+	if len(visited) != 2 || visited[0] != "carrot" || visited[1] != "apple" {
+		t.Fatalf("Expected: %v Actual: %v", []string{"carrot", "apple"}, visited)
+	}
+	for range (&priorityqueue.PriorityQueue{}).SortedIter() {
+		t.Fatalf("Expected no Items")
+	}
+}
@@ -0,0 +1,312 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+type pastry struct {
+	Name     string
+	Calories int
+}
+
+func decodePastry(raw json.RawMessage) (interface{}, error) {
+	var p pastry
+	err := json.Unmarshal(raw, &p)
+	return p, err
+}
+
+func TestPriorityQueue_UnmarshalJSON(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	jsonBytes, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	unmarshaled := &priorityqueue.PriorityQueue{}
+	if err := json.Unmarshal(jsonBytes, unmarshaled); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	expectedPopOrder := []string{"carrot", "apple", "banana", "danish"}
+	if unmarshaled.Len() != len(expectedPopOrder) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedPopOrder), unmarshaled.Len())
+	}
+	for _, expected := range expectedPopOrder {
+		item := heap.Pop(unmarshaled).(*priorityqueue.Item)
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+		if smallRaw[expected] != item.Priority {
+			t.Fatalf("Expected: %f Actual: %f", smallRaw[expected], item.Priority)
+		}
+	}
+}
+
+func TestPriorityQueue_UnmarshalJSONInvalid(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	invalid := []string{`{"Value":"apple"}`, `[{"Value":"apple","Priority":"high"}]`, `[`}
+	for _, data := range invalid {
+		if err := json.Unmarshal([]byte(data), pq); err == nil {
+			t.Fatalf("Expected an error unmarshaling: %s", data)
+		}
+		if pq.Len() != len(smallRaw) {
+			t.Fatalf("Expected: %d Actual: %d", len(smallRaw), pq.Len())
+		}
+	}
+	pq.SetValueDecoder(decodePastry)
+	if err := json.Unmarshal([]byte(`[{"Value":"apple","Priority":1}]`), pq); err == nil {
+		t.Fatalf("Expected an error decoding a Value")
+	}
+	if actual := heap.Pop(pq).(*priorityqueue.Item).Value; actual != "carrot" {
+		t.Fatalf("Expected: %s Actual: %s", "carrot", actual)
+	}
+	if err := json.Unmarshal([]byte(`[]`), pq); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	if pq.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, pq.Len())
+	}
+}
+
+func TestPriorityQueue_UnmarshalJSONReversed(t *testing.T) {
+	data := `[{"Value":"carrot","Priority":11},{"Value":"apple","Priority":10},{"Value":"banana","Priority":5}]`
+	pq := priorityqueue.NewMin()
+	if err := json.Unmarshal([]byte(data), pq); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	expectedPopOrder := []string{"banana", "apple", "carrot"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_EncodeJSON(t *testing.T) {
+	raw := map[string]float64{}
+	for i := 0; i < 1000; i++ {
+		raw[strconv.Itoa(i)] = float64(i)
+	}
+	pq := generatePriorityQueue(raw)
+	var buffer bytes.Buffer
+	if err := pq.EncodeJSON(&buffer); err != nil {
+		t.Fatalf("Unexpected error encoding JSON: %s", err)
+	}
+	marshaled, err := pq.MarshalJSON()
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	if !bytes.Equal(marshaled, buffer.Bytes()) {
+		t.Fatalf("Expected: %s Actual: %s", marshaled, buffer.Bytes())
+	}
+	var items []priorityqueue.Item
+	if err := json.Unmarshal(buffer.Bytes(), &items); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	if len(items) != len(raw) {
+		t.Fatalf("Expected: %d Actual: %d", len(raw), len(items))
+	}
+	for i, item := range items {
+		expected := strconv.Itoa(len(raw) - 1 - i)
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+		if raw[expected] != item.Priority {
+			t.Fatalf("Expected: %f Actual: %f", raw[expected], item.Priority)
+		}
+	}
+	if pq.Len() != len(raw) {
+		t.Fatalf("Expected: %d Actual: %d", len(raw), pq.Len())
+	}
+	// The output is buffered rather than written an Item at a time.
+	var counter writeCounter
+	if err := pq.EncodeJSON(&counter); err != nil {
+		t.Fatalf("Unexpected error encoding JSON: %s", err)
+	}
+	if maxWrites := buffer.Len()/4096 + 1; counter.writes > maxWrites {
+		t.Fatalf("Expected at most %d writes Actual: %d", maxWrites, counter.writes)
+	}
+}
+
+// writeCounter is an io.Writer that counts the calls to Write.
+type writeCounter struct {
+	writes int
+}
+
+func (w *writeCounter) Write(p []byte) (int, error) {
+	w.writes++
+	return len(p), nil
+}
+
+func TestPriorityQueue_MarshalJSONReadOnly(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	indexes := map[string]int{}
+	for value := range smallRaw {
+		indexes[value] = pq.IndexOf(value)
+	}
+	expected, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	// Marshaling only reads the PriorityQueue, so concurrent readers are safe.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			actual, err := json.Marshal(pq)
+			if err != nil {
+				t.Errorf("Unexpected error marshaling JSON: %s", err)
+			}
+			if !bytes.Equal(expected, actual) {
+				t.Errorf("Expected: %s Actual: %s", expected, actual)
+			}
+		}()
+	}
+	wg.Wait()
+	for value, expectedIndex := range indexes {
+		if actual := pq.IndexOf(value); expectedIndex != actual {
+			t.Fatalf("%s: Expected: %d Actual: %d", value, expectedIndex, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_MarshalJSONDeterministic(t *testing.T) {
+	items := [][]*priorityqueue.Item{{
+		{Value: "apple", Priority: 1.0},
+		{Value: "banana", Priority: 2.0},
+		{Value: "carrot", Priority: 1.0},
+		{Value: "danish", Priority: 2.0},
+		{Value: "eclair", Priority: 1.0},
+	}, {
+		{Value: "eclair", Priority: 1.0},
+		{Value: "danish", Priority: 2.0},
+		{Value: "carrot", Priority: 1.0},
+		{Value: "banana", Priority: 2.0},
+		{Value: "apple", Priority: 1.0},
+	}, {
+		{Value: "carrot", Priority: 1.0},
+		{Value: "apple", Priority: 1.0},
+		{Value: "danish", Priority: 2.0},
+		{Value: "eclair", Priority: 1.0},
+		{Value: "banana", Priority: 2.0},
+	}}
+	var expected []byte
+	for i, pushOrder := range items {
+		pq := &priorityqueue.PriorityQueue{}
+		for _, item := range pushOrder {
+			heap.Push(pq, item)
+		}
+		actual, err := json.Marshal(pq)
+		if err != nil {
+			t.Fatalf("Unexpected error marshaling JSON: %s", err)
+		}
+		if i == 0 {
+			expected = actual
+			continue
+		}
+		if !bytes.Equal(expected, actual) {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	expectedJSON := `[{"Value":"banana","Priority":2},{"Value":"danish","Priority":2},` +
+		`{"Value":"apple","Priority":1},{"Value":"carrot","Priority":1},{"Value":"eclair","Priority":1}]`
+	if expectedJSON != string(expected) {
+		t.Fatalf("Expected: %s Actual: %s", expectedJSON, expected)
+	}
+}
+
+func TestPriorityQueue_UnmarshalJSONTieOrder(t *testing.T) {
+	pq := &priorityqueue.PriorityQueue{}
+	for _, value := range []string{"zeta", "alpha", "mid"} {
+		heap.Push(pq, &priorityqueue.Item{Value: value, Priority: 1.0})
+	}
+	jsonBytes, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	unmarshaled := &priorityqueue.PriorityQueue{}
+	if err := json.Unmarshal(jsonBytes, unmarshaled); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	// Ties are encoded in the byte order of their encodings, so the round trip loses the push order.
+	for _, expected := range []string{"alpha", "mid", "zeta"} {
+		if actual := heap.Pop(unmarshaled).(*priorityqueue.Item).Value; actual != expected {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_MarshalJSONExpiry(t *testing.T) {
+	expiry := time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC)
+	pq := &priorityqueue.PriorityQueue{}
+	heap.Push(pq, &priorityqueue.Item{Value: "apple", Priority: 2.0, Expiry: expiry})
+	heap.Push(pq, &priorityqueue.Item{Value: "banana", Priority: 1.0})
+	jsonBytes, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	// A zero Expiry is omitted.
+	expected := `[{"Value":"apple","Priority":2,"Expiry":"2030-01-02T03:04:05Z"},{"Value":"banana","Priority":1}]`
+	if string(jsonBytes) != expected {
+		t.Fatalf("Expected: %s Actual: %s", expected, jsonBytes)
+	}
+	unmarshaled := &priorityqueue.PriorityQueue{}
+	if err := json.Unmarshal(jsonBytes, unmarshaled); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	if actual := heap.Pop(unmarshaled).(*priorityqueue.Item).Expiry; !actual.Equal(expiry) {
+		t.Fatalf("Expected: %s Actual: %s", expiry, actual)
+	}
+	if actual := heap.Pop(unmarshaled).(*priorityqueue.Item).Expiry; !actual.IsZero() {
+		t.Fatalf("Expected: %s Actual: %s", time.Time{}, actual)
+	}
+}
+
+func TestPriorityQueue_SetValueDecoder(t *testing.T) {
+	pastries := []*priorityqueue.Item{
+		{Value: pastry{Name: "croissant", Calories: 231}, Priority: 2.0},
+		{Value: pastry{Name: "eclair", Calories: 262}, Priority: 3.0},
+		{Value: pastry{Name: "danish", Calories: 349}, Priority: 1.0},
+	}
+	pq := &priorityqueue.PriorityQueue{}
+	pq.PushAll(pastries...)
+	jsonBytes, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	unmarshaled := &priorityqueue.PriorityQueue{}
+	unmarshaled.SetValueDecoder(decodePastry)
+	if err := json.Unmarshal(jsonBytes, unmarshaled); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	expectedPopOrder := []*priorityqueue.Item{pastries[1], pastries[0], pastries[2]}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(unmarshaled).(*priorityqueue.Item)
+		if expected.Value != actual.Value {
+			t.Fatalf("Expected: %v Actual: %v", expected.Value, actual.Value)
+		}
+		if expected.Priority != actual.Priority {
+			t.Fatalf("Expected: %f Actual: %f", expected.Priority, actual.Priority)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package keyed implements a PriorityQueue that holds each value at most once and indexes its Items by value.  Values must
+be comparable, which the compiler enforces, so keyed.PriorityQueue[[]int, float64] does not compile.  That rules out a
+runtime panic from comparing values only for a type argument that neither is nor contains an interface type.  An
+interface type is comparable, but its dynamic values need not be:  a keyed.PriorityQueue[any, float64] compiles, and
+pushing or looking up a []int value panics, just as using it as a map key does.
+*/
+
+package keyed
@@ -0,0 +1,165 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyed
+
+import (
+	"cmp"
+	"container/heap"
+)
+
+// An entry is something we manage in a Priority queue.
+type entry[V comparable, P cmp.Ordered] struct {
+	value    V // The value of the item; the key of the index.
+	priority P // The priority of the item in the queue.
+	index    int
+}
+
+// A PriorityQueue is an indexed priority queue:  it holds unique values, keyed by themselves, and pops them highest
+// priority first.  The zero value is an empty PriorityQueue ready to use.
+type PriorityQueue[V comparable, P cmp.Ordered] struct {
+	items items[V, P]
+	index map[V]*entry[V, P]
+}
+
+// New returns an empty PriorityQueue that pops the highest priority first.
+func New[V comparable, P cmp.Ordered]() *PriorityQueue[V, P] {
+	return &PriorityQueue[V, P]{}
+}
+
+// NewMin returns an empty PriorityQueue that pops the lowest priority first, as needed by algorithms such as Dijkstra's.
+func NewMin[V comparable, P cmp.Ordered]() *PriorityQueue[V, P] {
+	return &PriorityQueue[V, P]{items: items[V, P]{min: true}}
+}
+
+// Len returns the number of values in the PriorityQueue.
+func (pq *PriorityQueue[V, P]) Len() int {
+	return pq.items.Len()
+}
+
+// Contains reports whether value is in the PriorityQueue in O(1).
+func (pq *PriorityQueue[V, P]) Contains(value V) bool {
+	_, ok := pq.index[value]
+	return ok
+}
+
+// Get returns the priority of value in O(1).  The second return value is false if value is not in the PriorityQueue.
+func (pq *PriorityQueue[V, P]) Get(value V) (P, bool) {
+	existing, ok := pq.index[value]
+	if !ok {
+		var priority P
+		return priority, false
+	}
+	return existing.priority, true
+}
+
+// UpdateKey changes the priority of value to priority in O(log n).  It returns false if value is not in the
+// PriorityQueue.
+func (pq *PriorityQueue[V, P]) UpdateKey(value V, priority P) bool {
+	existing, ok := pq.index[value]
+	if !ok {
+		return false
+	}
+	existing.priority = priority
+	heap.Fix(&pq.items, existing.index)
+	return true
+}
+
+// RemoveKey removes value from the PriorityQueue in O(log n).  It returns false if value is not in the PriorityQueue.
+func (pq *PriorityQueue[V, P]) RemoveKey(value V) bool {
+	existing, ok := pq.index[value]
+	if !ok {
+		return false
+	}
+	heap.Remove(&pq.items, existing.index)
+	delete(pq.index, value)
+	return true
+}
+
+// Upsert adds value with priority if it is not in the PriorityQueue, and otherwise changes its priority to priority.
+// Either way it is O(log n).  It returns true if value was added.
+func (pq *PriorityQueue[V, P]) Upsert(value V, priority P) bool {
+	if pq.UpdateKey(value, priority) {
+		return false
+	}
+	if pq.index == nil {
+		pq.index = make(map[V]*entry[V, P])
+	}
+	added := &entry[V, P]{value: value, priority: priority}
+	pq.index[value] = added
+	heap.Push(&pq.items, added)
+	return true
+}
+
+// Peek returns the highest priority value and its priority without removing it.  The last return value is false if
+// the PriorityQueue is empty.
+func (pq *PriorityQueue[V, P]) Peek() (V, P, bool) {
+	if pq.items.Len() == 0 {
+		var value V
+		var priority P
+		return value, priority, false
+	}
+	head := pq.items.entries[0]
+	return head.value, head.priority, true
+}
+
+// Pop removes and returns the highest priority value and its priority in O(log n).  The last return value is false if
+// the PriorityQueue is empty.
+func (pq *PriorityQueue[V, P]) Pop() (V, P, bool) {
+	if pq.items.Len() == 0 {
+		var value V
+		var priority P
+		return value, priority, false
+	}
+	popped := heap.Pop(&pq.items).(*entry[V, P])
+	delete(pq.index, popped.value)
+	return popped.value, popped.priority, true
+}
+
+// items implements heap.Interface and holds entries.
+type items[V comparable, P cmp.Ordered] struct {
+	entries []*entry[V, P]
+	min     bool // Whether Pop gives the lowest, rather than highest, priority.
+}
+
+func (pq *items[V, P]) Len() int { return len(pq.entries) }
+
+func (pq *items[V, P]) Less(i, j int) bool {
+	if pq.min {
+		return pq.entries[i].priority < pq.entries[j].priority
+	}
+	// We want Pop to give us the highest, not lowest, priority so we use greater than here.
+	return pq.entries[i].priority > pq.entries[j].priority
+}
+
+func (pq *items[V, P]) Swap(i, j int) {
+	pq.entries[i], pq.entries[j] = pq.entries[j], pq.entries[i]
+	pq.entries[i].index = i
+	pq.entries[j].index = j
+}
+
+func (pq *items[V, P]) Push(x any) {
+	n := len(pq.entries)
+	item := x.(*entry[V, P])
+	item.index = n
+	pq.entries = append(pq.entries, item)
+}
+
+func (pq *items[V, P]) Pop() any {
+	old := pq.entries
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil  // avoid memory leak
+	item.index = -1 // for safety
+	pq.entries = old[0 : n-1]
+	return item
+}
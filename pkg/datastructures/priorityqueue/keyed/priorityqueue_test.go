@@ -0,0 +1,115 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyed_test
+
+import (
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue/keyed"
+)
+
+type point struct {
+	x, y int
+}
+
+func TestPriorityQueue_String(t *testing.T) {
+	pq := keyed.New[string, float64]()
+	raw := map[string]float64{"apple": 10.0, "banana": 5.0, "carrot": 11.0, "danish": 0.0}
+	for value, priority := range raw {
+		if !pq.Upsert(value, priority) {
+			t.Fatalf("Expected an insert for: %s", value)
+		}
+	}
+	if pq.Upsert("danish", 20.0) {
+		t.Fatalf("Expected an update for: %s", "danish")
+	}
+	if pq.Len() != len(raw) {
+		t.Fatalf("Expected: %d Actual: %d", len(raw), pq.Len())
+	}
+	if !pq.Contains("apple") || pq.Contains("eclair") {
+		t.Fatalf("Unexpected membership")
+	}
+	expectedPopOrder := []string{"danish", "carrot", "apple", "banana"}
+	for _, expected := range expectedPopOrder {
+		actual, _, ok := pq.Pop()
+		if !ok || expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	if pq.Contains("apple") {
+		t.Fatalf("Expected apple to be popped")
+	}
+	if _, _, ok := pq.Pop(); ok {
+		t.Fatalf("Expected an empty PriorityQueue")
+	}
+}
+
+func TestPriorityQueue_Struct(t *testing.T) {
+	var pq keyed.PriorityQueue[point, int]
+	pq.Upsert(point{1, 2}, 3)
+	pq.Upsert(point{2, 1}, 1)
+	pq.Upsert(point{1, 2}, 0)
+	pq.Upsert(point{0, 0}, 2)
+	if pq.Len() != 3 {
+		t.Fatalf("Expected: %d Actual: %d", 3, pq.Len())
+	}
+	if !pq.Contains(point{1, 2}) || pq.Contains(point{2, 2}) {
+		t.Fatalf("Unexpected membership")
+	}
+	if value, priority, _ := pq.Peek(); value != (point{0, 0}) || priority != 2 {
+		t.Fatalf("Expected: %v Actual: %v", point{0, 0}, value)
+	}
+	expectedPopOrder := []point{{0, 0}, {2, 1}, {1, 2}}
+	for _, expected := range expectedPopOrder {
+		actual, _, _ := pq.Pop()
+		if expected != actual {
+			t.Fatalf("Expected: %v Actual: %v", expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_Indexed(t *testing.T) {
+	pq := keyed.NewMin[string, int]()
+	distances := map[string]int{"a": 0, "b": 7, "c": 9, "d": 14, "e": 20}
+	for node, distance := range distances {
+		pq.Upsert(node, distance)
+	}
+	if distance, ok := pq.Get("c"); !ok || distance != 9 {
+		t.Fatalf("Expected: %d Actual: %d", 9, distance)
+	}
+	if _, ok := pq.Get("z"); ok {
+		t.Fatalf("Expected no priority for: %s", "z")
+	}
+	if !pq.UpdateKey("d", 2) || !pq.UpdateKey("b", 11) {
+		t.Fatalf("Expected updates")
+	}
+	if pq.UpdateKey("z", 1) {
+		t.Fatalf("Expected no update for: %s", "z")
+	}
+	if !pq.RemoveKey("c") {
+		t.Fatalf("Expected a removal for: %s", "c")
+	}
+	if pq.RemoveKey("c") || pq.Contains("c") {
+		t.Fatalf("Expected c to be removed")
+	}
+	expectedPopOrder := []string{"a", "d", "b", "e"}
+	for _, expected := range expectedPopOrder {
+		actual, _, _ := pq.Pop()
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	if pq.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, pq.Len())
+	}
+}
@@ -0,0 +1,160 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import (
+	"container/heap"
+)
+
+// Merge moves every Item of other into the PriorityQueue, leaving other empty, and re-establishes the heap invariant once
+// in O(n + m) rather than popping and pushing each Item in O(m log(n + m)).  Items of equal priority keep their relative
+// order, with those of other after those already in the PriorityQueue.
+func (pq *PriorityQueue) Merge(other *PriorityQueue) {
+	if other == pq {
+		return
+	}
+	for _, item := range other.items {
+		item.sequence += pq.sequence
+		item.index = len(pq.items)
+		pq.items = append(pq.items, item)
+	}
+	pq.sequence += other.sequence
+	for i := range other.items {
+		other.items[i] = nil
+	}
+	other.items = other.items[:0]
+	heap.Init(pq)
+}
+
+// Union returns a new PriorityQueue, with the ordering of the PriorityQueue, holding copies of the Items of both the
+// PriorityQueue and other.  Neither is modified.  Union is O(n + m).
+func (pq *PriorityQueue) Union(other *PriorityQueue) *PriorityQueue {
+	union := &PriorityQueue{
+		items:       make([]*Item, 0, len(pq.items)+len(other.items)),
+		less:        pq.less,
+		stable:      pq.stable,
+		reversed:    pq.reversed,
+		sequence:    pq.sequence + other.sequence,
+		decodeValue: pq.decodeValue,
+	}
+	for _, item := range pq.items {
+		itemCopy := *item
+		itemCopy.index = len(union.items)
+		union.items = append(union.items, &itemCopy)
+	}
+	for _, item := range other.items {
+		itemCopy := *item
+		itemCopy.sequence += pq.sequence
+		itemCopy.index = len(union.items)
+		union.items = append(union.items, &itemCopy)
+	}
+	heap.Init(union)
+	return union
+}
+
+// An Iterator returns successive Items of a stream, and false once the stream is exhausted.
+type Iterator func() (*Item, bool)
+
+// Merge returns an Iterator that lazily performs a k-way merge of iterators, each of which must yield its Items in Pop
+// order of the default ordering, i.e. by non-increasing Priority.  The merged stream is also in that order, and Items
+// of equal Priority are yielded in the order of the iterators that yield them.  Each call is O(log k) for k iterators.
+func Merge(iterators ...Iterator) Iterator {
+	heads := NewWithComparator(func(a, b *Item) bool {
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return a.Value.(mergeHead).source < b.Value.(mergeHead).source
+	})
+	advance := func(source int) {
+		if item, ok := iterators[source](); ok {
+			heads.Enqueue(&Item{Value: mergeHead{item: item, source: source}, Priority: item.Priority})
+		}
+	}
+	for source := range iterators {
+		advance(source)
+	}
+	return func() (*Item, bool) {
+		head, err := heads.Dequeue()
+		if err != nil {
+			return nil, false
+		}
+		next := head.Value.(mergeHead)
+		advance(next.source)
+		return next.item, true
+	}
+}
+
+// mergeHead is the Value of the Items merged by Merge, pairing the head of an iterator with the index of the iterator.
+type mergeHead struct {
+	item   *Item
+	source int
+}
+
+// MergeSorted returns an Iterator that lazily performs a k-way merge of queues, returning their Items in global Pop
+// order.  Each call pops one Item from whichever queue holds the next Item, so the queues are drained as the merge
+// progresses; the second return value is false once all of them are empty.  Items are ordered using the ordering of
+// the first queue, even if it is empty, which all queues are expected to share.  Each pull is O(log n + log k) for k queues.
+func MergeSorted(queues ...*PriorityQueue) Iterator {
+	heads := &mergeHeads{}
+	if len(queues) > 0 {
+		heads.order = queues[0]
+	}
+	for _, queue := range queues {
+		if queue.Len() > 0 {
+			heads.queues = append(heads.queues, queue)
+		}
+	}
+	heap.Init(heads)
+	return func() (*Item, bool) {
+		if heads.Len() == 0 {
+			return nil, false
+		}
+		queue := heads.queues[0]
+		item := heap.Pop(queue).(*Item)
+		if queue.Len() > 0 {
+			heap.Fix(heads, 0)
+		} else {
+			heap.Pop(heads)
+		}
+		return item, true
+	}
+}
+
+// mergeHeads implements heap.Interface over non-empty PriorityQueues, ordered by their highest priority Items.
+type mergeHeads struct {
+	queues []*PriorityQueue
+	order  *PriorityQueue // The first queue passed to MergeSorted, whose ordering compares the heads, even once empty.
+}
+
+func (h *mergeHeads) Len() int { return len(h.queues) }
+
+func (h *mergeHeads) Less(i, j int) bool {
+	return h.order.before(h.queues[i].items[0], h.queues[j].items[0])
+}
+
+func (h *mergeHeads) Swap(i, j int) {
+	h.queues[i], h.queues[j] = h.queues[j], h.queues[i]
+}
+
+func (h *mergeHeads) Push(x interface{}) {
+	h.queues = append(h.queues, x.(*PriorityQueue))
+}
+
+func (h *mergeHeads) Pop() interface{} {
+	old := h.queues
+	n := len(old)
+	queue := old[n-1]
+	old[n-1] = nil // avoid memory leak
+	h.queues = old[0 : n-1]
+	return queue
+}
@@ -0,0 +1,158 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"container/heap"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestPriorityQueue_Merge(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	other := generatePriorityQueue(map[string]float64{"eclair": 7.0, "fig": 12.0, "grape": -1.0})
+	heap.Push(pq, &priorityqueue.Item{Value: "honeydew", Priority: 5.0})
+	heap.Push(other, &priorityqueue.Item{Value: "icecream", Priority: 5.0})
+	pq.Merge(other)
+	if other.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, other.Len())
+	}
+	expectedPopOrder := []string{"fig", "carrot", "apple", "eclair", "banana", "honeydew", "icecream", "danish", "grape"}
+	if pq.Len() != len(expectedPopOrder) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedPopOrder), pq.Len())
+	}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_Union(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	other := generatePriorityQueue(map[string]float64{"eclair": 7.0, "fig": 12.0, "grape": -1.0})
+	union := pq.Union(other)
+	if pq.Len() != len(smallRaw) || other.Len() != 3 {
+		t.Fatalf("Expected the operands to be unmodified")
+	}
+	union.UpdateByValue("danish", 100.0)
+	if actual, _ := pq.PeekPriority(); actual != 11.0 {
+		t.Fatalf("Expected: %f Actual: %f", 11.0, actual)
+	}
+	expectedPopOrder := []string{"danish", "fig", "carrot", "apple", "eclair", "banana", "grape"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(union).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	if pq.IndexOf("danish") < 0 {
+		t.Fatalf("Expected danish to remain in the operand")
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	queues := []*priorityqueue.PriorityQueue{
+		generatePriorityQueue(smallRaw),
+		generatePriorityQueue(map[string]float64{"eclair": 7.0, "fig": 12.0, "grape": -1.0}),
+		generatePriorityQueue(map[string]float64{}),
+		generatePriorityQueue(map[string]float64{"honeydew": 5.5, "icecream": 10.5}),
+	}
+	next := priorityqueue.MergeSorted(queues...)
+	expectedPopOrder := []string{"fig", "carrot", "icecream", "apple", "eclair", "honeydew", "banana", "danish", "grape"}
+	for _, expected := range expectedPopOrder {
+		item, ok := next()
+		if !ok {
+			t.Fatalf("Expected: %s Actual: no Item", expected)
+		}
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+	if item, ok := next(); ok {
+		t.Fatalf("Expected no Item, Actual: %v", item)
+	}
+	for _, queue := range queues {
+		if queue.Len() != 0 {
+			t.Fatalf("Expected: %d Actual: %d", 0, queue.Len())
+		}
+	}
+	if _, ok := priorityqueue.MergeSorted()(); ok {
+		t.Fatalf("Expected no Item")
+	}
+}
+
+// sliceIterator returns an Iterator over items.
+func sliceIterator(items ...*priorityqueue.Item) priorityqueue.Iterator {
+	return func() (*priorityqueue.Item, bool) {
+		if len(items) == 0 {
+			return nil, false
+		}
+		item := items[0]
+		items = items[1:]
+		return item, true
+	}
+}
+
+func TestMergeSorted_FirstQueueOrdering(t *testing.T) {
+	// The empty first queue pops the lowest Priority first, so the heads of the others are compared that way.
+	first := priorityqueue.NewMin()
+	second, third := &priorityqueue.PriorityQueue{}, &priorityqueue.PriorityQueue{}
+	second.Enqueue(&priorityqueue.Item{Value: "three", Priority: 3.0})
+	second.Enqueue(&priorityqueue.Item{Value: "one", Priority: 1.0})
+	third.Enqueue(&priorityqueue.Item{Value: "two", Priority: 2.0})
+	next := priorityqueue.MergeSorted(first, second, third)
+	for _, expected := range []string{"two", "three", "one"} {
+		if item, ok := next(); !ok || expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %v", expected, item)
+		}
+	}
+	if _, ok := next(); ok {
+		t.Fatalf("Expected the merge to be exhausted")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	next := priorityqueue.Merge(
+		sliceIterator(
+			&priorityqueue.Item{Value: "fig", Priority: 12.0},
+			&priorityqueue.Item{Value: "eclair", Priority: 7.0},
+			&priorityqueue.Item{Value: "grape", Priority: -1.0},
+		),
+		sliceIterator(),
+		priorityqueue.MergeSorted(generatePriorityQueue(smallRaw)),
+		sliceIterator(
+			&priorityqueue.Item{Value: "icecream", Priority: 10.0},
+			&priorityqueue.Item{Value: "honeydew", Priority: 7.0},
+		),
+	)
+	// Items of equal Priority follow the order of their iterators.
+	expectedPopOrder := []string{"fig", "carrot", "apple", "icecream", "eclair", "honeydew", "banana", "danish", "grape"}
+	for _, expected := range expectedPopOrder {
+		item, ok := next()
+		if !ok {
+			t.Fatalf("Expected: %s Actual: no Item", expected)
+		}
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+	if item, ok := next(); ok {
+		t.Fatalf("Expected no Item, Actual: %v", item)
+	}
+	if _, ok := priorityqueue.Merge()(); ok {
+		t.Fatalf("Expected no Item")
+	}
+}
@@ -0,0 +1,210 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import "math/bits"
+
+var _ Interface = (*MinMax)(nil)
+
+// A MinMax is a double-ended priority queue backed by a min-max heap, in which both the highest and the lowest Priority
+// Items can be inspected in O(1) and removed in O(log n).  This suits bounded "best N" sets, where the worst Item must
+// be evicted to make room for a better one.  The root and every other level of the heap hold the highest Item of their
+// subtree, while the levels in between hold the lowest.  The ordering matches the default PriorityQueue:  PopMax gives
+// the highest Priority first, and Items of equal Priority in the order they were pushed, while PopMin gives exactly the
+// reverse order.  The zero value is an empty MinMax ready to use.
+type MinMax struct {
+	pq PriorityQueue // Holds the Items and their ordering.
+}
+
+// NewMinMax returns an empty MinMax.
+func NewMinMax() *MinMax {
+	return &MinMax{}
+}
+
+// Len returns the number of Items in the MinMax.
+func (h *MinMax) Len() int {
+	return h.pq.Len()
+}
+
+// Push adds item to the MinMax in O(log n).
+func (h *MinMax) Push(item *Item) {
+	h.pq.append(item)
+	h.fix(h.pq.Len() - 1)
+}
+
+// Pop is PopMax, but returns ErrEmpty if the MinMax is empty, so that MinMax satisfies Interface.
+func (h *MinMax) Pop() (*Item, error) {
+	if h.pq.Len() == 0 {
+		return nil, ErrEmpty
+	}
+	return h.PopMax(), nil
+}
+
+// Peek is PeekMax.
+func (h *MinMax) Peek() *Item {
+	return h.PeekMax()
+}
+
+// PeekMax returns the highest priority Item without removing it in O(1), or nil if the MinMax is empty.
+func (h *MinMax) PeekMax() *Item {
+	return h.pq.Peek()
+}
+
+// PeekMin returns the lowest priority Item without removing it in O(1), or nil if the MinMax is empty.
+func (h *MinMax) PeekMin() *Item {
+	i := h.indexOfMin()
+	if i < 0 {
+		return nil
+	}
+	return h.pq.items[i]
+}
+
+// PopMax removes and returns the highest priority Item in O(log n), or nil if the MinMax is empty.
+func (h *MinMax) PopMax() *Item {
+	if h.pq.Len() == 0 {
+		return nil
+	}
+	return h.removeAt(0)
+}
+
+// PopMin removes and returns the lowest priority Item in O(log n), or nil if the MinMax is empty.
+func (h *MinMax) PopMin() *Item {
+	i := h.indexOfMin()
+	if i < 0 {
+		return nil
+	}
+	return h.removeAt(i)
+}
+
+// Update modifies the Value and Priority of item, which must be in the MinMax, and re-establishes the heap invariant.
+func (h *MinMax) Update(item *Item, value interface{}, priority float64) {
+	item.Value = value
+	item.Priority = priority
+	h.fix(item.index)
+}
+
+// Remove removes item from the MinMax in O(log n).  It returns false if item is not in the MinMax.
+func (h *MinMax) Remove(item *Item) bool {
+	i := item.index
+	if i < 0 || i >= h.pq.Len() || h.pq.items[i] != item {
+		return false
+	}
+	h.removeAt(i)
+	return true
+}
+
+// indexOfMin returns the index of the lowest priority Item, which is the root or one of its children, or -1 if the
+// MinMax is empty.
+func (h *MinMax) indexOfMin() int {
+	switch h.pq.Len() {
+	case 0:
+		return -1
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.pq.Less(1, 2) {
+		return 2
+	}
+	return 1
+}
+
+// removeAt removes and returns the Item at index i.
+func (h *MinMax) removeAt(i int) *Item {
+	n := h.pq.Len() - 1
+	h.pq.Swap(i, n)
+	item := h.pq.Pop().(*Item)
+	if i < n {
+		h.fix(i)
+	}
+	return item
+}
+
+// isMaxLevel reports whether index i is on a level holding the highest Item of each subtree.
+func isMaxLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// above reports whether the Item at index i belongs nearer the root than the Item at index j, on a level holding the
+// highest Items if max is true, and the lowest otherwise.
+func (h *MinMax) above(i, j int, max bool) bool {
+	if max {
+		return h.pq.Less(i, j)
+	}
+	return h.pq.Less(j, i)
+}
+
+// fix re-establishes the heap invariant after the Item at index i has changed.
+func (h *MinMax) fix(i int) {
+	if i > 0 {
+		// The Item may belong on the levels of the other kind, e.g. it may be higher than its parent on a max level.
+		// Then it swaps with its parent and continues upwards, while the parent's Item, which outranks the whole
+		// subtree, sifts down from i.
+		if p := (i - 1) / 2; h.above(p, i, isMaxLevel(i)) {
+			h.pq.Swap(i, p)
+			h.up(p)
+			h.down(i)
+			return
+		}
+	}
+	if !h.up(i) {
+		h.down(i)
+	}
+}
+
+// up sifts the Item at index i towards the root through the levels of its own kind, and reports whether it moved.
+func (h *MinMax) up(i int) bool {
+	max := isMaxLevel(i)
+	i0 := i
+	for i > 2 {
+		g := ((i-1)/2 - 1) / 2 // grandparent
+		if !h.above(i, g, max) {
+			break
+		}
+		h.pq.Swap(i, g)
+		i = g
+	}
+	return i != i0
+}
+
+// down sifts the Item at index i towards the leaves, comparing it with its children and grandchildren.
+func (h *MinMax) down(i int) {
+	max := isMaxLevel(i)
+	n := h.pq.Len()
+	for {
+		first := 2*i + 1
+		if first >= n {
+			return
+		}
+		// Find the Item that belongs nearest the root among the children and grandchildren.
+		m := first
+		for _, c := range [...]int{first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4} {
+			if c < n && h.above(c, m, max) {
+				m = c
+			}
+		}
+		if !h.above(m, i, max) {
+			return
+		}
+		h.pq.Swap(m, i)
+		if m <= first+1 {
+			// A child can only outrank the grandchildren if it has no children of its own, so m is a leaf.
+			return
+		}
+		if p := (m - 1) / 2; h.above(p, m, max) {
+			h.pq.Swap(m, p)
+		}
+		i = m
+	}
+}
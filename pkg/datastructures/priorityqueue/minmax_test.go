@@ -0,0 +1,120 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestMinMax(t *testing.T) {
+	h := priorityqueue.NewMinMax()
+	if h.PeekMin() != nil || h.PeekMax() != nil || h.PopMin() != nil || h.PopMax() != nil {
+		t.Fatalf("Expected no Item")
+	}
+	for value, priority := range smallRaw {
+		h.Push(&priorityqueue.Item{Value: value, Priority: priority})
+	}
+	if item := h.PeekMin(); item.Value != "danish" {
+		t.Fatalf("Expected: %s Actual: %s", "danish", item.Value)
+	}
+	if item := h.PeekMax(); item.Value != "carrot" {
+		t.Fatalf("Expected: %s Actual: %s", "carrot", item.Value)
+	}
+	expectedPopOrder := []string{"danish", "carrot", "banana", "apple"}
+	for i, expected := range expectedPopOrder {
+		var item *priorityqueue.Item
+		if i%2 == 0 {
+			item = h.PopMin()
+		} else {
+			item = h.PopMax()
+		}
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+}
+
+// TestMinMax_BestN keeps the 10 highest of many random priorities by evicting the lowest whenever the MinMax grows too
+// large.
+func TestMinMax_BestN(t *testing.T) {
+	const n = 10
+	r := rand.New(rand.NewSource(1))
+	h := priorityqueue.NewMinMax()
+	reference := &priorityqueue.PriorityQueue{}
+	for i := 0; i < 10000; i++ {
+		priority := r.Float64()
+		h.Push(&priorityqueue.Item{Value: i, Priority: priority})
+		reference.Enqueue(&priorityqueue.Item{Value: i, Priority: priority})
+		if h.Len() > n {
+			h.PopMin()
+		}
+	}
+	for i := 0; i < n; i++ {
+		expected, _ := reference.Dequeue()
+		if actual := h.PopMax(); expected.Value != actual.Value {
+			t.Fatalf("Expected: %v Actual: %v", expected.Value, actual.Value)
+		}
+	}
+}
+
+func TestMinMax_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := priorityqueue.NewMinMax()
+	reference := &priorityqueue.PriorityQueue{}
+	var items []*priorityqueue.Item
+	for i := 0; i < 5000; i++ {
+		priority := float64(r.Intn(100))
+		item := &priorityqueue.Item{Value: i, Priority: priority}
+		items = append(items, item)
+		h.Push(item)
+		reference.Enqueue(&priorityqueue.Item{Value: i, Priority: priority})
+		switch r.Intn(5) {
+		case 0:
+			expected, _ := reference.Dequeue()
+			if actual := h.PopMax(); expected.Value != actual.Value {
+				t.Fatalf("Expected: %v Actual: %v", expected.Value, actual.Value)
+			}
+		case 1:
+			// Among Items of equal Priority the reference may give any, so remove the one the MinMax gave.
+			expected := reference.PeekMin()
+			actual := h.PopMin()
+			if expected.Priority != actual.Priority {
+				t.Fatalf("Expected: %f Actual: %f", expected.Priority, actual.Priority)
+			}
+			removed, _ := reference.ItemAt(reference.IndexOf(actual.Value))
+			reference.Remove(removed)
+		case 2:
+			item := items[r.Intn(len(items))]
+			if reference.IndexOf(item.Value) >= 0 {
+				priority := float64(r.Intn(100))
+				h.Update(item, item.Value, priority)
+				reference.UpdateByValue(item.Value, priority)
+			}
+		case 3:
+			item := items[r.Intn(len(items))]
+			if h.Remove(item) {
+				removed, _ := reference.ItemAt(reference.IndexOf(item.Value))
+				reference.Remove(removed)
+			}
+		}
+	}
+	for reference.Len() > 0 {
+		expected, _ := reference.Dequeue()
+		if actual := h.PopMax(); expected.Value != actual.Value {
+			t.Fatalf("Expected: %v Actual: %v", expected.Value, actual.Value)
+		}
+	}
+}
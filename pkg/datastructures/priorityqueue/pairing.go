@@ -0,0 +1,203 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+var _ Interface = (*Pairing)(nil)
+
+// A Pairing is a priority queue backed by a pairing heap.  Push, Merge and increasing the Priority of an Item through
+// Update are O(1), while Pop and Remove are O(log n) amortized.  The ordering matches the default PriorityQueue:
+// highest Priority first, and Items of equal Priority in the order they were pushed.  The zero value is an empty
+// Pairing ready to use.
+type Pairing struct {
+	root     *pairingNode
+	nodes    map[*Item]*pairingNode // The node holding each Item, for Update and Remove.
+	sequence uint64                 // The sequence of the next pushed Item.
+}
+
+// A pairingNode is a node of a pairing heap, stored as a leftmost-child, right-sibling tree.
+type pairingNode struct {
+	item     *Item
+	sequence uint64
+	child    *pairingNode // The leftmost child.
+	sibling  *pairingNode // The next sibling to the right.
+	prev     *pairingNode // The parent if this is the leftmost child, and the previous sibling otherwise.
+}
+
+// NewPairing returns an empty Pairing.
+func NewPairing() *Pairing {
+	return &Pairing{}
+}
+
+// Len returns the number of Items in the Pairing.
+func (h *Pairing) Len() int {
+	return len(h.nodes)
+}
+
+// Push adds item to the Pairing in O(1).
+func (h *Pairing) Push(item *Item) {
+	if h.nodes == nil {
+		h.nodes = make(map[*Item]*pairingNode)
+	}
+	node := &pairingNode{item: item, sequence: h.sequence}
+	h.sequence++
+	h.nodes[item] = node
+	h.root = meld(h.root, node)
+}
+
+// Pop removes and returns the highest priority Item in O(log n) amortized, or returns ErrEmpty if the Pairing is empty.
+func (h *Pairing) Pop() (*Item, error) {
+	if h.root == nil {
+		return nil, ErrEmpty
+	}
+	root := h.root
+	h.root = mergePairs(root.child)
+	delete(h.nodes, root.item)
+	return root.item, nil
+}
+
+// Peek returns the highest priority Item without removing it in O(1), or nil if the Pairing is empty.
+func (h *Pairing) Peek() *Item {
+	if h.root == nil {
+		return nil
+	}
+	return h.root.item
+}
+
+// Update modifies the Value and Priority of item, which must be in the Pairing.  Increasing the Priority is O(1), while
+// decreasing it is O(log n) amortized.
+func (h *Pairing) Update(item *Item, value interface{}, priority float64) {
+	node := h.nodes[item]
+	increased := priority >= item.Priority
+	item.Value = value
+	item.Priority = priority
+	if node == h.root {
+		if !increased {
+			h.root = meld(mergePairs(node.detachChildren()), node)
+		}
+		return
+	}
+	node.cut()
+	if !increased {
+		h.root = meld(h.root, mergePairs(node.detachChildren()))
+	}
+	h.root = meld(h.root, node)
+}
+
+// Remove removes item from the Pairing in O(log n) amortized.  It returns false if item is not in the Pairing.
+func (h *Pairing) Remove(item *Item) bool {
+	node, ok := h.nodes[item]
+	if !ok {
+		return false
+	}
+	if node == h.root {
+		h.Pop()
+		return true
+	}
+	node.cut()
+	h.root = meld(h.root, mergePairs(node.detachChildren()))
+	delete(h.nodes, item)
+	return true
+}
+
+// Merge moves every Item of other into the Pairing in O(m) for the bookkeeping of other's m Items, plus O(1) to meld the
+// heaps, leaving other empty.  Items of equal priority keep their relative order, with those of other after those
+// already in the Pairing.
+func (h *Pairing) Merge(other *Pairing) {
+	if other == h || other.root == nil {
+		return
+	}
+	if h.nodes == nil {
+		h.nodes = make(map[*Item]*pairingNode)
+	}
+	for item, node := range other.nodes {
+		node.sequence += h.sequence
+		h.nodes[item] = node
+	}
+	h.sequence += other.sequence
+	h.root = meld(h.root, other.root)
+	*other = Pairing{}
+}
+
+// before reports whether a is popped before b.
+func (a *pairingNode) before(b *pairingNode) bool {
+	if a.item.Priority == b.item.Priority {
+		return a.sequence < b.sequence
+	}
+	return a.item.Priority > b.item.Priority
+}
+
+// cut detaches the subtree rooted at a from its parent and siblings.
+func (a *pairingNode) cut() {
+	if a.prev.child == a {
+		a.prev.child = a.sibling
+	} else {
+		a.prev.sibling = a.sibling
+	}
+	if a.sibling != nil {
+		a.sibling.prev = a.prev
+	}
+	a.prev = nil
+	a.sibling = nil
+}
+
+// detachChildren removes the children of a, returning the leftmost of them.
+func (a *pairingNode) detachChildren() *pairingNode {
+	child := a.child
+	a.child = nil
+	if child != nil {
+		child.prev = nil
+	}
+	return child
+}
+
+// meld combines the heaps rooted at a and b, which must have no siblings, and returns the new root.
+func meld(a, b *pairingNode) *pairingNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.before(a) {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// mergePairs combines the heaps rooted at first and its siblings using the standard two-pass pairing, and returns the
+// new root.
+func mergePairs(first *pairingNode) *pairingNode {
+	var pairs []*pairingNode
+	for first != nil {
+		a, b := first, first.sibling
+		a.prev, a.sibling = nil, nil
+		if b == nil {
+			pairs = append(pairs, a)
+			break
+		}
+		first = b.sibling
+		b.prev, b.sibling = nil, nil
+		pairs = append(pairs, meld(a, b))
+	}
+	var root *pairingNode
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = meld(pairs[i], root)
+	}
+	return root
+}
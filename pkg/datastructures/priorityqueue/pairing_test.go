@@ -0,0 +1,115 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestPairing_Merge(t *testing.T) {
+	h := priorityqueue.NewPairing()
+	for value, priority := range smallRaw {
+		h.Push(&priorityqueue.Item{Value: value, Priority: priority})
+	}
+	h.Push(&priorityqueue.Item{Value: "honeydew", Priority: 5.0})
+	var other priorityqueue.Pairing
+	other.Push(&priorityqueue.Item{Value: "icecream", Priority: 5.0})
+	fig := &priorityqueue.Item{Value: "fig", Priority: 12.0}
+	other.Push(fig)
+	h.Merge(&other)
+	if other.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, other.Len())
+	}
+	h.Update(fig, "fig", 1.0)
+	expectedPopOrder := []string{"carrot", "apple", "banana", "honeydew", "icecream", "fig", "danish"}
+	if h.Len() != len(expectedPopOrder) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedPopOrder), h.Len())
+	}
+	for _, expected := range expectedPopOrder {
+		item, _ := h.Pop()
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+}
+
+// testInterface runs the same scenario against any implementation of priorityqueue.Interface, checking the result
+// against a PriorityQueue.
+func testInterface(t *testing.T, name string, q priorityqueue.Interface) {
+	r := rand.New(rand.NewSource(1))
+	reference := &priorityqueue.PriorityQueue{}
+	var items []*priorityqueue.Item
+	for i := 0; i < 2000; i++ {
+		priority := float64(r.Intn(100))
+		item := &priorityqueue.Item{Value: i, Priority: priority}
+		items = append(items, item)
+		q.Push(item)
+		reference.Enqueue(&priorityqueue.Item{Value: i, Priority: priority})
+		if i%10 == 0 {
+			expected, _ := reference.Dequeue()
+			actual, _ := q.Pop()
+			if expected.Value != actual.Value {
+				t.Fatalf("%s: Expected: %v Actual: %v", name, expected.Value, actual.Value)
+			}
+		}
+	}
+	for i := 0; i < 500; i++ {
+		item := items[r.Intn(len(items))]
+		if q.Remove(item) {
+			removed, _ := reference.ItemAt(reference.IndexOf(item.Value))
+			reference.Remove(removed)
+		}
+		item = items[r.Intn(len(items))]
+		if reference.IndexOf(item.Value) >= 0 {
+			priority := float64(r.Intn(100))
+			q.Update(item, item.Value, priority)
+			reference.UpdateByValue(item.Value, priority)
+		}
+	}
+	if q.Len() != reference.Len() {
+		t.Fatalf("%s: Expected: %d Actual: %d", name, reference.Len(), q.Len())
+	}
+	for reference.Len() > 0 {
+		expected, _ := reference.Dequeue()
+		if actual := q.Peek(); expected.Priority != actual.Priority {
+			t.Fatalf("%s: Expected: %f Actual: %f", name, expected.Priority, actual.Priority)
+		}
+		actual, _ := q.Pop()
+		if expected.Priority != actual.Priority {
+			t.Fatalf("%s: Expected: %f Actual: %f", name, expected.Priority, actual.Priority)
+		}
+	}
+	if _, err := q.Pop(); err != priorityqueue.ErrEmpty {
+		t.Fatalf("%s: Expected: %s Actual: %v", name, priorityqueue.ErrEmpty, err)
+	}
+	if q.Peek() != nil {
+		t.Fatalf("%s: Expected no Item", name)
+	}
+}
+
+func TestInterface(t *testing.T) {
+	implementations := map[string]priorityqueue.Interface{
+		"Queue":      priorityqueue.NewQueue(),
+		"DAry":       priorityqueue.NewDAry(4),
+		"Pairing":    priorityqueue.NewPairing(),
+		"Expiring":   priorityqueue.NewExpiring(),
+		"MinMax":     priorityqueue.NewMinMax(),
+		"Cancelable": priorityqueue.NewCancelable(0),
+	}
+	for name, q := range implementations {
+		testInterface(t, name, q)
+	}
+}
@@ -18,76 +18,706 @@ https://golang.org/pkg/container/heap/.
 package priorityqueue
 
 import (
+	"bufio"
 	"bytes"
 	"container/heap"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"reflect"
+	"sort"
+	"time"
+	"unsafe"
 )
 
-// An Item is something we manage in a Priority queue.
+// ErrEmpty is returned when an Item is requested from an empty PriorityQueue.
+var ErrEmpty = errors.New("priorityqueue: empty")
+
+// ErrCorrupt is wrapped by the errors returned from Verify.
+var ErrCorrupt = errors.New("priorityqueue: corrupt")
+
+// An Item is something we manage in a Priority queue.  Items may be constructed directly, but NewItem is recommended
+// since it validates the Item.
 type Item struct {
 	Value    interface{} // The Value of the item; arbitrary.
 	Priority float64     // The Priority of the item in the queue.
+	Expiry   time.Time   // The Expiry of the item; the zero Time means none.
 	// The index is needed by update and is maintained by the heap.Interface methods.
-	index    int // The index of the item in the heap.
+	index int // The index of the item in the heap.
+	// The sequence breaks ties between items of equal Priority, and is stamped by Push.
+	sequence uint64 // The insertion order of the item.
+}
+
+// A PriorityQueue implements heap.Interface and holds Items.  The zero value is an empty PriorityQueue that pops the
+// highest Priority first, and pops Items of equal Priority in the order they were pushed.
+type PriorityQueue struct {
+	items    []*Item
+	less     func(a, b *Item) bool // The ordering; nil orders by highest Priority.
+	stable   bool                  // Whether ties under less are popped in the order they were pushed.
+	reversed bool                  // Whether Pop gives Items in the opposite of the ordering.
+	sequence uint64                // The sequence of the next pushed Item.
+	// decodeValue reconstructs a Value in UnmarshalJSON; nil decodes into an interface{}.
+	decodeValue func(json.RawMessage) (interface{}, error)
+}
+
+// An Option configures a PriorityQueue constructed by NewWithComparator.
+type Option func(*PriorityQueue)
+
+// Stable makes a PriorityQueue pop Items that are tied under its comparator, i.e. for which neither is popped before the
+// other, in the order they were pushed.  Without it, the order of ties is unspecified.  Stable costs an extra comparison
+// whenever the comparator reports that a is not popped before b.
+func Stable() Option {
+	return func(pq *PriorityQueue) {
+		pq.stable = true
+	}
+}
+
+// NewWithComparator returns an empty PriorityQueue ordered by less, which reports whether a is popped before b,
+// configured by opts.  See SetComparator.
+func NewWithComparator(less func(a, b *Item) bool, opts ...Option) *PriorityQueue {
+	pq := &PriorityQueue{less: less}
+	for _, opt := range opts {
+		opt(pq)
+	}
+	return pq
 }
 
-// A PriorityQueue implements heap.Interface and holds Items.
-type PriorityQueue []*Item
+// NewMin returns an empty PriorityQueue that pops the lowest Priority first.
+func NewMin() *PriorityQueue {
+	return &PriorityQueue{reversed: true}
+}
+
+// NewFromItems returns a PriorityQueue holding items, heapified in O(n) rather than pushed one at a time in O(n log n).
+// The PriorityQueue takes ownership of items, which must not be used by the caller afterwards.  Items of equal Priority
+// are popped in the order they appear in items.
+func NewFromItems(items []*Item) *PriorityQueue {
+	pq := &PriorityQueue{items: items}
+	for i, item := range items {
+		item.index = i
+		item.sequence = pq.sequence
+		pq.sequence++
+	}
+	heap.Init(pq)
+	return pq
+}
 
-func (pq PriorityQueue) Len() int { return len(pq) }
+// FromMap returns a PriorityQueue holding one Item per entry of m, using the key as the Value and the value as the
+// Priority.  The PriorityQueue is heapified in O(n), so the iteration order of m is irrelevant, except that Items of equal
+// Priority are popped in that unspecified order.  Since map keys are unique, no two Items share a Value.
+func FromMap(m map[string]float64) *PriorityQueue {
+	pq := &PriorityQueue{items: make([]*Item, 0, len(m))}
+	for value, priority := range m {
+		pq.append(&Item{Value: value, Priority: priority})
+	}
+	heap.Init(pq)
+	return pq
+}
 
-func (pq PriorityQueue) Less(i, j int) bool {
+func (pq *PriorityQueue) Len() int { return len(pq.items) }
+
+func (pq *PriorityQueue) Less(i, j int) bool {
+	return pq.before(pq.items[i], pq.items[j])
+}
+
+// before reports whether a is popped before b.
+func (pq *PriorityQueue) before(a, b *Item) bool {
+	if pq.less != nil {
+		first, second := a, b
+		if pq.reversed {
+			first, second = b, a
+		}
+		if pq.less(first, second) {
+			return true
+		}
+		if pq.stable && !pq.less(second, first) {
+			return a.sequence < b.sequence
+		}
+		return false
+	}
+	if a.Priority == b.Priority {
+		// Items of equal Priority are popped in the order they were pushed, whichever the direction.
+		return a.sequence < b.sequence
+	}
 	// We want Pop to give us the highest, not lowest, Priority so we use greater than here.
-	return pq[i].Priority > pq[j].Priority
+	return a.Priority > b.Priority != pq.reversed
+}
+
+// tied reports whether a and b are of equal priority, i.e. whether they are ordered by insertion alone.
+func (pq *PriorityQueue) tied(a, b *Item) bool {
+	if pq.less != nil {
+		return !pq.less(a, b) && !pq.less(b, a)
+	}
+	return a.Priority == b.Priority
 }
 
-func (pq PriorityQueue) Swap(i, j int) {
-	pq[i], pq[j] = pq[j], pq[i]
-	pq[i].index = i
-	pq[j].index = j
+func (pq *PriorityQueue) Swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
 }
 
 func (pq *PriorityQueue) Push(x interface{}) {
-	n := len(*pq)
-	item := x.(*Item)
-	item.index = n
-	*pq = append(*pq, item)
+	pq.append(x.(*Item))
+}
+
+// append stamps item with the next sequence and appends it to the underlying heap array without re-establishing the
+// heap invariant.
+func (pq *PriorityQueue) append(item *Item) {
+	item.index = len(pq.items)
+	item.sequence = pq.sequence
+	pq.sequence++
+	pq.items = append(pq.items, item)
 }
 
 func (pq *PriorityQueue) Pop() interface{} {
-	old := *pq
+	old := pq.items
 	n := len(old)
 	item := old[n-1]
 	old[n-1] = nil  // avoid memory leak
 	item.index = -1 // for safety
-	*pq = old[0 : n-1]
+	pq.items = old[0 : n-1]
 	return item
 }
 
-// Marshal a PriorityQueue in priorityqueue order.  Warning, this method is not terribly efficient, as iterating over a
-// heap-based PriorityQueue is destructive.  Thus, O(n) auxillary space is required to store the item references and
-// O(n) time complexity is needed to re-construct the priority queue post destruction.  There are likely more efficient
-// implementations, but in this case n is expected to remain sufficiently small, so this implementation is "good
-// enough".
+// Enqueue adds item to the PriorityQueue in O(log n).  It is equivalent to heap.Push(pq, item).
+func (pq *PriorityQueue) Enqueue(item *Item) {
+	heap.Push(pq, item)
+}
+
+// Dequeue removes and returns the highest priority Item in O(log n).  Unlike heap.Pop, it returns ErrEmpty rather than
+// panicking if the PriorityQueue is empty.
+func (pq *PriorityQueue) Dequeue() (*Item, error) {
+	if len(pq.items) == 0 {
+		return nil, ErrEmpty
+	}
+	return heap.Pop(pq).(*Item), nil
+}
+
+// Requeue adds item, which must have been popped from this PriorityQueue, back in O(log n).  Unlike Enqueue, it keeps
+// the sequence item was stamped with when first pushed, so item is popped again before the Items of equal priority
+// pushed after it, as if it had never been popped.  This lets a consumer give back an Item it could not handle.
+func (pq *PriorityQueue) Requeue(item *Item) {
+	item.index = len(pq.items)
+	pq.items = append(pq.items, item)
+	heap.Fix(pq, item.index)
+}
+
+// Peek returns the highest priority Item without removing it in O(1), or nil if the PriorityQueue is empty.
+func (pq *PriorityQueue) Peek() *Item {
+	if len(pq.items) == 0 {
+		return nil
+	}
+	return pq.items[0]
+}
+
+// SetComparator replaces the ordering of the PriorityQueue with less, which reports whether a is popped before b, and
+// re-establishes the heap invariant in O(n).  A nil less restores the default ordering by highest Priority.  Like every
+// other method, SetComparator must not be called while another goroutine operates on the PriorityQueue without external
+// locking.
+func (pq *PriorityQueue) SetComparator(less func(a, b *Item) bool) {
+	pq.less = less
+	heap.Init(pq)
+}
+
+// Min returns the Item that would be popped last, without removing it.  For the default ordering that is the lowest
+// Priority Item; for a reversed PriorityQueue it is the highest.  The second return value is false if the PriorityQueue
+// is empty.  Unlike Peek, which is O(1), Min scans every Item and is O(n).
+func (pq *PriorityQueue) Min() (*Item, bool) {
+	if len(pq.items) == 0 {
+		return nil, false
+	}
+	last := pq.items[0]
+	for _, item := range pq.items[1:] {
+		if pq.before(last, item) {
+			last = item
+		}
+	}
+	return last, true
+}
+
+// PopMany pops min(n, Len()) Items, appends them to dst in Pop order and returns the extended slice.  Passing a dst with
+// spare capacity lets callers reuse one buffer across calls and avoid reallocation when draining large batches.
+func (pq *PriorityQueue) PopMany(n int, dst []*Item) []*Item {
+	for ; n > 0 && len(pq.items) > 0; n-- {
+		dst = append(dst, heap.Pop(pq).(*Item))
+	}
+	return dst
+}
+
+// PopN pops min(n, Len()) Items and returns them in Pop order.  The result is allocated once at its final size.
+func (pq *PriorityQueue) PopN(n int) []*Item {
+	if n > len(pq.items) {
+		n = len(pq.items)
+	}
+	if n <= 0 {
+		return nil
+	}
+	return pq.PopMany(n, make([]*Item, 0, n))
+}
+
+// Drain removes and returns every Item in Pop order, leaving the PriorityQueue empty.  Rather than popping each Item,
+// Drain sorts the underlying heap array once, which is cheaper than n calls to heap.Pop.
+func (pq *PriorityQueue) Drain() []*Item {
+	drained := pq.sorted()
+	pq.Reset()
+	return drained
+}
+
+// Verify checks in O(n) that every Item records its own index and that the heap invariant holds, which catches
+// corruption such as a Priority changed directly rather than through Update.  It returns nil if the PriorityQueue is
+// sound, and otherwise an error wrapping ErrCorrupt that describes the first problem found.
+func (pq *PriorityQueue) Verify() error {
+	for i, item := range pq.items {
+		switch {
+		case item == nil:
+			return fmt.Errorf("%w: nil Item at index %d", ErrCorrupt, i)
+		case item.index != i:
+			return fmt.Errorf("%w: Item at index %d records index %d", ErrCorrupt, i, item.index)
+		case math.IsNaN(item.Priority):
+			return fmt.Errorf("%w: Item at index %d has a NaN Priority", ErrCorrupt, i)
+		case i > 0 && pq.Less(i, (i-1)/2):
+			return fmt.Errorf("%w: Item at index %d is ordered before its parent", ErrCorrupt, i)
+		}
+	}
+	return nil
+}
+
+// retain drops the Items for which keep returns false in O(n), setting their index to -1, and returns how many were
+// dropped.  The Items kept retain their sequence, so Items of equal priority keep their order.
+func (pq *PriorityQueue) retain(keep func(*Item) bool) int {
+	kept := pq.items[:0]
+	for _, item := range pq.items {
+		if !keep(item) {
+			item.index = -1
+			continue
+		}
+		item.index = len(kept)
+		kept = append(kept, item)
+	}
+	dropped := len(pq.items) - len(kept)
+	clear(pq.items[len(kept):])
+	pq.items = kept
+	if dropped > 0 {
+		heap.Init(pq)
+	}
+	return dropped
+}
+
+// IsEmpty reports whether the PriorityQueue holds no Items.
+func (pq *PriorityQueue) IsEmpty() bool {
+	return len(pq.items) == 0
+}
+
+// Clear removes every Item and releases the underlying heap array, so that neither it nor the Items can be retained
+// through the PriorityQueue.  Removed Items have their index set to -1.  The ordering of the PriorityQueue is kept.
+func (pq *PriorityQueue) Clear() {
+	pq.Reset()
+	pq.items = nil
+}
+
+// Reset removes every Item but keeps the capacity of the underlying heap array, so that a PriorityQueue reused in a hot
+// loop does not reallocate it.  The references to the removed Items are cleared, as in Pop, and the Items have their
+// index set to -1.  The ordering of the PriorityQueue is kept.
+func (pq *PriorityQueue) Reset() {
+	for i, item := range pq.items {
+		item.index = -1
+		pq.items[i] = nil
+	}
+	pq.items = pq.items[:0]
+}
+
+// PeekMin returns the lowest Priority Item without removing it, or nil if the PriorityQueue is empty.  Priorities are
+// compared as stored, so there is no need to negate them to find the lowest.  PeekMin is O(1) if the PriorityQueue was
+// reversed to pop the lowest Priority first, and O(n) otherwise.
+func (pq *PriorityQueue) PeekMin() *Item {
+	index := pq.indexOfMin()
+	if index < 0 {
+		return nil
+	}
+	return pq.items[index]
+}
+
+// PopMin removes and returns the lowest Priority Item, or nil if the PriorityQueue is empty.  It has the cost of
+// PeekMin plus O(log n) to remove the Item.
+func (pq *PriorityQueue) PopMin() *Item {
+	index := pq.indexOfMin()
+	if index < 0 {
+		return nil
+	}
+	return heap.Remove(pq, index).(*Item)
+}
+
+// indexOfMin returns the index of the lowest Priority Item, or -1 if the PriorityQueue is empty.
+func (pq *PriorityQueue) indexOfMin() int {
+	if len(pq.items) == 0 {
+		return -1
+	}
+	if pq.less == nil && pq.reversed {
+		return 0
+	}
+	start := 0
+	if pq.less == nil {
+		// In a max-heap the lowest Priority is always a leaf.
+		start = len(pq.items) / 2
+	}
+	lowest := start
+	for i := start + 1; i < len(pq.items); i++ {
+		if pq.items[i].Priority < pq.items[lowest].Priority {
+			lowest = i
+		}
+	}
+	return lowest
+}
+
+// PeekPriority returns the Priority of the highest priority Item in O(1).  The second return value is false if the
+// PriorityQueue is empty.
+func (pq *PriorityQueue) PeekPriority() (float64, bool) {
+	if len(pq.items) == 0 {
+		return 0, false
+	}
+	return pq.items[0].Priority, true
+}
+
+// PopWhile pops Items while pred holds for the highest priority Item, and returns them in Pop order.  It stops at the
+// first Item for which pred is false, which is left in the PriorityQueue, or when the PriorityQueue is empty.
+func (pq *PriorityQueue) PopWhile(pred func(*Item) bool) []*Item {
+	var popped []*Item
+	for len(pq.items) > 0 && pred(pq.items[0]) {
+		popped = append(popped, heap.Pop(pq).(*Item))
+	}
+	return popped
+}
+
+// Truncate retains the n highest priority Items and drops the rest, in O(n log m) for a PriorityQueue of m Items.
+// Dropped Items have their index set to -1.
+func (pq *PriorityQueue) Truncate(n int) {
+	if n >= len(pq.items) {
+		return
+	}
+	if n < 0 {
+		n = 0
+	}
+	retained := make([]*Item, 0, n)
+	for len(retained) < n {
+		retained = append(retained, heap.Pop(pq).(*Item))
+	}
+	for i, item := range pq.items {
+		item.index = -1
+		pq.items[i] = nil
+	}
+	pq.items = pq.items[:0]
+	// retained is in Pop order, which already satisfies the heap invariant.
+	for _, item := range retained {
+		item.index = len(pq.items)
+		pq.items = append(pq.items, item)
+	}
+}
+
+// Reverse flips the ordering of the PriorityQueue in place, so subsequent Pops give Items in the opposite order.  This
+// is O(n).
+func (pq *PriorityQueue) Reverse() {
+	pq.reversed = !pq.reversed
+	heap.Init(pq)
+}
+
+// PushAll adds items to the PriorityQueue, choosing the cheaper of two strategies.  Pushing k items one at a time into a
+// PriorityQueue of n items is O(k log(n + k)), while appending them all and re-establishing the heap invariant once
+// using heap.Init is O(n + k), so the latter is used when the batch is large relative to the PriorityQueue.
+func (pq *PriorityQueue) PushAll(items ...*Item) {
+	n, k := len(pq.items), len(items)
+	if k*bits.Len(uint(n+k)) <= n+k {
+		for _, item := range items {
+			pq.Enqueue(item)
+		}
+		return
+	}
+	for _, item := range items {
+		pq.append(item)
+	}
+	heap.Init(pq)
+}
+
+// IndexOf returns the current index of the Item holding value in the underlying heap array, or -1 if no such Item
+// exists.  Values are compared using ==, and a value that is not comparable, such as a slice or a struct holding a
+// slice in an interface field, is held by no Item, so -1 is returned rather than panicking.  This is an O(n) operation.
+func (pq *PriorityQueue) IndexOf(value interface{}) int {
+	if value != nil && !reflect.ValueOf(value).Comparable() {
+		return -1
+	}
+	for _, item := range pq.items {
+		if item.Value == value {
+			return item.index
+		}
+	}
+	return -1
+}
+
+// Contains reports whether the PriorityQueue holds an Item holding value, in O(n).  See IndexOf.
+func (pq *PriorityQueue) Contains(value interface{}) bool {
+	return pq.IndexOf(value) >= 0
+}
+
+// Find returns the highest priority Item for which pred returns true, or nil if there is none, in O(n).  The Item may
+// then be passed to Update or Remove.  pred must not modify the PriorityQueue or change any Priority.
+func (pq *PriorityQueue) Find(pred func(*Item) bool) *Item {
+	var found *Item
+	for _, item := range pq.items {
+		if pred(item) && (found == nil || pq.before(item, found)) {
+			found = item
+		}
+	}
+	return found
+}
+
+// Walk calls fn for each Item in the order of the underlying heap array, which is not Pop order, and stops early if fn
+// returns false.  fn must not modify the PriorityQueue or change any Priority.
+func (pq *PriorityQueue) Walk(fn func(*Item) bool) {
+	for _, item := range pq.items {
+		if !fn(item) {
+			return
+		}
+	}
+}
+
+// Update modifies the Value and Priority of item, which must be in the PriorityQueue, and re-establishes the heap
+// invariant in O(log n).  This supports decrease-key, as used by algorithms such as Dijkstra's and A*.
+func (pq *PriorityQueue) Update(item *Item, value interface{}, priority float64) {
+	item.Value = value
+	item.Priority = priority
+	heap.Fix(pq, item.index)
+}
+
+// Remove removes item from the PriorityQueue in O(log n).  It returns false if item is not in the PriorityQueue.
+func (pq *PriorityQueue) Remove(item *Item) bool {
+	if item.index < 0 || item.index >= len(pq.items) || pq.items[item.index] != item {
+		return false
+	}
+	heap.Remove(pq, item.index)
+	return true
+}
+
+// UpdateByValue sets the Priority of the Item holding value to priority and re-establishes the heap invariant.  It
+// returns false if no such Item exists.  Finding the Item is O(n); see IndexOf.
+func (pq *PriorityQueue) UpdateByValue(value interface{}, priority float64) bool {
+	index := pq.IndexOf(value)
+	if index < 0 {
+		return false
+	}
+	pq.Update(pq.items[index], value, priority)
+	return true
+}
+
+// ItemAt returns the Item at index i of the underlying heap array.  The second return value is false if i is out of
+// range.  The returned Item must not be modified in a way that changes its Priority.
+func (pq *PriorityQueue) ItemAt(i int) (*Item, bool) {
+	if i < 0 || i >= len(pq.items) {
+		return nil, false
+	}
+	return pq.items[i], true
+}
+
+// MapValues replaces the Value of every Item with the result of applying f to it.  Priorities are unchanged, so the heap
+// invariant is preserved.  f must not depend on or alter any Priority.
+func (pq *PriorityQueue) MapValues(f func(interface{}) interface{}) {
+	for _, item := range pq.items {
+		item.Value = f(item.Value)
+	}
+}
+
+// Histogram counts the Items whose Priority falls into each bucket defined by bucketEdges, which must be sorted in
+// ascending order.  Buckets are half-open intervals, so for k edges the returned slice has k+1 counts:
+//
+//	counts[0]  Priority < bucketEdges[0]
+//	counts[i]  bucketEdges[i-1] <= Priority < bucketEdges[i]
+//	counts[k]  bucketEdges[k-1] <= Priority
+//
+// The first and last counts are the underflow and overflow buckets.  Histogram is O(n log k) and does not modify the
+// PriorityQueue.
+func (pq *PriorityQueue) Histogram(bucketEdges []float64) []int {
+	counts := make([]int, len(bucketEdges)+1)
+	for _, item := range pq.items {
+		bucket := sort.Search(len(bucketEdges), func(i int) bool {
+			return bucketEdges[i] > item.Priority
+		})
+		counts[bucket]++
+	}
+	return counts
+}
+
+// ShrinkToFit reallocates the underlying heap array so that its capacity matches the number of Items, releasing any
+// capacity left over from earlier growth.
+func (pq *PriorityQueue) ShrinkToFit() {
+	items := make([]*Item, len(pq.items))
+	copy(items, pq.items)
+	pq.items = items
+}
+
+// LoadFactor returns the fraction of the capacity of the underlying heap array that holds Items.  A PriorityQueue with
+// no capacity wastes none, so its LoadFactor is 1.
+func (pq *PriorityQueue) LoadFactor() float64 {
+	if cap(pq.items) == 0 {
+		return 1
+	}
+	return float64(len(pq.items)) / float64(cap(pq.items))
+}
+
+// ShouldShrink reports whether the LoadFactor is below threshold, i.e. whether ShrinkToFit would reclaim a significant
+// fraction of the underlying heap array.
+func (pq *PriorityQueue) ShouldShrink(threshold float64) bool {
+	return pq.LoadFactor() < threshold
+}
+
+// MemoryUsage returns an approximate number of bytes used by the PriorityQueue:  the PriorityQueue itself, the
+// capacity of the underlying heap array, and the Items it holds.  Only the interface header of each Value is counted;
+// the memory referenced by arbitrary Values cannot be accounted for.  This is intended as a rough aid for capacity
+// planning, not an exact measure.
+func (pq *PriorityQueue) MemoryUsage() int {
+	var item *Item
+	size := unsafe.Sizeof(*pq)
+	size += uintptr(cap(pq.items)) * unsafe.Sizeof(item)
+	size += uintptr(len(pq.items)) * unsafe.Sizeof(*item)
+	return int(size)
+}
+
+// sorted returns a copy of the underlying Items in Pop order without modifying the PriorityQueue.
+func (pq *PriorityQueue) sorted() []*Item {
+	items := make([]*Item, len(pq.items))
+	copy(items, pq.items)
+	sort.SliceStable(items, func(i, j int) bool {
+		return pq.before(items[i], items[j])
+	})
+	return items
+}
+
+// Marshal a PriorityQueue in priorityqueue order.  The output is deterministic:  Items of equal priority are ordered by
+// their encodings rather than by insertion order, so the same logical contents always marshal to the same bytes,
+// however they were pushed.  A round trip through UnmarshalJSON therefore does not keep the insertion order among Items
+// of equal priority; MarshalBinary does.  See EncodeJSON.
 func (pq *PriorityQueue) MarshalJSON() ([]byte, error) {
-	buffer := bytes.NewBufferString("[")
-	pqLen := pq.Len()
-	var pqCopy []*Item
-	for i := 0; i < pqLen; i++ {
-		item := heap.Pop(pq).(*Item)
-		json, err := json.Marshal(*item)
+	var buffer bytes.Buffer
+	if err := pq.EncodeJSON(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// EncodeJSON writes the PriorityQueue to w in the array format produced by MarshalJSON, without encoding the whole
+// PriorityQueue in memory first.  Items are streamed in Pop order from SortedIter, so the PriorityQueue is not modified
+// and the encoding of an Item is written as soon as the run of Items of equal priority it belongs to is complete.
+// Memory is that of SortedIter, whose frontier of indexes is O(n) in the worst case, plus the encodings of one run of
+// Items of equal priority.  Those Items are written in the byte order of their encodings, not in insertion order, so
+// decoding the output does not restore the order in which they were pushed.  Writes to w are buffered, and flushed
+// before EncodeJSON returns.
+func (pq *PriorityQueue) EncodeJSON(w io.Writer) error {
+	buffered := bufio.NewWriter(w)
+	if err := buffered.WriteByte('['); err != nil {
+		return err
+	}
+	var previous *Item
+	var run [][]byte
+	written := 0
+	flush := func() error {
+		sort.Slice(run, func(i, j int) bool {
+			return bytes.Compare(run[i], run[j]) < 0
+		})
+		for _, itemJSON := range run {
+			if written > 0 {
+				if err := buffered.WriteByte(','); err != nil {
+					return err
+				}
+			}
+			if _, err := buffered.Write(itemJSON); err != nil {
+				return err
+			}
+			written++
+		}
+		run = run[:0]
+		return nil
+	}
+	for item := range pq.SortedIter() {
+		if previous != nil && !pq.tied(previous, item) {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		itemJSON, err := json.Marshal(newJSONItem(item))
 		if err != nil {
-			return nil, err
+			return err
+		}
+		run = append(run, itemJSON)
+		previous = item
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	if err := buffered.WriteByte(']'); err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+// A jsonItem is the layout of an Item in the array format of MarshalJSON.  Expiry is a pointer so that a zero Expiry
+// is omitted.
+type jsonItem struct {
+	Value    interface{}
+	Priority float64
+	Expiry   *time.Time `json:",omitempty"`
+}
+
+// newJSONItem returns the layout of item in the array format of MarshalJSON.
+func newJSONItem(item *Item) jsonItem {
+	record := jsonItem{Value: item.Value, Priority: item.Priority}
+	if !item.Expiry.IsZero() {
+		record.Expiry = &item.Expiry
+	}
+	return record
+}
+
+// SetValueDecoder registers decode to reconstruct each Value in UnmarshalJSON.  Since Value is arbitrary, UnmarshalJSON
+// cannot know its concrete type, and without a decoder Values are decoded as by json.Unmarshal into an interface{}, e.g.
+// as a map[string]interface{} or a float64.  A nil decode restores that behavior.
+func (pq *PriorityQueue) SetValueDecoder(decode func(json.RawMessage) (interface{}, error)) {
+	pq.decodeValue = decode
+}
+
+// UnmarshalJSON replaces the contents of the PriorityQueue with the Items in data, which must be in the array format
+// produced by MarshalJSON.  The ordering of the PriorityQueue is kept and the heap invariant is re-established in O(n).
+// Values are reconstructed using the decoder registered by SetValueDecoder, if any.
+func (pq *PriorityQueue) UnmarshalJSON(data []byte) error {
+	var raw []struct {
+		Value    json.RawMessage
+		Priority float64
+		Expiry   time.Time
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	items := make([]*Item, len(raw))
+	for i, r := range raw {
+		var value interface{}
+		var err error
+		if pq.decodeValue != nil {
+			value, err = pq.decodeValue(r.Value)
+		} else {
+			err = json.Unmarshal(r.Value, &value)
 		}
-        buffer.WriteString(string(json))
-		if i < pqLen - 1 {
-			buffer.WriteByte(',')
+		if err != nil {
+			return err
 		}
-		pqCopy = append(pqCopy, item)
+		items[i] = &Item{Value: value, Priority: r.Priority, Expiry: r.Expiry}
 	}
-	buffer.WriteString("]")
-	for _, item := range pqCopy {
-		pq.Push(item)
+	for _, item := range pq.items {
+		item.index = -1
 	}
-	return buffer.Bytes(), nil
+	pq.items = make([]*Item, 0, len(items))
+	// Stamping the Items in array order makes Items of equal Priority pop in the order they were encoded, which
+	// MarshalJSON chooses by their encodings rather than by the order they were originally pushed.
+	for _, item := range items {
+		pq.append(item)
+	}
+	heap.Init(pq)
+	return nil
 }
@@ -15,16 +15,22 @@ package priorityqueue_test
 import (
 	"container/heap"
 	"encoding/json"
+	"errors"
 	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+	"math"
 	"os"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 )
 
 var testCases []*testCase
 
+var smallRaw = map[string]float64{"apple": 10.0, "banana": 5.0, "carrot": 11.0, "danish": 0.0}
 
 type testCase struct {
-	description string
+	description      string
 	pq               *priorityqueue.PriorityQueue
 	raw              map[string]float64
 	expectedPopOrder *[]string
@@ -38,7 +44,7 @@ func generatePriorityQueue(raw map[string]float64) *priorityqueue.PriorityQueue
 	pq := priorityqueue.PriorityQueue{}
 	for value, priority := range raw {
 		item := &priorityqueue.Item{
-			Value: value,
+			Value:    value,
 			Priority: priority,
 		}
 		heap.Push(&pq, item)
@@ -49,9 +55,9 @@ func generatePriorityQueue(raw map[string]float64) *priorityqueue.PriorityQueue
 func createTestCase(description string, raw map[string]float64, expectedPopOrder *[]string) *testCase {
 	pq := generatePriorityQueue(raw)
 	testCase := &testCase{
-		description: description,
-		pq: pq,
-		raw: raw,
+		description:      description,
+		pq:               pq,
+		raw:              raw,
 		expectedPopOrder: expectedPopOrder,
 	}
 	return testCase
@@ -67,7 +73,7 @@ func setupEmptyPriorityQueueTestCase() {
 
 func setupSmallPriorityQueueTestCase() {
 	description := "Small PriorityQueue"
-	raw := map[string]float64{"apple": 10.0, "banana": 5.0, "carrot": 11.0, "danish": 0.0}
+	raw := smallRaw
 	expectedPopOrder := &[]string{"carrot", "apple", "banana", "danish"}
 	testCase := createTestCase(description, raw, expectedPopOrder)
 	testCases = append(testCases, testCase)
@@ -78,7 +84,7 @@ func setupLargePriorityQueueTestCase() {
 	raw := map[string]float64{}
 	expectedPopOrder := []string{}
 	for i := 0; i < 1000; i++ {
-		str := string(i)
+		str := strconv.Itoa(i)
 		expectedPopOrder = append([]string{str}, expectedPopOrder...)
 		val := float64(i)
 		raw[str] = val
@@ -136,6 +142,823 @@ func TestPriorityQueue_MarshalJSON(t *testing.T) {
 	}
 }
 
+func TestPriorityQueue_DuplicatePriorities(t *testing.T) {
+	// Items of equal Priority pop in the order they were pushed.
+	pq := &priorityqueue.PriorityQueue{}
+	for i := 0; i < 100; i++ {
+		heap.Push(pq, &priorityqueue.Item{Value: i, Priority: float64(i % 5)})
+	}
+	for priority := 4; priority >= 0; priority-- {
+		for i := priority; i < 100; i += 5 {
+			actual := heap.Pop(pq).(*priorityqueue.Item).Value
+			if i != actual {
+				t.Fatalf("Expected: %d Actual: %d", i, actual)
+			}
+		}
+	}
+
+	// Interleaving pushes and pops keeps the order of the remaining Items of equal Priority.
+	pq = &priorityqueue.PriorityQueue{}
+	for i := 0; i < 10; i++ {
+		heap.Push(pq, &priorityqueue.Item{Value: i, Priority: 1.0})
+	}
+	for i := 0; i < 5; i++ {
+		heap.Pop(pq)
+		heap.Push(pq, &priorityqueue.Item{Value: 10 + i, Priority: 1.0})
+	}
+	pq.PushAll(&priorityqueue.Item{Value: 15, Priority: 1.0}, &priorityqueue.Item{Value: 16, Priority: 1.0})
+	for i := 5; i < 17; i++ {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if i != actual {
+			t.Fatalf("Expected: %d Actual: %d", i, actual)
+		}
+	}
+
+	// Reversing changes the order of Priorities but not of Items of equal Priority.
+	pq = &priorityqueue.PriorityQueue{}
+	for i := 0; i < 100; i++ {
+		heap.Push(pq, &priorityqueue.Item{Value: i, Priority: float64(i % 5)})
+	}
+	pq.Reverse()
+	for priority := 0; priority < 5; priority++ {
+		for i := priority; i < 100; i += 5 {
+			actual := heap.Pop(pq).(*priorityqueue.Item).Value
+			if i != actual {
+				t.Fatalf("Expected: %d Actual: %d", i, actual)
+			}
+		}
+	}
+}
+
+func TestPriorityQueue_IndexOf(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	for value := range smallRaw {
+		index := pq.IndexOf(value)
+		if index < 0 {
+			t.Fatalf("Expected an index for: %s", value)
+		}
+		item, ok := pq.ItemAt(index)
+		if !ok {
+			t.Fatalf("Expected an Item at: %d", index)
+		}
+		if item.Value != value {
+			t.Fatalf("Expected: %s Actual: %s", value, item.Value)
+		}
+	}
+	if index := pq.IndexOf("eclair"); index != -1 {
+		t.Fatalf("Expected: %d Actual: %d", -1, index)
+	}
+	if _, ok := pq.ItemAt(pq.Len()); ok {
+		t.Fatalf("Expected no Item at: %d", pq.Len())
+	}
+	if _, ok := pq.ItemAt(-1); ok {
+		t.Fatalf("Expected no Item at: %d", -1)
+	}
+}
+
+func TestPriorityQueue_ContainsFind(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	for value := range smallRaw {
+		if !pq.Contains(value) {
+			t.Fatalf("Expected to contain: %s", value)
+		}
+	}
+	if pq.Contains("eclair") {
+		t.Fatalf("Expected not to contain: %s", "eclair")
+	}
+	item := pq.Find(func(item *priorityqueue.Item) bool {
+		return item.Priority < 10.0
+	})
+	if item == nil || item.Value != "banana" {
+		t.Fatalf("Expected: %s Actual: %v", "banana", item)
+	}
+	pq.Remove(item)
+	if pq.Contains("banana") {
+		t.Fatalf("Expected not to contain: %s", "banana")
+	}
+	if item := pq.Find(func(item *priorityqueue.Item) bool { return item.Priority > 20.0 }); item != nil {
+		t.Fatalf("Expected no Item Actual: %v", item.Value)
+	}
+}
+
+func TestNewWithComparator(t *testing.T) {
+	pq := priorityqueue.NewWithComparator(func(a, b *priorityqueue.Item) bool {
+		return a.Value.(string) < b.Value.(string)
+	})
+	for value, priority := range smallRaw {
+		heap.Push(pq, &priorityqueue.Item{Value: value, Priority: priority})
+	}
+	expectedPopOrder := []string{"apple", "banana", "carrot", "danish"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestNewWithComparator_Stable(t *testing.T) {
+	byLength := func(a, b *priorityqueue.Item) bool {
+		return len(a.Value.(string)) > len(b.Value.(string))
+	}
+	pq := priorityqueue.NewWithComparator(byLength, priorityqueue.Stable())
+	values := []string{"fig", "apple", "kiwi", "pear", "grape", "lime", "date", "melon", "plum", "yam"}
+	for _, value := range values {
+		heap.Push(pq, &priorityqueue.Item{Value: value})
+	}
+	expectedPopOrder := []string{"apple", "grape", "melon", "kiwi", "pear", "lime", "date", "plum", "fig", "yam"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+
+	pq = priorityqueue.NewWithComparator(byLength, priorityqueue.Stable())
+	for _, value := range values {
+		heap.Push(pq, &priorityqueue.Item{Value: value})
+	}
+	pq.Reverse()
+	expectedPopOrder = []string{"fig", "yam", "kiwi", "pear", "lime", "date", "plum", "apple", "grape", "melon"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestNewMin(t *testing.T) {
+	pq := priorityqueue.NewMin()
+	for value, priority := range smallRaw {
+		heap.Push(pq, &priorityqueue.Item{Value: value, Priority: priority})
+	}
+	expectedPopOrder := []string{"danish", "banana", "apple", "carrot"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestNewFromItems(t *testing.T) {
+	items := []*priorityqueue.Item{
+		{Value: "apple", Priority: 10.0},
+		{Value: "banana", Priority: 5.0},
+		{Value: "carrot", Priority: 11.0},
+		{Value: "danish", Priority: 0.0},
+		{Value: "eclair", Priority: 5.0},
+	}
+	pq := priorityqueue.NewFromItems(items)
+	expectedPopOrder := []string{"carrot", "apple", "banana", "eclair", "danish"}
+	if pq.Len() != len(expectedPopOrder) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedPopOrder), pq.Len())
+	}
+	heap.Push(pq, &priorityqueue.Item{Value: "fig", Priority: 5.0})
+	expectedPopOrder = []string{"carrot", "apple", "banana", "eclair", "fig", "danish"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	if pq := priorityqueue.NewFromItems(nil); pq.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, pq.Len())
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	pq := priorityqueue.FromMap(smallRaw)
+	expectedPopOrder := []string{"carrot", "apple", "banana", "danish"}
+	if pq.Len() != len(expectedPopOrder) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedPopOrder), pq.Len())
+	}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_PushAll(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	pq.PushAll([]*priorityqueue.Item{
+		{Value: "eclair", Priority: 7.0},
+		{Value: "fig", Priority: 12.0},
+		{Value: "grape", Priority: -1.0},
+	}...)
+	expectedPopOrder := []string{"fig", "carrot", "apple", "eclair", "banana", "danish", "grape"}
+	if pq.Len() != len(expectedPopOrder) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedPopOrder), pq.Len())
+	}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_PushAllSmallBatch(t *testing.T) {
+	pq := &priorityqueue.PriorityQueue{}
+	for i := 0; i < 1000; i++ {
+		pq.Enqueue(&priorityqueue.Item{Value: strconv.Itoa(i), Priority: float64(i % 100)})
+	}
+	// A batch this small relative to the PriorityQueue is pushed item by item.
+	pq.PushAll(&priorityqueue.Item{Value: "eclair", Priority: 150.0}, &priorityqueue.Item{Value: "fig", Priority: 99.0})
+	if err := pq.Verify(); err != nil {
+		t.Fatalf("Expected no error Actual: %s", err)
+	}
+	if item, _ := pq.Dequeue(); item.Value != "eclair" {
+		t.Fatalf("Expected: %s Actual: %s", "eclair", item.Value)
+	}
+	for i := 0; i < 10; i++ {
+		pq.Dequeue()
+	}
+	// fig ties with the ten Items of Priority 99 pushed before it, so it follows them.
+	if item, _ := pq.Dequeue(); item.Value != "fig" {
+		t.Fatalf("Expected: %s Actual: %s", "fig", item.Value)
+	}
+}
+
+func TestPriorityQueue_Walk(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	visited := 0
+	var found *priorityqueue.Item
+	pq.Walk(func(item *priorityqueue.Item) bool {
+		visited++
+		if item.Priority > 10.0 {
+			found = item
+			return false
+		}
+		return true
+	})
+	if found == nil || found.Value != "carrot" {
+		t.Fatalf("Expected: %s Actual: %v", "carrot", found)
+	}
+	// carrot has the highest Priority, so it is the root of the heap.
+	if visited != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, visited)
+	}
+	visited = 0
+	pq.Walk(func(item *priorityqueue.Item) bool {
+		visited++
+		return true
+	})
+	if visited != len(smallRaw) {
+		t.Fatalf("Expected: %d Actual: %d", len(smallRaw), visited)
+	}
+}
+
+func TestPriorityQueue_Update(t *testing.T) {
+	pq := &priorityqueue.PriorityQueue{}
+	items := map[string]*priorityqueue.Item{}
+	for value, priority := range smallRaw {
+		items[value] = &priorityqueue.Item{Value: value, Priority: priority}
+		heap.Push(pq, items[value])
+	}
+	pq.Update(items["banana"], "banana split", 12.0)
+	pq.Update(items["carrot"], "carrot", -1.0)
+	expectedPopOrder := []string{"banana split", "apple", "danish", "carrot"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_Remove(t *testing.T) {
+	pq := &priorityqueue.PriorityQueue{}
+	items := map[string]*priorityqueue.Item{}
+	for value, priority := range smallRaw {
+		items[value] = &priorityqueue.Item{Value: value, Priority: priority}
+		heap.Push(pq, items[value])
+	}
+	if !pq.Remove(items["apple"]) {
+		t.Fatalf("Expected a removal for: %s", "apple")
+	}
+	if pq.Remove(items["apple"]) {
+		t.Fatalf("Expected no removal for: %s", "apple")
+	}
+	if pq.Remove(&priorityqueue.Item{Value: "eclair", Priority: 1.0}) {
+		t.Fatalf("Expected no removal for: %s", "eclair")
+	}
+	other := generatePriorityQueue(smallRaw)
+	if other.Remove(items["carrot"]) {
+		t.Fatalf("Expected no removal from another PriorityQueue for: %s", "carrot")
+	}
+	expectedPopOrder := []string{"carrot", "banana", "danish"}
+	if pq.Len() != len(expectedPopOrder) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedPopOrder), pq.Len())
+	}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_UpdateByValue(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	if !pq.UpdateByValue("danish", 20.0) {
+		t.Fatalf("Expected an update for: %s", "danish")
+	}
+	if !pq.UpdateByValue("carrot", 1.0) {
+		t.Fatalf("Expected an update for: %s", "carrot")
+	}
+	if pq.UpdateByValue("eclair", 1.0) {
+		t.Fatalf("Expected no update for: %s", "eclair")
+	}
+	expectedPopOrder := []string{"danish", "apple", "banana", "carrot"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	// Values that are not comparable are reported as missing rather than panicking, even when an Item holds one.
+	type holder struct{ V interface{} }
+	pq.Enqueue(&priorityqueue.Item{Value: []int{1}, Priority: 1.0})
+	pq.Enqueue(&priorityqueue.Item{Value: holder{[]int{1}}, Priority: 1.0})
+	if pq.UpdateByValue([]int{1}, 2.0) || pq.UpdateByValue(holder{[]int{1}}, 2.0) {
+		t.Fatalf("Expected no update for a value that is not comparable")
+	}
+	if pq.UpdateByValue(holder{1}, 2.0) {
+		t.Fatalf("Expected no update for: %v", holder{1})
+	}
+}
+
+func TestPriorityQueue_Reverse(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	pq.Reverse()
+	expectedPopOrder := []string{"danish", "banana", "apple", "carrot"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	pq = generatePriorityQueue(smallRaw)
+	pq.Reverse()
+	pq.Reverse()
+	expectedPopOrder = []string{"carrot", "apple", "banana", "danish"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_Verify(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	if err := pq.Verify(); err != nil {
+		t.Fatalf("Expected no error Actual: %s", err)
+	}
+	item := pq.Find(func(item *priorityqueue.Item) bool { return item.Value == "danish" })
+	item.Priority = 30.0
+	if err := pq.Verify(); !errors.Is(err, priorityqueue.ErrCorrupt) {
+		t.Fatalf("Expected: %s Actual: %v", priorityqueue.ErrCorrupt, err)
+	}
+	heap.Init(pq)
+	if err := pq.Verify(); err != nil {
+		t.Fatalf("Expected no error Actual: %s", err)
+	}
+	item.Priority = math.NaN()
+	if err := pq.Verify(); !errors.Is(err, priorityqueue.ErrCorrupt) {
+		t.Fatalf("Expected: %s Actual: %v", priorityqueue.ErrCorrupt, err)
+	}
+}
+
+// FuzzPriorityQueue applies the operations encoded in ops, two bytes each, and verifies the PriorityQueue after each.
+func FuzzPriorityQueue(f *testing.F) {
+	f.Add([]byte{0, 10, 0, 5, 0, 11, 1, 0, 2, 3, 3, 1})
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		pq := &priorityqueue.PriorityQueue{}
+		for i := 0; i+1 < len(ops); i += 2 {
+			op, arg := ops[i]%4, ops[i+1]
+			switch op {
+			case 0:
+				pq.Enqueue(&priorityqueue.Item{Value: i, Priority: float64(arg)})
+			case 1:
+				pq.Dequeue()
+			case 2:
+				if item, ok := pq.ItemAt(int(arg)); ok {
+					pq.Update(item, item.Value, float64(arg))
+				}
+			case 3:
+				if item, ok := pq.ItemAt(int(arg)); ok {
+					pq.Remove(item)
+				}
+			}
+			if err := pq.Verify(); err != nil {
+				t.Fatalf("Expected no error Actual: %s", err)
+			}
+		}
+	})
+}
+
+func TestPriorityQueue_ClearReset(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	item, _ := pq.ItemAt(0)
+	pq.Reset()
+	if !pq.IsEmpty() {
+		t.Fatalf("Expected: %d Actual: %d", 0, pq.Len())
+	}
+	if pq.LoadFactor() != 0 {
+		t.Fatalf("Expected: %f Actual: %f", 0.0, pq.LoadFactor())
+	}
+	if pq.Remove(item) {
+		t.Fatalf("Expected not to remove: %s", item.Value)
+	}
+	pq.Enqueue(&priorityqueue.Item{Value: "eclair", Priority: 1.0})
+	if pq.IsEmpty() {
+		t.Fatalf("Expected: %d Actual: %d", 1, pq.Len())
+	}
+	pq.Clear()
+	if !pq.IsEmpty() || pq.LoadFactor() != 1 {
+		t.Fatalf("Expected: %f Actual: %f", 1.0, pq.LoadFactor())
+	}
+	pq.Enqueue(&priorityqueue.Item{Value: "fig", Priority: 2.0})
+	if item, _ := pq.Dequeue(); item.Value != "fig" {
+		t.Fatalf("Expected: %s Actual: %s", "fig", item.Value)
+	}
+}
+
+func TestPriorityQueue_Truncate(t *testing.T) {
+	raw := map[string]float64{}
+	for i := 0; i < 1000; i++ {
+		raw[strconv.Itoa(i)] = float64(i)
+	}
+	pq := generatePriorityQueue(raw)
+	pq.Truncate(5)
+	if pq.Len() != 5 {
+		t.Fatalf("Expected: %d Actual: %d", 5, pq.Len())
+	}
+	if index := pq.IndexOf(strconv.Itoa(0)); index != -1 {
+		t.Fatalf("Expected: %d Actual: %d", -1, index)
+	}
+	for i := 999; i > 994; i-- {
+		expected := strconv.Itoa(i)
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	pq = generatePriorityQueue(smallRaw)
+	pq.Truncate(10)
+	if pq.Len() != len(smallRaw) {
+		t.Fatalf("Expected: %d Actual: %d", len(smallRaw), pq.Len())
+	}
+	pq.Truncate(0)
+	if pq.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, pq.Len())
+	}
+}
+
+func TestPriorityQueue_SetComparator(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	pq.SetComparator(func(a, b *priorityqueue.Item) bool {
+		return a.Priority < b.Priority
+	})
+	heap.Push(pq, &priorityqueue.Item{Value: "eclair", Priority: 7.0})
+	expectedPopOrder := []string{"danish", "banana", "eclair", "apple", "carrot"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	pq = generatePriorityQueue(smallRaw)
+	pq.SetComparator(func(a, b *priorityqueue.Item) bool {
+		return a.Value.(string) < b.Value.(string)
+	})
+	pq.SetComparator(nil)
+	expectedPopOrder = []string{"carrot", "apple", "banana", "danish"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_Histogram(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	bucketConfigs := []struct {
+		edges    []float64
+		expected []int
+	}{
+		{edges: []float64{5.0, 10.0}, expected: []int{1, 1, 2}},
+		{edges: []float64{0.0, 20.0}, expected: []int{0, 4, 0}},
+		{edges: []float64{-5.0, 1.0, 6.0, 10.5, 100.0}, expected: []int{0, 1, 1, 1, 1, 0}},
+		{edges: nil, expected: []int{4}},
+	}
+	for _, bucketConfig := range bucketConfigs {
+		actual := pq.Histogram(bucketConfig.edges)
+		if !reflect.DeepEqual(bucketConfig.expected, actual) {
+			t.Fatalf("%v: Expected: %v Actual: %v", bucketConfig.edges, bucketConfig.expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_MemoryUsage(t *testing.T) {
+	pq := &priorityqueue.PriorityQueue{}
+	previous := pq.MemoryUsage()
+	for i := 0; i < 100; i++ {
+		heap.Push(pq, &priorityqueue.Item{Value: i, Priority: float64(i)})
+		current := pq.MemoryUsage()
+		if current <= previous {
+			t.Fatalf("Expected growth after push: Previous: %d Current: %d", previous, current)
+		}
+		previous = current
+	}
+	for i := 0; i < 90; i++ {
+		heap.Pop(pq)
+	}
+	beforeShrink := pq.MemoryUsage()
+	pq.ShrinkToFit()
+	afterShrink := pq.MemoryUsage()
+	if afterShrink >= beforeShrink {
+		t.Fatalf("Expected shrinkage: Before: %d After: %d", beforeShrink, afterShrink)
+	}
+	if pq.Len() != 10 {
+		t.Fatalf("Expected: %d Actual: %d", 10, pq.Len())
+	}
+}
+
+func TestPriorityQueue_MapValues(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	pq.MapValues(func(value interface{}) interface{} {
+		return strings.ToUpper(value.(string))
+	})
+	expectedPopOrder := []string{"CARROT", "APPLE", "BANANA", "DANISH"}
+	for _, expected := range expectedPopOrder {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestPriorityQueue_Dequeue(t *testing.T) {
+	pq := &priorityqueue.PriorityQueue{}
+	for value, priority := range smallRaw {
+		pq.Enqueue(&priorityqueue.Item{Value: value, Priority: priority})
+	}
+	expectedPopOrder := []string{"carrot", "apple", "banana", "danish"}
+	for _, expected := range expectedPopOrder {
+		if actual := pq.Peek().Value; expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+		item, err := pq.Dequeue()
+		if err != nil {
+			t.Fatalf("Unexpected error dequeuing: %s", err)
+		}
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+}
+
+func TestPriorityQueue_Requeue(t *testing.T) {
+	pq := &priorityqueue.PriorityQueue{}
+	for _, value := range []string{"first", "second"} {
+		pq.Enqueue(&priorityqueue.Item{Value: value, Priority: 1.0})
+	}
+	item, _ := pq.Dequeue()
+	pq.Enqueue(&priorityqueue.Item{Value: "third", Priority: 1.0})
+	pq.Requeue(item)
+	for _, expected := range []string{"first", "second", "third"} {
+		if actual, _ := pq.Dequeue(); expected != actual.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual.Value)
+		}
+	}
+}
+
+func TestPriorityQueue_PeekPriority(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	expectedPriorities := []float64{11.0, 10.0, 5.0, 0.0}
+	for _, expected := range expectedPriorities {
+		actual, ok := pq.PeekPriority()
+		if !ok || expected != actual {
+			t.Fatalf("Expected: %f Actual: %f", expected, actual)
+		}
+		heap.Pop(pq)
+	}
+	if _, ok := pq.PeekPriority(); ok {
+		t.Fatalf("Expected no Priority for an empty PriorityQueue")
+	}
+}
+
+func TestPriorityQueue_Empty(t *testing.T) {
+	pq := &priorityqueue.PriorityQueue{}
+	if item, err := pq.Dequeue(); err != priorityqueue.ErrEmpty || item != nil {
+		t.Fatalf("Expected: %s Actual: %v", priorityqueue.ErrEmpty, err)
+	}
+	if item := pq.Peek(); item != nil {
+		t.Fatalf("Expected no Item, Actual: %v", item)
+	}
+	if pq.UpdateByValue("apple", 1.0) {
+		t.Fatalf("Expected no update for: %s", "apple")
+	}
+}
+
+func TestPriorityQueue_PopMin(t *testing.T) {
+	for _, reversed := range []bool{false, true} {
+		pq := generatePriorityQueue(smallRaw)
+		if reversed {
+			pq.Reverse()
+		}
+		if actual := pq.PeekMin().Value; actual != "danish" {
+			t.Fatalf("Expected: %s Actual: %s", "danish", actual)
+		}
+		expectedPopOrder := []string{"danish", "banana"}
+		for _, expected := range expectedPopOrder {
+			actual := pq.PopMin().Value
+			if expected != actual {
+				t.Fatalf("Expected: %s Actual: %s", expected, actual)
+			}
+		}
+		jsonBytes, err := json.Marshal(pq)
+		if err != nil {
+			t.Fatalf("Unexpected error marshaling JSON: %s", err)
+		}
+		var items []priorityqueue.Item
+		if err := json.Unmarshal(jsonBytes, &items); err != nil {
+			t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+		}
+		for _, item := range items {
+			if expected := smallRaw[item.Value.(string)]; expected != item.Priority {
+				t.Fatalf("Expected: %f Actual: %f", expected, item.Priority)
+			}
+		}
+		expectedPopOrder = []string{"apple", "carrot"}
+		for _, expected := range expectedPopOrder {
+			actual := pq.PopMin().Value
+			if expected != actual {
+				t.Fatalf("Expected: %s Actual: %s", expected, actual)
+			}
+		}
+		if item := pq.PopMin(); item != nil {
+			t.Fatalf("Expected no Item, Actual: %v", item)
+		}
+		if item := pq.PeekMin(); item != nil {
+			t.Fatalf("Expected no Item, Actual: %v", item)
+		}
+	}
+}
+
+func TestPriorityQueue_Min(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	if item, ok := pq.Min(); !ok || item.Value != "danish" {
+		t.Fatalf("Expected: %s Actual: %v", "danish", item)
+	}
+	if pq.Len() != len(smallRaw) {
+		t.Fatalf("Expected: %d Actual: %d", len(smallRaw), pq.Len())
+	}
+	pq.Reverse()
+	if item, ok := pq.Min(); !ok || item.Value != "carrot" {
+		t.Fatalf("Expected: %s Actual: %v", "carrot", item)
+	}
+	pq = &priorityqueue.PriorityQueue{}
+	if item, ok := pq.Min(); ok {
+		t.Fatalf("Expected no Item, Actual: %v", item)
+	}
+}
+
+func TestPriorityQueue_PopMany(t *testing.T) {
+	raw := map[string]float64{}
+	for i := 0; i < 1000; i++ {
+		raw[strconv.Itoa(i)] = float64(i)
+	}
+	pq := generatePriorityQueue(raw)
+	buffer := make([]*priorityqueue.Item, 0, 300)
+	expected := 999
+	for pq.Len() > 0 {
+		buffer = pq.PopMany(300, buffer[:0])
+		if cap(buffer) != 300 {
+			t.Fatalf("Expected: %d Actual: %d", 300, cap(buffer))
+		}
+		for _, item := range buffer {
+			if actual := item.Value; strconv.Itoa(expected) != actual {
+				t.Fatalf("Expected: %s Actual: %s", strconv.Itoa(expected), actual)
+			}
+			expected--
+		}
+	}
+	if expected != -1 {
+		t.Fatalf("Expected: %d Actual: %d", -1, expected)
+	}
+	if buffer = pq.PopMany(10, buffer[:0]); len(buffer) != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, len(buffer))
+	}
+}
+
+func TestPriorityQueue_PopN(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	expectedPopOrder := []string{"carrot", "apple", "banana", "danish"}
+	popped := pq.PopN(3)
+	if len(popped) != 3 {
+		t.Fatalf("Expected: %d Actual: %d", 3, len(popped))
+	}
+	popped = append(popped, pq.PopN(3)...)
+	for i, expected := range expectedPopOrder {
+		if actual := popped[i].Value; expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	if popped := pq.PopN(3); len(popped) != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, len(popped))
+	}
+}
+
+func TestPriorityQueue_Drain(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	heap.Push(pq, &priorityqueue.Item{Value: "eclair", Priority: 5.0})
+	expectedPopOrder := []string{"carrot", "apple", "banana", "eclair", "danish"}
+	drained := pq.Drain()
+	if pq.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, pq.Len())
+	}
+	if len(drained) != len(expectedPopOrder) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedPopOrder), len(drained))
+	}
+	for i, expected := range expectedPopOrder {
+		if actual := drained[i].Value; expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	if pq.Remove(drained[0]) {
+		t.Fatalf("Expected drained Items to no longer belong to the PriorityQueue")
+	}
+}
+
+func TestPriorityQueue_PopWhile(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	popped := pq.PopWhile(func(item *priorityqueue.Item) bool {
+		return item.Priority >= 10.0
+	})
+	expectedPopped := []string{"carrot", "apple"}
+	if len(popped) != len(expectedPopped) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedPopped), len(popped))
+	}
+	for i, expected := range expectedPopped {
+		if actual := popped[i].Value; expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	expectedRemaining := []string{"banana", "danish"}
+	if pq.Len() != len(expectedRemaining) {
+		t.Fatalf("Expected: %d Actual: %d", len(expectedRemaining), pq.Len())
+	}
+	for _, expected := range expectedRemaining {
+		actual := heap.Pop(pq).(*priorityqueue.Item).Value
+		if expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	if popped := pq.PopWhile(func(*priorityqueue.Item) bool { return true }); len(popped) != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, len(popped))
+	}
+}
+
+func TestPriorityQueue_LoadFactor(t *testing.T) {
+	pq := &priorityqueue.PriorityQueue{}
+	if actual := pq.LoadFactor(); actual != 1 {
+		t.Fatalf("Expected: %f Actual: %f", 1.0, actual)
+	}
+	for i := 0; i < 1000; i++ {
+		heap.Push(pq, &priorityqueue.Item{Value: i, Priority: float64(i)})
+	}
+	if actual := pq.LoadFactor(); actual <= 0.5 || actual > 1 {
+		t.Fatalf("Expected a load factor in (0.5, 1], Actual: %f", actual)
+	}
+	if pq.ShouldShrink(0.25) {
+		t.Fatalf("Expected no shrink after fill")
+	}
+	for i := 0; i < 990; i++ {
+		heap.Pop(pq)
+	}
+	if actual := pq.LoadFactor(); actual >= 0.05 {
+		t.Fatalf("Expected a load factor below 0.05, Actual: %f", actual)
+	}
+	if !pq.ShouldShrink(0.25) {
+		t.Fatalf("Expected a shrink after drain")
+	}
+	pq.ShrinkToFit()
+	if actual := pq.LoadFactor(); actual != 1 {
+		t.Fatalf("Expected: %f Actual: %f", 1.0, actual)
+	}
+	if pq.ShouldShrink(0.25) {
+		t.Fatalf("Expected no shrink after shrinking")
+	}
+}
+
 func TestMain(m *testing.M) {
 	setup()
 	code := m.Run()
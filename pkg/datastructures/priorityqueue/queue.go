@@ -0,0 +1,92 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+// Interface is implemented by the priority queues that maintain the heap invariant themselves, such as Queue, DAry and
+// Pairing, so that callers can swap implementations without code changes.  Pop returns ErrEmpty if the queue is empty,
+// and Peek returns nil.
+type Interface interface {
+	Len() int
+	Push(item *Item)
+	Pop() (*Item, error)
+	Peek() *Item
+	Update(item *Item, value interface{}, priority float64)
+	Remove(item *Item) bool
+}
+
+var (
+	_ Interface = (*Queue)(nil)
+	_ Interface = (*DAry)(nil)
+)
+
+// A Queue is a PriorityQueue that does not expose heap.Interface.  Its Push and Pop maintain the heap invariant
+// themselves, so there is no need to import container/heap, and no risk of corrupting the order by calling the raw
+// heap.Interface methods directly.  The zero value is an empty Queue that pops the highest Priority first.
+type Queue struct {
+	pq PriorityQueue
+}
+
+// NewQueue returns an empty Queue.
+func NewQueue() *Queue {
+	return &Queue{}
+}
+
+// Len returns the number of Items in the Queue.
+func (q *Queue) Len() int {
+	return q.pq.Len()
+}
+
+// Push adds item to the Queue in O(log n).
+func (q *Queue) Push(item *Item) {
+	q.pq.Enqueue(item)
+}
+
+// Pop removes and returns the highest priority Item in O(log n), or returns ErrEmpty if the Queue is empty.
+func (q *Queue) Pop() (*Item, error) {
+	return q.pq.Dequeue()
+}
+
+// Peek returns the highest priority Item without removing it in O(1), or nil if the Queue is empty.
+func (q *Queue) Peek() *Item {
+	return q.pq.Peek()
+}
+
+// Update modifies the Value and Priority of item, which must be in the Queue, in O(log n).
+func (q *Queue) Update(item *Item, value interface{}, priority float64) {
+	q.pq.Update(item, value, priority)
+}
+
+// Remove removes item from the Queue in O(log n).  It returns false if item is not in the Queue.
+func (q *Queue) Remove(item *Item) bool {
+	return q.pq.Remove(item)
+}
+
+// MarshalJSON marshals the Queue in priority order.  See PriorityQueue.MarshalJSON.
+func (q *Queue) MarshalJSON() ([]byte, error) {
+	return q.pq.MarshalJSON()
+}
+
+// MarshalBinary encodes the Queue in heap order.  See PriorityQueue.MarshalBinary.
+func (q *Queue) MarshalBinary() ([]byte, error) {
+	return q.pq.MarshalBinary()
+}
+
+// UnmarshalBinary replaces the contents of the Queue with those encoded in data.  See PriorityQueue.UnmarshalBinary.
+func (q *Queue) UnmarshalBinary(data []byte) error {
+	return q.pq.UnmarshalBinary(data)
+}
+
+// UnmarshalJSON replaces the contents of the Queue with the Items in data.  See PriorityQueue.UnmarshalJSON.
+func (q *Queue) UnmarshalJSON(data []byte) error {
+	return q.pq.UnmarshalJSON(data)
+}
@@ -0,0 +1,74 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestQueue(t *testing.T) {
+	q := priorityqueue.NewQueue()
+	items := map[string]*priorityqueue.Item{}
+	for value, priority := range smallRaw {
+		items[value] = &priorityqueue.Item{Value: value, Priority: priority}
+		q.Push(items[value])
+	}
+	if q.Len() != len(smallRaw) {
+		t.Fatalf("Expected: %d Actual: %d", len(smallRaw), q.Len())
+	}
+	q.Update(items["danish"], "danish", 20.0)
+	if !q.Remove(items["apple"]) {
+		t.Fatalf("Expected a removal for: %s", "apple")
+	}
+	expectedPopOrder := []string{"danish", "carrot", "banana"}
+	for _, expected := range expectedPopOrder {
+		if actual := q.Peek().Value; expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+		item, err := q.Pop()
+		if err != nil {
+			t.Fatalf("Unexpected error popping: %s", err)
+		}
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+	if _, err := q.Pop(); err != priorityqueue.ErrEmpty {
+		t.Fatalf("Expected: %s Actual: %v", priorityqueue.ErrEmpty, err)
+	}
+}
+
+func TestQueue_JSON(t *testing.T) {
+	var q priorityqueue.Queue
+	for value, priority := range smallRaw {
+		q.Push(&priorityqueue.Item{Value: value, Priority: priority})
+	}
+	jsonBytes, err := json.Marshal(&q)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	var unmarshaled priorityqueue.Queue
+	if err := json.Unmarshal(jsonBytes, &unmarshaled); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	expectedPopOrder := []string{"carrot", "apple", "banana", "danish"}
+	for _, expected := range expectedPopOrder {
+		item, _ := unmarshaled.Pop()
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+// ErrClosed is returned by Submit once the Scheduler is closed.
+var ErrClosed = errors.New("scheduler: closed")
+
+// A Scheduler runs submitted tasks on a fixed number of workers, highest Priority first, and tasks of equal Priority in
+// the order they were submitted.
+type Scheduler struct {
+	mu      sync.Mutex
+	pending sync.Cond // Broadcast whenever a task is submitted or the Scheduler is closed.
+	pq      priorityqueue.PriorityQueue
+	closed  bool
+	workers sync.WaitGroup
+}
+
+// A Task is a handle to a submitted task.
+type Task struct {
+	s        *Scheduler
+	item     *priorityqueue.Item // The Item holding the Task while it is pending.
+	run      func(ctx context.Context) error
+	deadline time.Time
+	expiry   *time.Timer        // Drops the task at its deadline if it is still pending; nil without a deadline.
+	cancel   context.CancelFunc // Cancels the running task; nil unless running.
+	done     chan struct{}
+	err      error
+}
+
+// An Option configures a submitted task.
+type Option func(*Task)
+
+// WithDeadline sets a deadline for a task.  A task that has not started by its deadline is dropped at the deadline, even
+// while every worker is busy, and a running task has its context cancelled at its deadline.
+func WithDeadline(deadline time.Time) Option {
+	return func(t *Task) {
+		t.deadline = deadline
+	}
+}
+
+// New returns a Scheduler running tasks on the given number of workers.  It panics if workers is less than 1.
+func New(workers int) *Scheduler {
+	if workers < 1 {
+		panic("scheduler: workers must be at least 1")
+	}
+	s := &Scheduler{}
+	s.pending.L = &s.mu
+	s.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go s.work()
+	}
+	return s
+}
+
+// Submit queues run with priority, to be called on a worker with a context that is cancelled if the task is cancelled
+// or reaches its deadline.  It returns ErrClosed if the Scheduler is closed.
+func (s *Scheduler) Submit(run func(ctx context.Context) error, priority float64, opts ...Option) (*Task, error) {
+	t := &Task{s: s, run: run, done: make(chan struct{})}
+	for _, opt := range opts {
+		opt(t)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+	t.item = &priorityqueue.Item{Value: t, Priority: priority}
+	s.pq.Enqueue(t.item)
+	if !t.deadline.IsZero() {
+		t.expiry = time.AfterFunc(time.Until(t.deadline), t.expire)
+	}
+	s.pending.Signal()
+	return t, nil
+}
+
+// expire drops the task at its deadline if it is still pending.
+func (t *Task) expire() {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+	if t.item != nil {
+		t.s.pq.Remove(t.item)
+		t.item = nil
+		t.finish(context.DeadlineExceeded)
+	}
+}
+
+// Len returns the number of pending tasks.
+func (s *Scheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.Len()
+}
+
+// Close stops the Scheduler accepting tasks, and waits for the pending and running tasks to finish.
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.pending.Broadcast()
+	s.mu.Unlock()
+	s.workers.Wait()
+}
+
+// work runs pending tasks until the Scheduler is closed and no tasks remain.
+func (s *Scheduler) work() {
+	defer s.workers.Done()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		for s.pq.Len() == 0 && !s.closed {
+			s.pending.Wait()
+		}
+		item, err := s.pq.Dequeue()
+		if err != nil {
+			return
+		}
+		t := item.Value.(*Task)
+		t.item = nil
+		if t.expiry != nil {
+			t.expiry.Stop()
+		}
+		if !t.deadline.IsZero() && !time.Now().Before(t.deadline) {
+			t.finish(context.DeadlineExceeded)
+			continue
+		}
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if t.deadline.IsZero() {
+			ctx, cancel = context.WithCancel(context.Background())
+		} else {
+			ctx, cancel = context.WithDeadline(context.Background(), t.deadline)
+		}
+		t.cancel = cancel
+		s.mu.Unlock()
+		err = t.run(ctx)
+		if err == nil {
+			err = ctx.Err()
+		}
+		cancel()
+		s.mu.Lock()
+		t.cancel = nil
+		t.finish(err)
+	}
+}
+
+// finish records the outcome of the task, which is published to Wait by closing done.  s.mu must be held.
+func (t *Task) finish(err error) {
+	t.err = err
+	close(t.done)
+}
+
+// Cancel cancels the task, removing it if it is pending, or cancelling its context if it is running.  It returns false
+// if the task has already finished.
+func (t *Task) Cancel() bool {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+	switch {
+	case t.item != nil:
+		t.s.pq.Remove(t.item)
+		t.item = nil
+		if t.expiry != nil {
+			t.expiry.Stop()
+		}
+		t.finish(context.Canceled)
+		return true
+	case t.cancel != nil:
+		t.cancel()
+		return true
+	}
+	return false
+}
+
+// SetPriority changes the priority of the task.  It returns false if the task is no longer pending.
+func (t *Task) SetPriority(priority float64) bool {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+	if t.item == nil {
+		return false
+	}
+	t.s.pq.Update(t.item, t, priority)
+	return true
+}
+
+// Done returns a channel that is closed once the task has finished, been cancelled, or been dropped at its deadline.
+func (t *Task) Done() <-chan struct{} {
+	return t.done
+}
+
+// Wait blocks until the task has finished, and returns the error it returned.  If the task was cancelled or reached
+// its deadline, the error is context.Canceled or context.DeadlineExceeded respectively.
+func (t *Task) Wait() error {
+	<-t.done
+	return t.err
+}
@@ -0,0 +1,185 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue/scheduler"
+)
+
+var smallRaw = map[string]float64{"apple": 10.0, "banana": 5.0, "carrot": 11.0, "danish": 0.0}
+
+func TestScheduler(t *testing.T) {
+	s := scheduler.New(1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocker, _ := s.Submit(func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}, 0.0)
+	<-started
+
+	var mu sync.Mutex
+	var order []string
+	tasks := map[string]*scheduler.Task{}
+	for value, priority := range smallRaw {
+		tasks[value], _ = s.Submit(func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, value)
+			return nil
+		}, priority)
+	}
+	if !tasks["danish"].SetPriority(20.0) {
+		t.Fatalf("Expected to re-prioritize: %s", "danish")
+	}
+	if !tasks["apple"].Cancel() {
+		t.Fatalf("Expected to cancel: %s", "apple")
+	}
+	if s.Len() != 3 {
+		t.Fatalf("Expected: %d Actual: %d", 3, s.Len())
+	}
+	close(release)
+	s.Close()
+
+	expectedOrder := []string{"danish", "carrot", "banana"}
+	if len(order) != len(expectedOrder) {
+		t.Fatalf("Expected: %v Actual: %v", expectedOrder, order)
+	}
+	for i, expected := range expectedOrder {
+		if expected != order[i] {
+			t.Fatalf("Expected: %s Actual: %s", expected, order[i])
+		}
+	}
+	if err := tasks["apple"].Wait(); err != context.Canceled {
+		t.Fatalf("Expected: %s Actual: %v", context.Canceled, err)
+	}
+	if err := blocker.Wait(); err != nil {
+		t.Fatalf("Expected no error Actual: %s", err)
+	}
+	if tasks["carrot"].Cancel() || tasks["carrot"].SetPriority(1.0) {
+		t.Fatalf("Expected a finished task not to change")
+	}
+	if _, err := s.Submit(func(ctx context.Context) error { return nil }, 1.0); err != scheduler.ErrClosed {
+		t.Fatalf("Expected: %s Actual: %v", scheduler.ErrClosed, err)
+	}
+}
+
+func TestScheduler_CancelRunning(t *testing.T) {
+	s := scheduler.New(1)
+	defer s.Close()
+	started := make(chan struct{})
+	task, _ := s.Submit(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}, 1.0)
+	<-started
+	if !task.Cancel() {
+		t.Fatalf("Expected to cancel a running task")
+	}
+	<-task.Done()
+	if err := task.Wait(); err != context.Canceled {
+		t.Fatalf("Expected: %s Actual: %v", context.Canceled, err)
+	}
+}
+
+func TestScheduler_Deadline(t *testing.T) {
+	s := scheduler.New(1)
+	defer s.Close()
+	var ran atomic.Bool
+	expired, _ := s.Submit(func(ctx context.Context) error {
+		ran.Store(true)
+		return nil
+	}, 1.0, scheduler.WithDeadline(time.Now().Add(-time.Second)))
+	if err := expired.Wait(); err != context.DeadlineExceeded {
+		t.Fatalf("Expected: %s Actual: %v", context.DeadlineExceeded, err)
+	}
+	if ran.Load() {
+		t.Fatalf("Expected a task past its deadline not to run")
+	}
+	overrun, _ := s.Submit(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, 1.0, scheduler.WithDeadline(time.Now().Add(10*time.Millisecond)))
+	if err := overrun.Wait(); err != context.DeadlineExceeded {
+		t.Fatalf("Expected: %s Actual: %v", context.DeadlineExceeded, err)
+	}
+	failure := errors.New("failure")
+	failed, _ := s.Submit(func(ctx context.Context) error { return failure }, 1.0)
+	if err := failed.Wait(); err != failure {
+		t.Fatalf("Expected: %s Actual: %v", failure, err)
+	}
+}
+
+func TestScheduler_DeadlineWhilePending(t *testing.T) {
+	s := scheduler.New(1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	s.Submit(func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}, 1.0)
+	<-started
+	defer func() {
+		close(release)
+		s.Close()
+	}()
+	// The only worker is busy, so the task expires while pending and must be dropped without a worker.
+	expired, _ := s.Submit(func(ctx context.Context) error { return nil }, 1.0,
+		scheduler.WithDeadline(time.Now().Add(10*time.Millisecond)))
+	if err := expired.Wait(); err != context.DeadlineExceeded {
+		t.Fatalf("Expected: %s Actual: %v", context.DeadlineExceeded, err)
+	}
+	if s.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, s.Len())
+	}
+}
+
+func TestScheduler_Workers(t *testing.T) {
+	const tasks = 1000
+	s := scheduler.New(8)
+	var count atomic.Int64
+	var wg sync.WaitGroup
+	for producer := 0; producer < 4; producer++ {
+		wg.Add(1)
+		go func(producer int) {
+			defer wg.Done()
+			for i := 0; i < tasks/4; i++ {
+				task, err := s.Submit(func(ctx context.Context) error {
+					count.Add(1)
+					return nil
+				}, float64(i))
+				if err != nil {
+					t.Errorf("Unexpected error submitting: %s", err)
+				}
+				if i%10 == 0 {
+					task.SetPriority(float64(-i))
+				}
+			}
+		}(producer)
+	}
+	wg.Wait()
+	s.Close()
+	if actual := count.Load(); actual != tasks {
+		t.Fatalf("Expected: %d Actual: %d", tasks, actual)
+	}
+}
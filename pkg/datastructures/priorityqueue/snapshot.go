@@ -0,0 +1,65 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+// A Snapshot is an opaque capture of the contents of a PriorityQueue, created by Snapshot and applied by Restore.
+// Mutating the PriorityQueue, or the Items it holds, after the Snapshot is taken does not affect the Snapshot.
+type Snapshot struct {
+	items    []Item
+	less     func(a, b *Item) bool
+	stable   bool
+	reversed bool
+	sequence uint64
+}
+
+// Snapshot captures the current contents of the PriorityQueue in O(n).
+func (pq *PriorityQueue) Snapshot() Snapshot {
+	items := make([]Item, len(pq.items))
+	for i, item := range pq.items {
+		items[i] = *item
+	}
+	return Snapshot{items: items, less: pq.less, stable: pq.stable, reversed: pq.reversed, sequence: pq.sequence}
+}
+
+// Restore resets the PriorityQueue to the contents captured by s in O(n).  The PriorityQueue holds new copies of the
+// captured Items, so Item pointers obtained before Restore no longer belong to the PriorityQueue.  A Snapshot may be
+// restored any number of times.
+func (pq *PriorityQueue) Restore(s Snapshot) {
+	for i := range pq.items {
+		pq.items[i].index = -1
+		pq.items[i] = nil
+	}
+	pq.items = pq.items[:0]
+	for i := range s.items {
+		item := s.items[i]
+		item.index = i
+		pq.items = append(pq.items, &item)
+	}
+	pq.less = s.less
+	pq.stable = s.stable
+	pq.reversed = s.reversed
+	pq.sequence = s.sequence
+}
+
+// Clone returns an independent copy of the PriorityQueue in O(n).  The copy holds new Items with the same Values,
+// Priorities and Expiries in the same heap order, and has the same ordering and value decoder, so the two may be
+// modified separately.  Values themselves are copied shallowly.
+func (pq *PriorityQueue) Clone() *PriorityQueue {
+	clone := *pq
+	clone.items = make([]*Item, len(pq.items))
+	for i, item := range pq.items {
+		copied := *item
+		clone.items[i] = &copied
+	}
+	return &clone
+}
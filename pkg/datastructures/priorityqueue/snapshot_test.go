@@ -0,0 +1,70 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"container/heap"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestPriorityQueue_Restore(t *testing.T) {
+	pq := generatePriorityQueue(smallRaw)
+	snapshot := pq.Snapshot()
+
+	heap.Push(pq, &priorityqueue.Item{Value: "eclair", Priority: 20.0})
+	heap.Pop(pq)
+	heap.Pop(pq)
+	pq.UpdateByValue("danish", 30.0)
+	heap.Push(pq, &priorityqueue.Item{Value: "fig", Priority: 1.0})
+
+	for i := 0; i < 2; i++ {
+		pq.Restore(snapshot)
+		if pq.Len() != len(smallRaw) {
+			t.Fatalf("Expected: %d Actual: %d", len(smallRaw), pq.Len())
+		}
+		expectedPopOrder := []string{"carrot", "apple", "banana", "danish"}
+		for _, expected := range expectedPopOrder {
+			item := heap.Pop(pq).(*priorityqueue.Item)
+			if expected != item.Value {
+				t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+			}
+			if smallRaw[expected] != item.Priority {
+				t.Fatalf("Expected: %f Actual: %f", smallRaw[expected], item.Priority)
+			}
+		}
+	}
+}
+
+func TestPriorityQueue_Clone(t *testing.T) {
+	pq := priorityqueue.NewMin()
+	for value, priority := range smallRaw {
+		pq.Enqueue(&priorityqueue.Item{Value: value, Priority: priority})
+	}
+	clone := pq.Clone()
+	pq.UpdateByValue("danish", 30.0)
+	pq.Dequeue()
+	clone.Enqueue(&priorityqueue.Item{Value: "eclair", Priority: 5.0})
+
+	expectedPopOrder := []string{"danish", "banana", "eclair", "apple", "carrot"}
+	for _, expected := range expectedPopOrder {
+		item, _ := clone.Dequeue()
+		if expected != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected, item.Value)
+		}
+	}
+	if pq.Len() != len(smallRaw)-1 {
+		t.Fatalf("Expected: %d Actual: %d", len(smallRaw)-1, pq.Len())
+	}
+}
@@ -0,0 +1,85 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import "sort"
+
+// A TopKTracker tracks the k highest priority Items of a stream using a min-heap of at most k Items, so each Add is
+// O(log k) and memory is O(k) however long the stream.  Of Items of equal Priority, those added first are kept.  The
+// Items added are not modified, so they may also belong to a PriorityQueue.
+type TopKTracker struct {
+	k    int
+	heap *PriorityQueue // Holds the tracked Items as Values, lowest Priority and then last added first.
+}
+
+// NewTopKTracker returns a TopKTracker of the k highest priority Items.  It panics if k is negative.
+func NewTopKTracker(k int) *TopKTracker {
+	if k < 0 {
+		panic("priorityqueue: k must not be negative")
+	}
+	// Of Items of equal Priority, the one added last is evicted first.
+	return &TopKTracker{k: k, heap: NewWithComparator(func(a, b *Item) bool {
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.sequence > b.sequence
+	})}
+}
+
+// Add offers item to the TopKTracker, which keeps it if it is among the k highest priority Items added so far.  It
+// reports whether item was kept.
+func (t *TopKTracker) Add(item *Item) bool {
+	if t.heap.Len() < t.k {
+		t.heap.Enqueue(&Item{Value: item, Priority: item.Priority})
+		return true
+	}
+	lowest := t.heap.Peek()
+	if lowest == nil || item.Priority <= lowest.Priority {
+		return false
+	}
+	t.heap.Dequeue()
+	t.heap.Enqueue(&Item{Value: item, Priority: item.Priority})
+	return true
+}
+
+// Len returns the number of Items tracked, which is at most k.
+func (t *TopKTracker) Len() int {
+	return t.heap.Len()
+}
+
+// Items returns the tracked Items in Pop order, i.e. highest Priority first, and Items of equal Priority in the order
+// they were added.  The TopKTracker is not modified.
+func (t *TopKTracker) Items() []*Item {
+	tracked := make([]*Item, len(t.heap.items))
+	copy(tracked, t.heap.items)
+	sort.Slice(tracked, func(i, j int) bool {
+		if tracked[i].Priority != tracked[j].Priority {
+			return tracked[i].Priority > tracked[j].Priority
+		}
+		return tracked[i].sequence < tracked[j].sequence
+	})
+	items := make([]*Item, len(tracked))
+	for i, entry := range tracked {
+		items[i] = entry.Value.(*Item)
+	}
+	return items
+}
+
+// TopK returns the k highest priority of items in Pop order, in O(n log k).  items is not modified.
+func TopK(k int, items []*Item) []*Item {
+	t := NewTopKTracker(k)
+	for _, item := range items {
+		t.Add(item)
+	}
+	return t.Items()
+}
@@ -0,0 +1,77 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/priorityqueue"
+)
+
+func TestTopK(t *testing.T) {
+	items := []*priorityqueue.Item{
+		{Value: "banana", Priority: 5.0},
+		{Value: "apple", Priority: 10.0},
+		{Value: "danish", Priority: 0.0},
+		{Value: "eclair", Priority: 10.0},
+		{Value: "carrot", Priority: 11.0},
+		{Value: "fig", Priority: 10.0},
+		{Value: "grape", Priority: 12.0},
+	}
+	// Of the Items of Priority 10, those added first are kept.
+	expected := []string{"grape", "carrot", "apple"}
+	top := priorityqueue.TopK(3, items)
+	if len(top) != len(expected) {
+		t.Fatalf("Expected: %d Actual: %d", len(expected), len(top))
+	}
+	for i, item := range top {
+		if expected[i] != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected[i], item.Value)
+		}
+	}
+	expected = []string{"grape", "carrot", "apple", "eclair"}
+	top = priorityqueue.TopK(4, items)
+	for i, item := range top {
+		if expected[i] != item.Value {
+			t.Fatalf("Expected: %s Actual: %s", expected[i], item.Value)
+		}
+	}
+	if top := priorityqueue.TopK(10, items); len(top) != len(items) {
+		t.Fatalf("Expected: %d Actual: %d", len(items), len(top))
+	}
+	if top := priorityqueue.TopK(0, items); len(top) != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, len(top))
+	}
+}
+
+func TestTopKTracker(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tracker := priorityqueue.NewTopKTracker(10)
+	var priorities []float64
+	for i := 0; i < 10000; i++ {
+		priority := r.Float64()
+		priorities = append(priorities, priority)
+		tracker.Add(&priorityqueue.Item{Value: i, Priority: priority})
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(priorities)))
+	if tracker.Len() != 10 {
+		t.Fatalf("Expected: %d Actual: %d", 10, tracker.Len())
+	}
+	for i, item := range tracker.Items() {
+		if priorities[i] != item.Priority {
+			t.Fatalf("Expected: %f Actual: %f", priorities[i], item.Priority)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quadtree
+
+import "iter"
+
+const (
+	defaultCapacity = 8
+	defaultMaxDepth = 16
+)
+
+// A Tree is a bucketed point quadtree over a fixed bounding Rect.  Each leaf holds up to a capacity of points, and
+// splits into four quadrants when it overflows, unless it is already at the maximum depth, which bounds the depth of a
+// Tree holding many equal points.  A Remove that leaves a node with no more points than its capacity merges the node's
+// quadrants back into a leaf.  Insert and Remove are O(depth).
+type Tree[V comparable] struct {
+	root     node[V]
+	capacity int
+	maxDepth int
+}
+
+// node is a node of the Tree, which is either a leaf holding items or has four children, one per quadrant of bounds.
+type node[V comparable] struct {
+	bounds   Rect
+	items    []item[V]
+	children *[4]node[V]
+	size     int // The number of items in the subtree rooted at the node.
+}
+
+// item is a point in the Tree and its value.
+type item[V comparable] struct {
+	point Point
+	value V
+}
+
+// An Option configures a Tree constructed by New.
+type Option func(*options)
+
+// options holds the configuration set by Options.
+type options struct {
+	capacity int
+	maxDepth int
+}
+
+// WithCapacity sets the number of points a node holds before it splits.  By default it is 8.  It panics if capacity is
+// less than 1.
+func WithCapacity(capacity int) Option {
+	if capacity < 1 {
+		panic("quadtree: capacity must be at least 1")
+	}
+	return func(o *options) {
+		o.capacity = capacity
+	}
+}
+
+// WithMaxDepth sets the depth below which nodes no longer split, however many points they hold.  The root has depth
+// 0.  By default it is 16.  It panics if depth is negative.
+func WithMaxDepth(depth int) Option {
+	if depth < 0 {
+		panic("quadtree: max depth must not be negative")
+	}
+	return func(o *options) {
+		o.maxDepth = depth
+	}
+}
+
+// New returns an empty Tree of the points within bounds.
+func New[V comparable](bounds Rect, opts ...Option) *Tree[V] {
+	o := options{capacity: defaultCapacity, maxDepth: defaultMaxDepth}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Tree[V]{root: node[V]{bounds: bounds}, capacity: o.capacity, maxDepth: o.maxDepth}
+}
+
+// Bounds returns the Rect covered by the Tree.
+func (t *Tree[V]) Bounds() Rect {
+	return t.root.bounds
+}
+
+// Len returns the number of points in the Tree.
+func (t *Tree[V]) Len() int {
+	return t.root.size
+}
+
+// Insert adds point with value to the Tree.  It returns false, leaving the Tree unchanged, if point lies outside the
+// bounds of the Tree.
+func (t *Tree[V]) Insert(point Point, value V) bool {
+	if !t.root.bounds.Contains(point) {
+		return false
+	}
+	n := &t.root
+	for depth := 0; ; depth++ {
+		n.size++
+		if n.children == nil {
+			n.items = append(n.items, item[V]{point: point, value: value})
+			if len(n.items) > t.capacity && depth < t.maxDepth {
+				n.split()
+			}
+			return true
+		}
+		n = n.child(point)
+	}
+}
+
+// Remove removes one occurrence of point with value from the Tree.  It returns false if there is none.
+func (t *Tree[V]) Remove(point Point, value V) bool {
+	if !t.root.bounds.Contains(point) {
+		return false
+	}
+	return t.root.remove(item[V]{point: point, value: value}, t.capacity)
+}
+
+// remove removes target from the subtree rooted at n, merging quadrants that no longer need to be split on the way
+// back up.  It returns false if target is not in the subtree.
+func (n *node[V]) remove(target item[V], capacity int) bool {
+	if n.children == nil {
+		for i, it := range n.items {
+			if it == target {
+				last := len(n.items) - 1
+				n.items[i], n.items[last] = n.items[last], item[V]{}
+				n.items = n.items[:last]
+				n.size--
+				return true
+			}
+		}
+		return false
+	}
+	if !n.child(target.point).remove(target, capacity) {
+		return false
+	}
+	n.size--
+	if n.size <= capacity {
+		n.merge()
+	}
+	return true
+}
+
+// split turns the leaf n into four quadrants and moves its items into them.  A quadrant that receives more items than
+// the capacity is split when the next item is inserted into it.
+func (n *node[V]) split() {
+	quadrants := n.bounds.quadrants()
+	n.children = new([4]node[V])
+	for i := range n.children {
+		n.children[i].bounds = quadrants[i]
+	}
+	for _, it := range n.items {
+		c := n.child(it.point)
+		c.items = append(c.items, it)
+		c.size++
+	}
+	n.items = nil
+}
+
+// merge turns n back into a leaf holding every item of its subtree.
+func (n *node[V]) merge() {
+	items := make([]item[V], 0, n.size)
+	n.each(func(it item[V]) bool {
+		items = append(items, it)
+		return true
+	})
+	n.items, n.children = items, nil
+}
+
+// child returns the quadrant of n holding point.  Points on the center lines belong to the north and east quadrants.
+func (n *node[V]) child(point Point) *node[V] {
+	i := 0
+	if point.X >= n.children[0].bounds.MaxX {
+		i |= 1
+	}
+	if point.Y >= n.children[0].bounds.MaxY {
+		i |= 2
+	}
+	return &n.children[i]
+}
+
+// QueryRect returns an iterator over the points within r and their values, in no particular order.  The Tree must not
+// be modified during iteration.
+func (t *Tree[V]) QueryRect(r Rect) iter.Seq2[Point, V] {
+	return func(yield func(Point, V) bool) {
+		t.root.query(r.Intersects, r.Contains, yield)
+	}
+}
+
+// QueryRadius returns an iterator over the points within radius of center, inclusive, and their values, in no
+// particular order.  The Tree must not be modified during iteration.
+func (t *Tree[V]) QueryRadius(center Point, radius float64) iter.Seq2[Point, V] {
+	r2 := radius * radius
+	return func(yield func(Point, V) bool) {
+		t.root.query(func(bounds Rect) bool {
+			return bounds.squaredDistance(center) <= r2
+		}, func(p Point) bool {
+			dx, dy := p.X-center.X, p.Y-center.Y
+			return dx*dx+dy*dy <= r2
+		}, yield)
+	}
+}
+
+// All returns an iterator over the points of the Tree and their values, in no particular order.  The Tree must not be
+// modified during iteration.
+func (t *Tree[V]) All() iter.Seq2[Point, V] {
+	return func(yield func(Point, V) bool) {
+		t.root.each(func(it item[V]) bool {
+			return yield(it.point, it.value)
+		})
+	}
+}
+
+// query yields the items of the subtree rooted at n whose points satisfy match, skipping quadrants whose bounds fail
+// overlaps.  It returns false if yield asked to stop.
+func (n *node[V]) query(overlaps func(Rect) bool, match func(Point) bool, yield func(Point, V) bool) bool {
+	if n.size == 0 || !overlaps(n.bounds) {
+		return true
+	}
+	if n.children == nil {
+		for _, it := range n.items {
+			if match(it.point) && !yield(it.point, it.value) {
+				return false
+			}
+		}
+		return true
+	}
+	for i := range n.children {
+		if !n.children[i].query(overlaps, match, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// each yields the items of the subtree rooted at n.  It returns false if yield asked to stop.
+func (n *node[V]) each(yield func(item[V]) bool) bool {
+	if n.children == nil {
+		for _, it := range n.items {
+			if !yield(it) {
+				return false
+			}
+		}
+		return true
+	}
+	for i := range n.children {
+		if !n.children[i].each(yield) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quadtree_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/quadtree"
+)
+
+func TestTree(t *testing.T) {
+	tree := quadtree.New[string](quadtree.Rect{MinX: 0, MinY: 0, MaxX: 100, MaxY: 100}, quadtree.WithCapacity(1))
+	if tree.Insert(quadtree.Point{X: 101, Y: 50}, "outside") {
+		t.Fatalf("Expected a point outside the bounds to be rejected")
+	}
+	tree.Insert(quadtree.Point{X: 10, Y: 10}, "a")
+	tree.Insert(quadtree.Point{X: 12, Y: 11}, "b")
+	tree.Insert(quadtree.Point{X: 90, Y: 90}, "c")
+	tree.Insert(quadtree.Point{X: 50, Y: 50}, "d")
+	found := map[string]bool{}
+	for _, value := range tree.QueryRect(quadtree.Rect{MinX: 0, MinY: 0, MaxX: 50, MaxY: 50}) {
+		found[value] = true
+	}
+	if len(found) != 3 || !found["a"] || !found["b"] || !found["d"] {
+		t.Fatalf("Expected: %s Actual: %v", "[a b d]", found)
+	}
+	found = map[string]bool{}
+	for _, value := range tree.QueryRadius(quadtree.Point{X: 11, Y: 11}, 1.5) {
+		found[value] = true
+	}
+	if len(found) != 2 || !found["a"] || !found["b"] {
+		t.Fatalf("Expected: %s Actual: %v", "[a b]", found)
+	}
+	if !tree.Remove(quadtree.Point{X: 12, Y: 11}, "b") || tree.Remove(quadtree.Point{X: 12, Y: 11}, "b") {
+		t.Fatalf("Expected to remove %s once", "b")
+	}
+	if tree.Len() != 3 {
+		t.Fatalf("Expected: %d Actual: %d", 3, tree.Len())
+	}
+}
+
+func TestTree_MaxDepth(t *testing.T) {
+	tree := quadtree.New[int](quadtree.Rect{MaxX: 1, MaxY: 1}, quadtree.WithCapacity(2), quadtree.WithMaxDepth(3))
+	for i := 0; i < 100; i++ {
+		tree.Insert(quadtree.Point{X: 0.5, Y: 0.5}, i)
+	}
+	count := 0
+	for range tree.QueryRadius(quadtree.Point{X: 0.5, Y: 0.5}, 0) {
+		count++
+	}
+	if count != 100 {
+		t.Fatalf("Expected: %d Actual: %d", 100, count)
+	}
+}
+
+func TestTree_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := quadtree.New[int](quadtree.Rect{MaxX: 1000, MaxY: 1000}, quadtree.WithCapacity(4))
+	reference := map[int]quadtree.Point{}
+	for i := 0; i < 3000; i++ {
+		if r.Intn(3) == 0 && len(reference) > 0 {
+			for value, point := range reference {
+				if !tree.Remove(point, value) {
+					t.Fatalf("Expected to remove %d at %v", value, point)
+				}
+				delete(reference, value)
+				break
+			}
+			continue
+		}
+		point := quadtree.Point{X: float64(r.Intn(1001)), Y: float64(r.Intn(1001))}
+		tree.Insert(point, i)
+		reference[i] = point
+	}
+	if tree.Len() != len(reference) {
+		t.Fatalf("Expected: %d Actual: %d", len(reference), tree.Len())
+	}
+	for i := 0; i < 100; i++ {
+		center := quadtree.Point{X: r.Float64() * 1000, Y: r.Float64() * 1000}
+		radius := r.Float64() * 100
+		rect := quadtree.Rect{
+			MinX: center.X - radius, MinY: center.Y - radius, MaxX: center.X + radius, MaxY: center.Y + radius,
+		}
+		expectedRect, expectedRadius := 0, 0
+		for _, point := range reference {
+			if rect.Contains(point) {
+				expectedRect++
+			}
+			if dx, dy := point.X-center.X, point.Y-center.Y; dx*dx+dy*dy <= radius*radius {
+				expectedRadius++
+			}
+		}
+		actualRect, actualRadius := 0, 0
+		for point, value := range tree.QueryRect(rect) {
+			if reference[value] != point {
+				t.Fatalf("Expected: %v Actual: %v", reference[value], point)
+			}
+			actualRect++
+		}
+		for range tree.QueryRadius(center, radius) {
+			actualRadius++
+		}
+		if actualRect != expectedRect || actualRadius != expectedRadius {
+			t.Fatalf("Expected: %d, %d Actual: %d, %d", expectedRect, expectedRadius, actualRect, actualRadius)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quadtree
+
+// A Point is a location in the plane.
+type Point struct {
+	X, Y float64
+}
+
+// A Rect is the axis-aligned rectangle of points from (MinX, MinY) to (MaxX, MaxY), inclusive.
+type Rect struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Contains reports whether p lies within r.
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.MinX && p.X <= r.MaxX && p.Y >= r.MinY && p.Y <= r.MaxY
+}
+
+// Intersects reports whether r and o share any point.
+func (r Rect) Intersects(o Rect) bool {
+	return r.MinX <= o.MaxX && o.MinX <= r.MaxX && r.MinY <= o.MaxY && o.MinY <= r.MaxY
+}
+
+// squaredDistance returns the square of the distance from p to the closest point of r, which is zero if r contains p.
+func (r Rect) squaredDistance(p Point) float64 {
+	dx := max(r.MinX-p.X, 0, p.X-r.MaxX)
+	dy := max(r.MinY-p.Y, 0, p.Y-r.MaxY)
+	return dx*dx + dy*dy
+}
+
+// quadrants splits r at its center into its south-west, south-east, north-west and north-east quarters.
+func (r Rect) quadrants() [4]Rect {
+	midX, midY := r.MinX+(r.MaxX-r.MinX)/2, r.MinY+(r.MaxY-r.MinY)/2
+	return [4]Rect{
+		{r.MinX, r.MinY, midX, midY},
+		{midX, r.MinY, r.MaxX, midY},
+		{r.MinX, midY, midX, r.MaxY},
+		{midX, midY, r.MaxX, r.MaxY},
+	}
+}
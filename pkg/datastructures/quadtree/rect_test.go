@@ -0,0 +1,32 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quadtree_test
+
+import (
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/quadtree"
+)
+
+func TestRect(t *testing.T) {
+	r := quadtree.Rect{MinX: 0, MinY: 0, MaxX: 10, MaxY: 5}
+	if !r.Contains(quadtree.Point{X: 10, Y: 5}) || r.Contains(quadtree.Point{X: 10.5, Y: 5}) {
+		t.Fatalf("Expected %v to contain its corners and nothing beyond them", r)
+	}
+	if !r.Intersects(quadtree.Rect{MinX: 10, MinY: 5, MaxX: 12, MaxY: 7}) {
+		t.Fatalf("Expected %v to intersect a Rect touching its corner", r)
+	}
+	if r.Intersects(quadtree.Rect{MinX: 3, MinY: 6, MaxX: 4, MaxY: 7}) {
+		t.Fatalf("Expected %v not to intersect a Rect above it", r)
+	}
+}
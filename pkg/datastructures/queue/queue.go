@@ -0,0 +1,111 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import "encoding/json"
+
+// minCapacity is the capacity of the ring buffer of a Queue once it first grows.
+const minCapacity = 8
+
+// A Queue is a first-in, first-out collection of values.  It is backed by a ring buffer, so Dequeue neither shifts the
+// remaining values nor retains the dequeued one, and Enqueue is amortized O(1).  The zero value is an empty Queue ready
+// to use.
+type Queue[T any] struct {
+	buffer []T
+	head   int // The index in buffer of the front of the Queue.
+	n      int // The number of values in the Queue.
+}
+
+// New returns an empty Queue.
+func New[T any]() *Queue[T] {
+	return &Queue[T]{}
+}
+
+// Len returns the number of values in the Queue.
+func (q *Queue[T]) Len() int {
+	return q.n
+}
+
+// IsEmpty reports whether the Queue holds no values.
+func (q *Queue[T]) IsEmpty() bool {
+	return q.n == 0
+}
+
+// Enqueue adds value to the back of the Queue, doubling the ring buffer if it is full.
+func (q *Queue[T]) Enqueue(value T) {
+	if q.n == len(q.buffer) {
+		q.grow()
+	}
+	q.buffer[(q.head+q.n)%len(q.buffer)] = value
+	q.n++
+}
+
+// Dequeue removes and returns the value at the front of the Queue.  The second return value is false if the Queue is
+// empty.
+func (q *Queue[T]) Dequeue() (T, bool) {
+	var zero T
+	if q.n == 0 {
+		return zero, false
+	}
+	value := q.buffer[q.head]
+	q.buffer[q.head] = zero // avoid memory leak
+	q.head = (q.head + 1) % len(q.buffer)
+	q.n--
+	return value, true
+}
+
+// Peek returns the value at the front of the Queue without removing it.  The second return value is false if the Queue
+// is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	if q.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.buffer[q.head], true
+}
+
+// MarshalJSON marshals the Queue as an array in Dequeue order.  The Queue is not modified.
+func (q *Queue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.values(q.n))
+}
+
+// UnmarshalJSON replaces the contents of the Queue with the values in data, which must be an array in the Dequeue order
+// produced by MarshalJSON.  The Queue is left unchanged if data cannot be decoded.
+func (q *Queue[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*q = Queue[T]{buffer: values, n: len(values)}
+	return nil
+}
+
+// grow doubles the capacity of the ring buffer, moving the values to the start of the new buffer.
+func (q *Queue[T]) grow() {
+	capacity := 2 * len(q.buffer)
+	if capacity < minCapacity {
+		capacity = minCapacity
+	}
+	q.buffer = q.values(capacity)
+	q.head = 0
+}
+
+// values returns the values of the Queue in Dequeue order in a new slice of length n.
+func (q *Queue[T]) values(n int) []T {
+	values := make([]T, n)
+	if q.n > 0 {
+		copied := copy(values, q.buffer[q.head:min(q.head+q.n, len(q.buffer))])
+		copy(values[copied:q.n], q.buffer)
+	}
+	return values
+}
@@ -0,0 +1,93 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/queue"
+)
+
+func TestQueue(t *testing.T) {
+	q := queue.New[string]()
+	if _, ok := q.Dequeue(); ok {
+		t.Fatalf("Expected an empty Queue")
+	}
+	if _, ok := q.Peek(); ok {
+		t.Fatalf("Expected an empty Queue")
+	}
+	for _, value := range []string{"apple", "banana", "carrot", "danish"} {
+		q.Enqueue(value)
+	}
+	if q.Len() != 4 || q.IsEmpty() {
+		t.Fatalf("Expected: %d Actual: %d", 4, q.Len())
+	}
+	if value, _ := q.Peek(); value != "apple" {
+		t.Fatalf("Expected: %s Actual: %s", "apple", value)
+	}
+	expectedDequeueOrder := []string{"apple", "banana", "carrot", "danish"}
+	for _, expected := range expectedDequeueOrder {
+		if actual, ok := q.Dequeue(); !ok || expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Fatalf("Expected: %d Actual: %d", 0, q.Len())
+	}
+}
+
+// TestQueue_Random interleaves Enqueues and Dequeues so that the ring buffer wraps around and grows while wrapped.
+func TestQueue_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var q queue.Queue[int]
+	var reference []int
+	for i := 0; i < 10000; i++ {
+		if r.Intn(3) > 0 {
+			q.Enqueue(i)
+			reference = append(reference, i)
+			continue
+		}
+		actual, ok := q.Dequeue()
+		if len(reference) == 0 {
+			if ok {
+				t.Fatalf("Expected an empty Queue Actual: %d", actual)
+			}
+			continue
+		}
+		if expected := reference[0]; !ok || expected != actual {
+			t.Fatalf("Expected: %d Actual: %d", expected, actual)
+		}
+		reference = reference[1:]
+	}
+	if q.Len() != len(reference) {
+		t.Fatalf("Expected: %d Actual: %d", len(reference), q.Len())
+	}
+	jsonBytes, err := json.Marshal(&q)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	var restored queue.Queue[int]
+	if err := json.Unmarshal(jsonBytes, &restored); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	for _, expected := range reference {
+		if actual, _ := restored.Dequeue(); expected != actual {
+			t.Fatalf("Expected: %d Actual: %d", expected, actual)
+		}
+		if actual, _ := q.Dequeue(); expected != actual {
+			t.Fatalf("Expected: %d Actual: %d", expected, actual)
+		}
+	}
+}
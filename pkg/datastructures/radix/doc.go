@@ -0,0 +1,20 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package radix implements a generic radix Tree, also called a Patricia tree, that maps string keys to values.  It is a
+trie whose chains of single-child nodes are compressed into one edge labeled with a whole substring, so it uses memory
+in proportion to the number of keys rather than their total length.  LongestPrefixMatch finds the longest key that is a
+prefix of a given string, the lookup at the heart of IP routing tables and HTTP routers.
+*/
+
+package radix
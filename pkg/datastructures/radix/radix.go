@@ -0,0 +1,251 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package radix
+
+import (
+	"iter"
+	"sort"
+	"strings"
+)
+
+// A Tree is a radix tree mapping string keys to values.  Every node other than the root either holds a key or has at
+// least two children.  The zero value is an empty Tree ready to use.
+type Tree[V any] struct {
+	root node[V]
+	size int
+}
+
+// node is a node of the Tree.  The key of a node is the concatenation of the prefixes on the path from the root.
+type node[V any] struct {
+	prefix string     // The label of the edge from the parent, which is empty only for the root.
+	edges  []*node[V] // Sorted by the first byte of their prefix, which is distinct among siblings.
+	value  V
+	leaf   bool // Whether a key ends at the node.
+}
+
+// New returns an empty Tree.
+func New[V any]() *Tree[V] {
+	return &Tree[V]{}
+}
+
+// Len returns the number of keys in the Tree.
+func (t *Tree[V]) Len() int {
+	return t.size
+}
+
+// Insert sets the value of key, adding key if it is not already in the Tree.  It returns true if key was added.
+func (t *Tree[V]) Insert(key string, value V) bool {
+	n, search := &t.root, key
+	for search != "" {
+		i, ok := n.find(search[0])
+		if !ok {
+			n.insertAt(i, &node[V]{prefix: search})
+			n = n.edges[i]
+			break
+		}
+		child := n.edges[i]
+		common := commonPrefix(search, child.prefix)
+		if common < len(child.prefix) {
+			// Split the edge where key diverges from it.
+			split := &node[V]{prefix: child.prefix[:common], edges: []*node[V]{child}}
+			child.prefix = child.prefix[common:]
+			n.edges[i] = split
+			child = split
+		}
+		n, search = child, search[common:]
+	}
+	added := !n.leaf
+	n.value, n.leaf = value, true
+	if added {
+		t.size++
+	}
+	return added
+}
+
+// Get returns the value of key.  The second return value is false if key is not in the Tree.
+func (t *Tree[V]) Get(key string) (V, bool) {
+	n, search := &t.root, key
+	for search != "" {
+		i, ok := n.find(search[0])
+		if !ok || !strings.HasPrefix(search, n.edges[i].prefix) {
+			var zero V
+			return zero, false
+		}
+		n, search = n.edges[i], search[len(n.edges[i].prefix):]
+	}
+	return n.value, n.leaf
+}
+
+// Delete removes key from the Tree, merging any node left with a single child into that child.  It returns false if key
+// was not in the Tree.
+func (t *Tree[V]) Delete(key string) bool {
+	if key == "" {
+		if !t.root.leaf {
+			return false
+		}
+		t.root.clear()
+	} else if !t.root.delete(key) {
+		return false
+	}
+	t.size--
+	return true
+}
+
+// LongestPrefixMatch returns the longest key in the Tree that is a prefix of s, and its value.  The third return value
+// is false if no key is a prefix of s.
+func (t *Tree[V]) LongestPrefixMatch(s string) (string, V, bool) {
+	var match *node[V]
+	var length int
+	n, search := &t.root, s
+	for {
+		if n.leaf {
+			match, length = n, len(s)-len(search)
+		}
+		if search == "" {
+			break
+		}
+		i, ok := n.find(search[0])
+		if !ok || !strings.HasPrefix(search, n.edges[i].prefix) {
+			break
+		}
+		n, search = n.edges[i], search[len(n.edges[i].prefix):]
+	}
+	if match == nil {
+		var zero V
+		return "", zero, false
+	}
+	return s[:length], match.value, true
+}
+
+// HasPrefix reports whether any key in the Tree starts with prefix.
+func (t *Tree[V]) HasPrefix(prefix string) bool {
+	n, _ := t.seek(prefix)
+	return n != nil
+}
+
+// WalkPrefix returns an iterator over the keys of the Tree that start with prefix, and their values, in lexicographic
+// order of key.  The Tree must not be modified during iteration.
+func (t *Tree[V]) WalkPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		if n, path := t.seek(prefix); n != nil {
+			n.each([]byte(path), yield)
+		}
+	}
+}
+
+// All returns an iterator over the keys and values of the Tree in lexicographic order of key.  The Tree must not be
+// modified during iteration.
+func (t *Tree[V]) All() iter.Seq2[string, V] {
+	return t.WalkPrefix("")
+}
+
+// seek returns the highest node whose key starts with prefix, along with that key, or nil if no key starts with prefix.
+func (t *Tree[V]) seek(prefix string) (*node[V], string) {
+	n, path, search := &t.root, "", prefix
+	for search != "" {
+		i, ok := n.find(search[0])
+		if !ok {
+			return nil, ""
+		}
+		child := n.edges[i]
+		switch {
+		case strings.HasPrefix(search, child.prefix):
+			search = search[len(child.prefix):]
+		case strings.HasPrefix(child.prefix, search):
+			// prefix ends part way along the edge, so every key below child starts with it.
+			search = ""
+		default:
+			return nil, ""
+		}
+		n, path = child, path+child.prefix
+	}
+	if n == &t.root && !n.leaf && len(n.edges) == 0 {
+		return nil, ""
+	}
+	return n, path
+}
+
+// find returns the index of the child of n whose prefix starts with b and true, or the index at which to insert such a
+// child and false.
+func (n *node[V]) find(b byte) (int, bool) {
+	i := sort.Search(len(n.edges), func(i int) bool { return n.edges[i].prefix[0] >= b })
+	return i, i < len(n.edges) && n.edges[i].prefix[0] == b
+}
+
+// insertAt inserts child as the child of n at index i.
+func (n *node[V]) insertAt(i int, child *node[V]) {
+	n.edges = append(n.edges, nil)
+	copy(n.edges[i+1:], n.edges[i:])
+	n.edges[i] = child
+}
+
+// delete removes search, which is a key relative to n and not empty, from the subtree rooted at n.  It returns false if
+// the key was not in the subtree.
+func (n *node[V]) delete(search string) bool {
+	i, ok := n.find(search[0])
+	if !ok || !strings.HasPrefix(search, n.edges[i].prefix) {
+		return false
+	}
+	child := n.edges[i]
+	if rest := search[len(child.prefix):]; rest == "" {
+		if !child.leaf {
+			return false
+		}
+		child.clear()
+	} else if !child.delete(rest) {
+		return false
+	}
+	if child.leaf {
+		return true
+	}
+	switch len(child.edges) {
+	case 0:
+		copy(n.edges[i:], n.edges[i+1:])
+		n.edges[len(n.edges)-1] = nil // avoid memory leak
+		n.edges = n.edges[:len(n.edges)-1]
+	case 1:
+		grandchild := child.edges[0]
+		grandchild.prefix = child.prefix + grandchild.prefix
+		n.edges[i] = grandchild
+	}
+	return true
+}
+
+// clear removes the key held by n.
+func (n *node[V]) clear() {
+	var zero V
+	n.value, n.leaf = zero, false // avoid memory leak
+}
+
+// each yields the keys of the subtree rooted at n, whose key is path, in lexicographic order.  It returns false if yield
+// asked to stop.
+func (n *node[V]) each(path []byte, yield func(string, V) bool) bool {
+	if n.leaf && !yield(string(path), n.value) {
+		return false
+	}
+	for _, child := range n.edges {
+		if !child.each(append(path, child.prefix...), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// commonPrefix returns the length of the longest common prefix of a and b.
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,139 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package radix_test
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/radix"
+)
+
+// keys collects the keys of seq.
+func keys(seq func(func(string, int) bool)) []string {
+	var keys []string
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestTree(t *testing.T) {
+	var tree radix.Tree[int]
+	for i, key := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"} {
+		if !tree.Insert(key, i) {
+			t.Fatalf("Expected to add %s", key)
+		}
+	}
+	if tree.Insert("ruber", 100) || tree.Len() != 7 {
+		t.Fatalf("Expected Insert of an existing key to replace its value")
+	}
+	if value, ok := tree.Get("ruber"); !ok || value != 100 {
+		t.Fatalf("Expected: %d Actual: %d", 100, value)
+	}
+	if _, ok := tree.Get("rub"); ok {
+		t.Fatalf("Expected a prefix alone not to be a key")
+	}
+	if !tree.HasPrefix("rubi") || !tree.HasPrefix("rom") || tree.HasPrefix("rox") {
+		t.Fatalf("Unexpected HasPrefix")
+	}
+	walks := map[string][]string{
+		"rubi": {"rubicon", "rubicundus"},
+		"roma": {"romane", "romanus"},
+		"rx":   nil,
+	}
+	for prefix, expected := range walks {
+		if actual := keys(tree.WalkPrefix(prefix)); !slices.Equal(expected, actual) {
+			t.Fatalf("Expected: %v Actual: %v", expected, actual)
+		}
+	}
+	if !tree.Delete("rubens") || tree.Delete("rubens") || tree.Delete("rub") {
+		t.Fatalf("Expected to delete %s once", "rubens")
+	}
+	expected := []string{"romane", "romanus", "romulus", "ruber", "rubicon", "rubicundus"}
+	if actual := keys(tree.All()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
+
+func TestTree_LongestPrefixMatch(t *testing.T) {
+	routes := radix.New[int]()
+	routes.Insert("/", 1)
+	routes.Insert("/api/", 2)
+	routes.Insert("/api/v1/", 3)
+	routes.Insert("/assets/", 4)
+	cases := []struct {
+		path, match string
+		value       int
+	}{
+		{"/api/v1/users", "/api/v1/", 3},
+		{"/api/v2/users", "/api/", 2},
+		{"/api", "/", 1},
+		{"/assets/logo.png", "/assets/", 4},
+		{"/", "/", 1},
+	}
+	for _, c := range cases {
+		match, value, ok := routes.LongestPrefixMatch(c.path)
+		if !ok || match != c.match || value != c.value {
+			t.Fatalf("Expected: %s Actual: %s", c.match, match)
+		}
+	}
+	if _, _, ok := routes.LongestPrefixMatch("api"); ok {
+		t.Fatalf("Expected no match for %s", "api")
+	}
+	routes.Insert("", 0)
+	if match, _, ok := routes.LongestPrefixMatch("api"); !ok || match != "" {
+		t.Fatalf("Expected the empty key to match %s", "api")
+	}
+}
+
+func TestTree_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := radix.New[int]()
+	reference := map[string]int{}
+	for i := 0; i < 20000; i++ {
+		var b strings.Builder
+		for j := r.Intn(6); j > 0; j-- {
+			b.WriteByte("abc"[r.Intn(3)])
+		}
+		key := b.String()
+		if r.Intn(2) == 0 {
+			_, ok := reference[key]
+			if tree.Delete(key) != ok {
+				t.Fatalf("Delete(%q) disagreed with the reference", key)
+			}
+			delete(reference, key)
+		} else {
+			tree.Insert(key, i)
+			reference[key] = i
+		}
+	}
+	var expected []string
+	for key := range reference {
+		expected = append(expected, key)
+	}
+	sort.Strings(expected)
+	var actual []string
+	for key, value := range tree.All() {
+		if reference[key] != value {
+			t.Fatalf("Expected: %d Actual: %d", reference[key], value)
+		}
+		actual = append(actual, key)
+	}
+	if !slices.Equal(expected, actual) || tree.Len() != len(expected) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
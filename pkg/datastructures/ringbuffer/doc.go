@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package ringbuffer implements a generic fixed-capacity RingBuffer, which either overwrites its oldest values or rejects
+new ones when full, so that it serves as a bounded buffer of recent logs or metrics.  A RingBuffer of bytes is an
+io.ReadWriter, and NewWriter adapts a RingBuffer of byte slices into an io.Writer that keeps the most recent writes.
+*/
+
+package ringbuffer
@@ -0,0 +1,191 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ringbuffer
+
+import (
+	"errors"
+	"io"
+	"iter"
+)
+
+// ErrFull is returned when a value is written to a full RingBuffer that does not overwrite.
+var ErrFull = errors.New("ringbuffer: full")
+
+// A RingBuffer is a first-in first-out queue of at most a fixed number of values, stored in a circular buffer allocated
+// once.  When it is full, writing a value either fails with ErrFull, by default, or overwrites the oldest value, if the
+// RingBuffer was constructed WithOverwrite.  Values are written and read singly or in batches in O(1) per value.
+type RingBuffer[T any] struct {
+	buffer    []T
+	head      int // The index in buffer of the oldest value.
+	n         int // The number of values in the RingBuffer.
+	overwrite bool
+}
+
+// An Option configures a RingBuffer constructed by New.
+type Option func(*options)
+
+// options holds the configuration set by Options.
+type options struct {
+	overwrite bool
+}
+
+// WithOverwrite makes a full RingBuffer overwrite its oldest values with new ones rather than reject them.
+func WithOverwrite() Option {
+	return func(o *options) {
+		o.overwrite = true
+	}
+}
+
+// New returns an empty RingBuffer holding at most capacity values.  It panics if capacity is less than 1.
+func New[T any](capacity int, opts ...Option) *RingBuffer[T] {
+	if capacity < 1 {
+		panic("ringbuffer: capacity must be at least 1")
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &RingBuffer[T]{buffer: make([]T, capacity), overwrite: o.overwrite}
+}
+
+// Len returns the number of values in the RingBuffer.
+func (r *RingBuffer[T]) Len() int {
+	return r.n
+}
+
+// Cap returns the greatest number of values the RingBuffer holds.
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.buffer)
+}
+
+// Full reports whether the RingBuffer holds as many values as it can.
+func (r *RingBuffer[T]) Full() bool {
+	return r.n == len(r.buffer)
+}
+
+// Push adds value after the newest value.  If the RingBuffer is full, it overwrites the oldest value if the RingBuffer
+// was constructed WithOverwrite, and otherwise returns ErrFull.
+func (r *RingBuffer[T]) Push(value T) error {
+	if r.n == len(r.buffer) {
+		if !r.overwrite {
+			return ErrFull
+		}
+		r.buffer[r.head] = value
+		r.head = r.index(1)
+		return nil
+	}
+	r.buffer[r.index(r.n)] = value
+	r.n++
+	return nil
+}
+
+// Pop removes and returns the oldest value.  The second return value is false if the RingBuffer is empty.
+func (r *RingBuffer[T]) Pop() (T, bool) {
+	var zero T
+	if r.n == 0 {
+		return zero, false
+	}
+	value := r.buffer[r.head]
+	r.buffer[r.head] = zero // avoid memory leak
+	r.head = r.index(1)
+	r.n--
+	return value, true
+}
+
+// Peek returns the oldest value without removing it.  The second return value is false if the RingBuffer is empty.
+func (r *RingBuffer[T]) Peek() (T, bool) {
+	return r.At(0)
+}
+
+// At returns the value at position i, counting from zero at the oldest value.  The second return value is false if i
+// is out of range.
+func (r *RingBuffer[T]) At(i int) (T, bool) {
+	if i < 0 || i >= r.n {
+		var zero T
+		return zero, false
+	}
+	return r.buffer[r.index(i)], true
+}
+
+// Write adds values after the newest value, and returns the number added.  If they do not all fit, a RingBuffer
+// constructed WithOverwrite overwrites its oldest values, keeping the last of values if there are more than its
+// capacity, and otherwise adds as many as fit and returns ErrFull.  A RingBuffer of bytes is therefore an io.Writer.
+func (r *RingBuffer[T]) Write(values []T) (int, error) {
+	written := len(values)
+	if free := len(r.buffer) - r.n; written > free {
+		if !r.overwrite {
+			r.write(values[:free])
+			return free, ErrFull
+		}
+		if len(values) > len(r.buffer) {
+			values = values[len(values)-len(r.buffer):]
+		}
+		r.discard(len(values) - free)
+	}
+	r.write(values)
+	return written, nil
+}
+
+// write adds values, which fit, after the newest value.
+func (r *RingBuffer[T]) write(values []T) {
+	tail := r.index(r.n)
+	copied := copy(r.buffer[tail:], values)
+	copy(r.buffer, values[copied:])
+	r.n += len(values)
+}
+
+// Read removes up to len(values) of the oldest values into values, and returns the number removed.  If the RingBuffer
+// is empty and values is not, it returns io.EOF, so a RingBuffer of bytes is an io.Reader.
+func (r *RingBuffer[T]) Read(values []T) (int, error) {
+	if r.n == 0 && len(values) > 0 {
+		return 0, io.EOF
+	}
+	n := min(len(values), r.n)
+	copied := copy(values[:n], r.buffer[r.head:])
+	copy(values[copied:n], r.buffer)
+	r.discard(n)
+	return n, nil
+}
+
+// discard removes the n oldest values.
+func (r *RingBuffer[T]) discard(n int) {
+	var zero T
+	for i := 0; i < n; i++ {
+		r.buffer[r.index(i)] = zero // avoid memory leak
+	}
+	r.head = r.index(n)
+	r.n -= n
+}
+
+// Clear removes every value from the RingBuffer.
+func (r *RingBuffer[T]) Clear() {
+	clear(r.buffer)
+	r.head, r.n = 0, 0
+}
+
+// All returns an iterator over the values of the RingBuffer from oldest to newest.  The RingBuffer must not be
+// modified during the iteration.
+func (r *RingBuffer[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < r.n; i++ {
+			if !yield(r.buffer[r.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// index returns the index in buffer of position i.
+func (r *RingBuffer[T]) index(i int) int {
+	return (r.head + i) % len(r.buffer)
+}
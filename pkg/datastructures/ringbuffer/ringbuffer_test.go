@@ -0,0 +1,104 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ringbuffer_test
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/ringbuffer"
+)
+
+// A RingBuffer of bytes is an io.ReadWriter.
+var _ io.ReadWriter = (*ringbuffer.RingBuffer[byte])(nil)
+
+func TestRingBuffer_Reject(t *testing.T) {
+	r := ringbuffer.New[int](3)
+	for i := 1; i <= 3; i++ {
+		if err := r.Push(i); err != nil {
+			t.Fatalf("Expected: %v Actual: %v", nil, err)
+		}
+	}
+	if err := r.Push(4); !errors.Is(err, ringbuffer.ErrFull) || !r.Full() {
+		t.Fatalf("Expected: %v Actual: %v", ringbuffer.ErrFull, err)
+	}
+	if value, ok := r.Pop(); !ok || value != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, value)
+	}
+	if n, err := r.Write([]int{5, 6}); n != 1 || !errors.Is(err, ringbuffer.ErrFull) {
+		t.Fatalf("Expected: %d, %v Actual: %d, %v", 1, ringbuffer.ErrFull, n, err)
+	}
+	if expected, actual := []int{2, 3, 5}, slices.Collect(r.All()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
+
+func TestRingBuffer_Overwrite(t *testing.T) {
+	r := ringbuffer.New[int](3, ringbuffer.WithOverwrite())
+	for i := 1; i <= 4; i++ {
+		if err := r.Push(i); err != nil {
+			t.Fatalf("Expected: %v Actual: %v", nil, err)
+		}
+	}
+	if expected, actual := []int{2, 3, 4}, slices.Collect(r.All()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if n, err := r.Write([]int{5, 6, 7, 8, 9}); n != 5 || err != nil {
+		t.Fatalf("Expected: %d, %v Actual: %d, %v", 5, nil, n, err)
+	}
+	values := make([]int, 5)
+	if n, _ := r.Read(values); n != 3 || !slices.Equal(values[:n], []int{7, 8, 9}) {
+		t.Fatalf("Expected: %v Actual: %v", []int{7, 8, 9}, values[:n])
+	}
+	if _, err := r.Read(values); err != io.EOF {
+		t.Fatalf("Expected: %v Actual: %v", io.EOF, err)
+	}
+}
+
+func TestRingBuffer_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, overwrite := range []bool{false, true} {
+		var opts []ringbuffer.Option
+		if overwrite {
+			opts = append(opts, ringbuffer.WithOverwrite())
+		}
+		r := ringbuffer.New[int](7, opts...)
+		var reference []int
+		next := 0
+		for i := 0; i < 2000; i++ {
+			if rnd.Intn(2) == 0 {
+				values := make([]int, rnd.Intn(10))
+				for j := range values {
+					values[j] = next
+					next++
+				}
+				n, _ := r.Write(values)
+				reference = append(reference, values[:n]...)
+				reference = reference[max(0, len(reference)-r.Cap()):]
+			} else {
+				values := make([]int, rnd.Intn(10))
+				n, _ := r.Read(values)
+				if !slices.Equal(values[:n], reference[:n]) {
+					t.Fatalf("Expected: %v Actual: %v", reference[:n], values[:n])
+				}
+				reference = reference[n:]
+			}
+			if actual := slices.Collect(r.All()); !slices.Equal(reference, actual) {
+				t.Fatalf("Expected: %v Actual: %v", reference, actual)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ringbuffer
+
+import (
+	"io"
+	"slices"
+)
+
+// writer is the io.Writer returned by NewWriter.
+type writer struct {
+	r *RingBuffer[[]byte]
+}
+
+// NewWriter returns an io.Writer that pushes a copy of the bytes of each Write onto r as a single value, so that a
+// logger writing each message in one call keeps its most recent messages in r when r was constructed WithOverwrite.  A
+// Write to a full RingBuffer that does not overwrite returns ErrFull without writing anything.
+func NewWriter(r *RingBuffer[[]byte]) io.Writer {
+	return writer{r: r}
+}
+
+// Write implements io.Writer.
+func (w writer) Write(p []byte) (int, error) {
+	if err := w.r.Push(slices.Clone(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,42 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ringbuffer_test
+
+import (
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/ringbuffer"
+)
+
+func TestNewWriter(t *testing.T) {
+	r := ringbuffer.New[[]byte](2, ringbuffer.WithOverwrite())
+	logger := log.New(ringbuffer.NewWriter(r), "", 0)
+	logger.Print("one")
+	logger.Print("two")
+	logger.Print("three")
+	var messages []string
+	for message := range r.All() {
+		messages = append(messages, string(message))
+	}
+	if len(messages) != 2 || messages[0] != "two\n" || messages[1] != "three\n" {
+		t.Fatalf("Expected: %q Actual: %q", []string{"two\n", "three\n"}, messages)
+	}
+
+	w := ringbuffer.NewWriter(ringbuffer.New[[]byte](1))
+	w.Write([]byte("kept"))
+	if n, err := w.Write([]byte("rejected")); n != 0 || !errors.Is(err, ringbuffer.ErrFull) {
+		t.Fatalf("Expected: %d, %v Actual: %d, %v", 0, ringbuffer.ErrFull, n, err)
+	}
+}
@@ -0,0 +1,167 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtree
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Insert adds the rectangle r with value to the Tree.
+func (t *Tree[V]) Insert(r Rect, value V) {
+	t.insert(entry[V]{rect: r, value: value}, 0, map[int]bool{})
+	t.size++
+}
+
+// insert adds e to a node at level, which is at most the level of the root, chosen by the R*-tree heuristics.  A node
+// that overflows first has its entries furthest from its center removed and inserted again, which often finds them a
+// better place than a split would, and is split only if that has already happened at its level during this Insert,
+// recorded in reinserted.
+func (t *Tree[V]) insert(e entry[V], level int, reinserted map[int]bool) {
+	path := []*node[V]{t.root}
+	var indexes []int // The index in path[i] of the entry for path[i+1].
+	for n := t.root; n.level > level; {
+		i := n.chooseSubtree(e.rect)
+		indexes = append(indexes, i)
+		n = n.entries[i].child
+		path = append(path, n)
+	}
+	leaf := path[len(path)-1]
+	leaf.entries = append(leaf.entries, e)
+	for depth := len(path) - 1; depth >= 0; depth-- {
+		n := path[depth]
+		if len(n.entries) > t.maxEntries {
+			if depth > 0 && !reinserted[n.level] {
+				reinserted[n.level] = true
+				removed := t.takeFarthest(n)
+				for ; depth > 0; depth-- {
+					path[depth-1].entries[indexes[depth-1]].rect = bounds(path[depth].entries)
+				}
+				for _, r := range removed {
+					t.insert(r, n.level, reinserted)
+				}
+				return
+			}
+			sibling := t.split(n)
+			if depth == 0 {
+				t.root = &node[V]{level: n.level + 1, entries: []entry[V]{
+					{rect: bounds(n.entries), child: n},
+					{rect: bounds(sibling.entries), child: sibling},
+				}}
+				return
+			}
+			parent := path[depth-1]
+			parent.entries = append(parent.entries, entry[V]{rect: bounds(sibling.entries), child: sibling})
+		}
+		if depth > 0 {
+			path[depth-1].entries[indexes[depth-1]].rect = bounds(n.entries)
+		}
+	}
+}
+
+// chooseSubtree returns the index of the entry of n, which is above the leaves, to insert r under.  Just above the
+// leaves, it is the entry whose overlap with its siblings grows least; higher up, the entry whose area grows least.
+// Ties go to the entry whose area grows least, and then to the smallest.
+func (n *node[V]) chooseSubtree(r Rect) int {
+	best := 0
+	bestOverlap, bestEnlargement, bestArea := math.Inf(1), math.Inf(1), math.Inf(1)
+	for i, e := range n.entries {
+		union := e.rect.Union(r)
+		area := e.rect.Area()
+		enlargement := union.Area() - area
+		var overlap float64
+		if n.level == 1 {
+			for j, o := range n.entries {
+				if j != i {
+					overlap += union.overlap(o.rect) - e.rect.overlap(o.rect)
+				}
+			}
+		}
+		if overlap < bestOverlap ||
+			overlap == bestOverlap && (enlargement < bestEnlargement || enlargement == bestEnlargement && area < bestArea) {
+			best, bestOverlap, bestEnlargement, bestArea = i, overlap, enlargement, area
+		}
+	}
+	return best
+}
+
+// takeFarthest removes from n the 30% of the maximum number of entries whose centers are furthest from the center of
+// n, and returns them closest first.
+func (t *Tree[V]) takeFarthest(n *node[V]) []entry[V] {
+	cx, cy := bounds(n.entries).center()
+	distance := func(e entry[V]) float64 {
+		x, y := e.rect.center()
+		return (x-cx)*(x-cx) + (y-cy)*(y-cy)
+	}
+	slices.SortFunc(n.entries, func(a, b entry[V]) int {
+		return cmp.Compare(distance(b), distance(a))
+	})
+	p := max(1, t.maxEntries*3/10)
+	removed := slices.Clone(n.entries[:p])
+	n.entries = append(n.entries[:0], n.entries[p:]...)
+	slices.Reverse(removed)
+	return removed
+}
+
+// split moves some of the entries of the overflowing node n into a new sibling, which it returns.  Following the
+// R*-tree, it splits along the axis where the distributions of the entries, sorted by their lower and then by their
+// upper bounds, have the least total margin, and picks the distribution along it whose two groups overlap least, and
+// then have the least area.
+func (t *Tree[V]) split(n *node[V]) *node[V] {
+	var chosen [2][]entry[V]
+	bestMargin := math.Inf(1)
+	for axis := range 2 {
+		sorted := sortAlong(n.entries, axis)
+		var margin float64
+		for _, entries := range sorted {
+			for k := t.minEntries; k <= len(entries)-t.minEntries; k++ {
+				margin += bounds(entries[:k]).margin() + bounds(entries[k:]).margin()
+			}
+		}
+		if margin < bestMargin {
+			chosen, bestMargin = sorted, margin
+		}
+	}
+	var group []entry[V]
+	var split int
+	bestOverlap, bestArea := math.Inf(1), math.Inf(1)
+	for _, entries := range chosen {
+		for k := t.minEntries; k <= len(entries)-t.minEntries; k++ {
+			a, b := bounds(entries[:k]), bounds(entries[k:])
+			overlap, area := a.overlap(b), a.Area()+b.Area()
+			if overlap < bestOverlap || overlap == bestOverlap && area < bestArea {
+				group, split, bestOverlap, bestArea = entries, k, overlap, area
+			}
+		}
+	}
+	n.entries = slices.Clone(group[:split])
+	return &node[V]{level: n.level, entries: slices.Clone(group[split:])}
+}
+
+// sortAlong returns copies of entries sorted along axis, 0 for x and 1 for y, by their lower and then their upper
+// bounds, and by their upper and then their lower bounds.
+func sortAlong[V comparable](entries []entry[V], axis int) [2][]entry[V] {
+	lo, hi := func(r Rect) float64 { return r.MinX }, func(r Rect) float64 { return r.MaxX }
+	if axis == 1 {
+		lo, hi = func(r Rect) float64 { return r.MinY }, func(r Rect) float64 { return r.MaxY }
+	}
+	byLo, byHi := slices.Clone(entries), slices.Clone(entries)
+	slices.SortFunc(byLo, func(a, b entry[V]) int {
+		return cmp.Or(cmp.Compare(lo(a.rect), lo(b.rect)), cmp.Compare(hi(a.rect), hi(b.rect)))
+	})
+	slices.SortFunc(byHi, func(a, b entry[V]) int {
+		return cmp.Or(cmp.Compare(hi(a.rect), hi(b.rect)), cmp.Compare(lo(a.rect), lo(b.rect)))
+	})
+	return [2][]entry[V]{byLo, byHi}
+}
@@ -0,0 +1,76 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtree
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Load returns a Tree of entries, packed by the Sort-Tile-Recursive algorithm in O(n log n).  Each level is built by
+// sorting the entries below it by the x coordinates of their centers, cutting them into about √P vertical slices for P
+// nodes, and cutting each slice, sorted by y, into nodes.  The nodes are nearly full and overlap little, so a loaded
+// Tree is smaller and faster to search than one built by Insert.  It may still be modified afterwards.
+func Load[V comparable](entries []Entry[V], opts ...Option) *Tree[V] {
+	t := New[V](opts...)
+	if len(entries) == 0 {
+		return t
+	}
+	level := make([]entry[V], len(entries))
+	for i, e := range entries {
+		level[i] = entry[V]{rect: e.Rect, value: e.Value}
+	}
+	t.size = len(entries)
+	for height := 0; ; height++ {
+		nodes := t.pack(level, height)
+		if len(nodes) == 1 {
+			t.root = nodes[0]
+			return t
+		}
+		level = make([]entry[V], len(nodes))
+		for i, n := range nodes {
+			level[i] = entry[V]{rect: bounds(n.entries), child: n}
+		}
+	}
+}
+
+// pack tiles entries into nodes at height, which it returns.  It reorders entries.
+func (t *Tree[V]) pack(entries []entry[V], height int) []*node[V] {
+	count := (len(entries) + t.maxEntries - 1) / t.maxEntries
+	sortByCenter(entries, func(r Rect) float64 { return r.MinX + r.MaxX })
+	var nodes []*node[V]
+	for _, slice := range chunks(entries, int(math.Ceil(math.Sqrt(float64(count))))) {
+		sortByCenter(slice, func(r Rect) float64 { return r.MinY + r.MaxY })
+		for _, group := range chunks(slice, (len(slice)+t.maxEntries-1)/t.maxEntries) {
+			nodes = append(nodes, &node[V]{level: height, entries: slices.Clone(group)})
+		}
+	}
+	return nodes
+}
+
+// sortByCenter sorts entries by coordinate, which returns twice the coordinate of the center of a Rect along an axis.
+func sortByCenter[V comparable](entries []entry[V], coordinate func(Rect) float64) {
+	slices.SortFunc(entries, func(a, b entry[V]) int {
+		return cmp.Compare(coordinate(a.rect), coordinate(b.rect))
+	})
+}
+
+// chunks cuts entries into count contiguous chunks whose lengths differ by at most one.
+func chunks[V comparable](entries []entry[V], count int) [][]entry[V] {
+	result := make([][]entry[V], count)
+	for i := range result {
+		result[i] = entries[i*len(entries)/count : (i+1)*len(entries)/count]
+	}
+	return result
+}
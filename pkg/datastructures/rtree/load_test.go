@@ -0,0 +1,48 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtree_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/rtree"
+)
+
+func TestLoad(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 16, 17, 3000} {
+		var entries []rtree.Entry[int]
+		reference := map[int]rtree.Rect{}
+		for i := 0; i < n; i++ {
+			rect := randomRect(r)
+			entries = append(entries, rtree.Entry[int]{Rect: rect, Value: i})
+			reference[i] = rect
+		}
+		tree := rtree.Load(entries)
+		checkQueries(t, r, tree, reference)
+		// A loaded Tree may still be modified.
+		for i := 0; i < n/2; i++ {
+			if !tree.Delete(reference[i], i) {
+				t.Fatalf("Expected to delete %d at %v", i, reference[i])
+			}
+			delete(reference, i)
+		}
+		for i := n; i < n+100; i++ {
+			rect := randomRect(r)
+			tree.Insert(rect, i)
+			reference[i] = rect
+		}
+		checkQueries(t, r, tree, reference)
+	}
+}
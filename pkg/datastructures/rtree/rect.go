@@ -0,0 +1,66 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtree
+
+// A Rect is the axis-aligned rectangle from (MinX, MinY) to (MaxX, MaxY), inclusive.  A point is a Rect with no area.
+type Rect struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Contains reports whether o lies within r.
+func (r Rect) Contains(o Rect) bool {
+	return r.MinX <= o.MinX && o.MaxX <= r.MaxX && r.MinY <= o.MinY && o.MaxY <= r.MaxY
+}
+
+// Intersects reports whether r and o share any point.
+func (r Rect) Intersects(o Rect) bool {
+	return r.MinX <= o.MaxX && o.MinX <= r.MaxX && r.MinY <= o.MaxY && o.MinY <= r.MaxY
+}
+
+// Union returns the smallest Rect containing both r and o.
+func (r Rect) Union(o Rect) Rect {
+	return Rect{min(r.MinX, o.MinX), min(r.MinY, o.MinY), max(r.MaxX, o.MaxX), max(r.MaxY, o.MaxY)}
+}
+
+// Area returns the area of r.
+func (r Rect) Area() float64 {
+	return (r.MaxX - r.MinX) * (r.MaxY - r.MinY)
+}
+
+// margin returns half the perimeter of r.
+func (r Rect) margin() float64 {
+	return (r.MaxX - r.MinX) + (r.MaxY - r.MinY)
+}
+
+// overlap returns the area shared by r and o.
+func (r Rect) overlap(o Rect) float64 {
+	w := min(r.MaxX, o.MaxX) - max(r.MinX, o.MinX)
+	h := min(r.MaxY, o.MaxY) - max(r.MinY, o.MinY)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	return w * h
+}
+
+// center returns the center of r.
+func (r Rect) center() (x, y float64) {
+	return r.MinX + (r.MaxX-r.MinX)/2, r.MinY + (r.MaxY-r.MinY)/2
+}
+
+// squaredDistance returns the square of the distance from (x, y) to the closest point of r, which is zero if r contains
+// the point.
+func (r Rect) squaredDistance(x, y float64) float64 {
+	dx := max(r.MinX-x, 0, x-r.MaxX)
+	dy := max(r.MinY-y, 0, y-r.MaxY)
+	return dx*dx + dy*dy
+}
@@ -0,0 +1,38 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtree_test
+
+import (
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/rtree"
+)
+
+func TestRect(t *testing.T) {
+	r := rtree.Rect{MinX: 0, MinY: 0, MaxX: 4, MaxY: 2}
+	inside, outside := rtree.Rect{MinX: 1, MinY: 1, MaxX: 4, MaxY: 2}, rtree.Rect{MinX: 1, MinY: 1, MaxX: 5, MaxY: 2}
+	if !r.Contains(inside) || r.Contains(outside) {
+		t.Fatalf("Expected %v to contain only the Rects within it", r)
+	}
+	touching, apart := rtree.Rect{MinX: 4, MinY: 2, MaxX: 6, MaxY: 3}, rtree.Rect{MinX: 5, MinY: 0, MaxX: 6, MaxY: 1}
+	if !r.Intersects(touching) || r.Intersects(apart) {
+		t.Fatalf("Expected %v to intersect only the Rects sharing a point with it", r)
+	}
+	union := r.Union(rtree.Rect{MinX: -1, MinY: 1, MaxX: 2, MaxY: 3})
+	if expected := (rtree.Rect{MinX: -1, MinY: 0, MaxX: 4, MaxY: 3}); union != expected {
+		t.Fatalf("Expected: %v Actual: %v", expected, union)
+	}
+	if union.Area() != 15 {
+		t.Fatalf("Expected: %d Actual: %f", 15, union.Area())
+	}
+}
@@ -0,0 +1,273 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtree
+
+import (
+	"container/heap"
+	"iter"
+	"math"
+)
+
+const defaultMaxEntries = 16
+
+// An Entry is a rectangle in the Tree and its value.
+type Entry[V comparable] struct {
+	Rect  Rect
+	Value V
+}
+
+// A Neighbor is an Entry found by Nearest and its distance from the query point.
+type Neighbor[V comparable] struct {
+	Entry[V]
+	Distance float64
+}
+
+// A Tree is an R-tree of rectangles and their values.  Every node holds between a minimum and a maximum number of
+// entries, each with the bounding Rect of its subtree, and all leaves are at the same depth, so Insert and Delete visit
+// O(log n) nodes, while a Search visits the nodes whose bounds intersect the query.  The same rectangle may be
+// inserted more than once.
+type Tree[V comparable] struct {
+	root       *node[V]
+	size       int
+	maxEntries int
+	minEntries int
+}
+
+// node is a node of the Tree at a level above the leaves, which are at level 0.
+type node[V comparable] struct {
+	level   int
+	entries []entry[V]
+}
+
+// entry is an entry of a node:  a child and its bounds above the leaves, or a rectangle and its value in a leaf.
+type entry[V comparable] struct {
+	rect  Rect
+	child *node[V]
+	value V
+}
+
+// An Option configures a Tree constructed by New or Load.
+type Option func(*options)
+
+// options holds the configuration set by Options.
+type options struct {
+	maxEntries int
+}
+
+// WithMaxEntries sets the number of entries a node holds before it splits.  By default it is 16.  Nodes other than
+// the root hold at least 40% as many.  It panics if maxEntries is less than 4.
+func WithMaxEntries(maxEntries int) Option {
+	if maxEntries < 4 {
+		panic("rtree: max entries must be at least 4")
+	}
+	return func(o *options) {
+		o.maxEntries = maxEntries
+	}
+}
+
+// New returns an empty Tree.
+func New[V comparable](opts ...Option) *Tree[V] {
+	o := options{maxEntries: defaultMaxEntries}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Tree[V]{
+		root:       &node[V]{},
+		maxEntries: o.maxEntries,
+		minEntries: max(2, o.maxEntries*2/5),
+	}
+}
+
+// Len returns the number of entries in the Tree.
+func (t *Tree[V]) Len() int {
+	return t.size
+}
+
+// Bounds returns the smallest Rect containing every entry of the Tree.  The second return value is false if the Tree
+// is empty.
+func (t *Tree[V]) Bounds() (Rect, bool) {
+	if len(t.root.entries) == 0 {
+		return Rect{}, false
+	}
+	return bounds(t.root.entries), true
+}
+
+// bounds returns the smallest Rect containing the rectangles of entries, which must not be empty.
+func bounds[V comparable](entries []entry[V]) Rect {
+	r := entries[0].rect
+	for _, e := range entries[1:] {
+		r = r.Union(e.rect)
+	}
+	return r
+}
+
+// Search returns an iterator over the entries whose rectangles intersect r, in no particular order.  The Tree must not
+// be modified during iteration.
+func (t *Tree[V]) Search(r Rect) iter.Seq2[Rect, V] {
+	return func(yield func(Rect, V) bool) {
+		t.root.search(r, yield)
+	}
+}
+
+// search yields the entries of the subtree rooted at n that intersect r.  It returns false if yield asked to stop.
+func (n *node[V]) search(r Rect, yield func(Rect, V) bool) bool {
+	for _, e := range n.entries {
+		if !e.rect.Intersects(r) {
+			continue
+		}
+		if n.level == 0 {
+			if !yield(e.rect, e.value) {
+				return false
+			}
+		} else if !e.child.search(r, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over the entries of the Tree in no particular order.  The Tree must not be modified during
+// iteration.
+func (t *Tree[V]) All() iter.Seq2[Rect, V] {
+	return func(yield func(Rect, V) bool) {
+		t.root.each(func(e entry[V]) bool {
+			return yield(e.rect, e.value)
+		})
+	}
+}
+
+// each yields the leaf entries of the subtree rooted at n.  It returns false if yield asked to stop.
+func (n *node[V]) each(yield func(entry[V]) bool) bool {
+	for _, e := range n.entries {
+		if n.level == 0 {
+			if !yield(e) {
+				return false
+			}
+		} else if !e.child.each(yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// Nearest returns the k entries whose rectangles are closest to the point (x, y), nearest first, or all of them if the
+// Tree holds fewer than k.  The distance to a rectangle containing the point is zero.  Nodes are visited best first,
+// in order of their distance from the point, so only nodes closer than the kth nearest entry are visited.
+func (t *Tree[V]) Nearest(x, y float64, k int) []Neighbor[V] {
+	if k <= 0 {
+		return nil
+	}
+	var neighbors []Neighbor[V]
+	candidates := &nearest[V]{}
+	for _, e := range t.root.entries {
+		heap.Push(candidates, candidate[V]{entry: e, level: t.root.level, distance: e.rect.squaredDistance(x, y)})
+	}
+	for candidates.Len() > 0 && len(neighbors) < k {
+		c := heap.Pop(candidates).(candidate[V])
+		if c.level == 0 {
+			neighbors = append(neighbors, Neighbor[V]{
+				Entry:    Entry[V]{Rect: c.entry.rect, Value: c.entry.value},
+				Distance: math.Sqrt(c.distance),
+			})
+			continue
+		}
+		for _, e := range c.entry.child.entries {
+			heap.Push(candidates, candidate[V]{entry: e, level: c.entry.child.level, distance: e.rect.squaredDistance(x, y)})
+		}
+	}
+	return neighbors
+}
+
+// candidate is an entry awaiting a visit by Nearest, with the level of the node holding it and the square of its
+// distance from the query point.
+type candidate[V comparable] struct {
+	entry    entry[V]
+	level    int
+	distance float64
+}
+
+// nearest is a min-heap of candidates by distance.
+type nearest[V comparable] []candidate[V]
+
+// Len, Less, Swap, Push and Pop implement heap.Interface.
+func (h *nearest[V]) Len() int           { return len(*h) }
+func (h *nearest[V]) Less(i, j int) bool { return (*h)[i].distance < (*h)[j].distance }
+func (h *nearest[V]) Swap(i, j int)      { (*h)[i], (*h)[j] = (*h)[j], (*h)[i] }
+func (h *nearest[V]) Push(x any)         { *h = append(*h, x.(candidate[V])) }
+func (h *nearest[V]) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// Delete removes one occurrence of the rectangle r with value from the Tree.  It returns false if there is none.
+// Nodes left with too few entries are removed, and their entries inserted again.
+func (t *Tree[V]) Delete(r Rect, value V) bool {
+	var orphans []*node[V]
+	if !t.delete(t.root, r, value, &orphans) {
+		return false
+	}
+	t.size--
+	for t.root.level > 0 && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+	}
+	for _, orphan := range orphans {
+		if orphan.level <= t.root.level {
+			for _, e := range orphan.entries {
+				t.insert(e, orphan.level, map[int]bool{})
+			}
+			continue
+		}
+		// The Tree shrank below the orphan, so insert its leaf entries instead.
+		orphan.each(func(e entry[V]) bool {
+			t.insert(e, 0, map[int]bool{})
+			return true
+		})
+	}
+	return true
+}
+
+// delete removes r with value from the subtree rooted at n, removing the nodes on the way back up that are left with
+// fewer than the minimum number of entries, and appending them to orphans.  It returns false if there is no such
+// entry in the subtree.
+func (t *Tree[V]) delete(n *node[V], r Rect, value V, orphans *[]*node[V]) bool {
+	for i, e := range n.entries {
+		if n.level == 0 {
+			if e.rect == r && e.value == value {
+				n.remove(i)
+				return true
+			}
+			continue
+		}
+		if !e.rect.Contains(r) || !t.delete(e.child, r, value, orphans) {
+			continue
+		}
+		if len(e.child.entries) < t.minEntries {
+			*orphans = append(*orphans, e.child)
+			n.remove(i)
+		} else {
+			n.entries[i].rect = bounds(e.child.entries)
+		}
+		return true
+	}
+	return false
+}
+
+// remove removes the entry of n at index i.
+func (n *node[V]) remove(i int) {
+	last := len(n.entries) - 1
+	n.entries[i] = n.entries[last]
+	n.entries[last] = entry[V]{}
+	n.entries = n.entries[:last]
+}
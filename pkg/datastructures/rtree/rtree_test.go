@@ -0,0 +1,134 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtree_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/rtree"
+)
+
+// randomRect returns a small Rect at a random place in the square from (0, 0) to (100, 100).
+func randomRect(r *rand.Rand) rtree.Rect {
+	x, y := r.Float64()*100, r.Float64()*100
+	return rtree.Rect{MinX: x, MinY: y, MaxX: x + r.Float64()*5, MaxY: y + r.Float64()*5}
+}
+
+// distance returns the distance from (x, y) to the closest point of r.
+func distance(r rtree.Rect, x, y float64) float64 {
+	dx := max(r.MinX-x, 0, x-r.MaxX)
+	dy := max(r.MinY-y, 0, y-r.MaxY)
+	return math.Hypot(dx, dy)
+}
+
+// checkQueries compares Search and Nearest on tree with brute force over reference.
+func checkQueries(t *testing.T, r *rand.Rand, tree *rtree.Tree[int], reference map[int]rtree.Rect) {
+	if tree.Len() != len(reference) {
+		t.Fatalf("Expected: %d Actual: %d", len(reference), tree.Len())
+	}
+	for i := 0; i < 50; i++ {
+		query := randomRect(r)
+		query.MaxX, query.MaxY = query.MaxX+10, query.MaxY+10
+		expected := 0
+		for _, rect := range reference {
+			if rect.Intersects(query) {
+				expected++
+			}
+		}
+		actual := 0
+		for rect, value := range tree.Search(query) {
+			if reference[value] != rect {
+				t.Fatalf("Expected: %v Actual: %v", reference[value], rect)
+			}
+			actual++
+		}
+		if actual != expected {
+			t.Fatalf("Expected: %d Actual: %d", expected, actual)
+		}
+
+		x, y := r.Float64()*100, r.Float64()*100
+		var distances []float64
+		for _, rect := range reference {
+			distances = append(distances, distance(rect, x, y))
+		}
+		sort.Float64s(distances)
+		neighbors := tree.Nearest(x, y, 5)
+		if len(neighbors) != min(5, len(reference)) {
+			t.Fatalf("Expected: %d Actual: %d", min(5, len(reference)), len(neighbors))
+		}
+		for j, neighbor := range neighbors {
+			if math.Abs(neighbor.Distance-distances[j]) > 1e-9 || reference[neighbor.Value] != neighbor.Rect {
+				t.Fatalf("Expected: %f Actual: %f", distances[j], neighbor.Distance)
+			}
+		}
+	}
+}
+
+func TestTree(t *testing.T) {
+	tree := rtree.New[string]()
+	if _, ok := tree.Bounds(); ok {
+		t.Fatalf("Expected an empty Tree to have no bounds")
+	}
+	tree.Insert(rtree.Rect{MinX: 0, MinY: 0, MaxX: 2, MaxY: 2}, "a")
+	tree.Insert(rtree.Rect{MinX: 5, MinY: 5, MaxX: 6, MaxY: 6}, "b")
+	tree.Insert(rtree.Rect{MinX: 1, MinY: 1, MaxX: 1, MaxY: 1}, "c")
+	found := map[string]bool{}
+	for _, value := range tree.Search(rtree.Rect{MinX: 1, MinY: 1, MaxX: 3, MaxY: 3}) {
+		found[value] = true
+	}
+	if len(found) != 2 || !found["a"] || !found["c"] {
+		t.Fatalf("Expected: %s Actual: %v", "[a c]", found)
+	}
+	if neighbors := tree.Nearest(4, 4, 1); len(neighbors) != 1 || neighbors[0].Value != "b" {
+		t.Fatalf("Expected: %s Actual: %v", "b", neighbors)
+	}
+	if bounds, _ := tree.Bounds(); bounds != (rtree.Rect{MinX: 0, MinY: 0, MaxX: 6, MaxY: 6}) {
+		t.Fatalf("Expected: %v Actual: %v", rtree.Rect{MinX: 0, MinY: 0, MaxX: 6, MaxY: 6}, bounds)
+	}
+	if b := (rtree.Rect{MinX: 5, MinY: 5, MaxX: 6, MaxY: 6}); !tree.Delete(b, "b") || tree.Delete(b, "b") {
+		t.Fatalf("Expected to delete %s once", "b")
+	}
+	if tree.Len() != 2 {
+		t.Fatalf("Expected: %d Actual: %d", 2, tree.Len())
+	}
+}
+
+func TestTree_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, maxEntries := range []int{4, 16} {
+		tree := rtree.New[int](rtree.WithMaxEntries(maxEntries))
+		reference := map[int]rtree.Rect{}
+		for i := 0; i < 5000; i++ {
+			if r.Intn(3) == 0 && len(reference) > 0 {
+				for value, rect := range reference {
+					if !tree.Delete(rect, value) {
+						t.Fatalf("Expected to delete %d at %v", value, rect)
+					}
+					delete(reference, value)
+					break
+				}
+				continue
+			}
+			rect := randomRect(r)
+			tree.Insert(rect, i)
+			reference[i] = rect
+			if i%1000 == 0 {
+				checkQueries(t, r, tree, reference)
+			}
+		}
+		checkQueries(t, r, tree, reference)
+	}
+}
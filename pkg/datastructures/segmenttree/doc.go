@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package segmenttree implements segment trees over a sequence of values, which answer queries such as the sum, minimum or
+maximum of any range in O(log n) while the values change.  Tree supports updates of single values under any associative
+operation, and Lazy also supports updates of whole ranges by deferring them until a query needs them.
+*/
+
+package segmenttree
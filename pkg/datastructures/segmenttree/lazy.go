@@ -0,0 +1,146 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segmenttree
+
+// A Lazy is a segment tree that supports updating every value in a range as well as querying a range, both in
+// O(log n).  An update to a range is applied to the O(log n) nodes that cover it and recorded there as pending, to be
+// pushed down to their children only when a later operation needs to look inside them.
+//
+// Values are of type T, combined by an associative combine, and updates are of type U.  apply(u, v, n) returns the
+// result of applying u to each of n values whose combination is v; for sums under range addition it is v + u*n.
+// compose(newer, older) returns the single update equivalent to applying older and then newer.
+type Lazy[T, U any] struct {
+	nodes   []T
+	pending []U
+	dirty   []bool // Whether each node has a pending update for its children.
+	n       int
+	combine func(a, b T) T
+	apply   func(update U, value T, length int) T
+	compose func(newer, older U) U
+}
+
+// NewLazy returns a Lazy over a copy of values in O(n).
+func NewLazy[T, U any](values []T, combine func(a, b T) T, apply func(update U, value T, length int) T,
+	compose func(newer, older U) U) *Lazy[T, U] {
+	n := len(values)
+	l := &Lazy[T, U]{
+		nodes:   make([]T, 4*n),
+		pending: make([]U, 4*n),
+		dirty:   make([]bool, 4*n),
+		n:       n,
+		combine: combine,
+		apply:   apply,
+		compose: compose,
+	}
+	if n > 0 {
+		l.build(values, 1, 0, n)
+	}
+	return l
+}
+
+// Len returns the number of values in the Lazy.
+func (l *Lazy[T, U]) Len() int {
+	return l.n
+}
+
+// Update applies update to every value with an index in [from, to) in O(log n).  It does nothing if the range is
+// empty, and panics if it is not within the Lazy.
+func (l *Lazy[T, U]) Update(from, to int, update U) {
+	if from < 0 || to > l.n {
+		panic("segmenttree: range out of bounds")
+	}
+	if from < to {
+		l.update(1, 0, l.n, from, to, update)
+	}
+}
+
+// Query returns the combination of the values with indexes in [from, to), in order, in O(log n).  The second return
+// value is false if the range is empty or not within the Lazy.
+func (l *Lazy[T, U]) Query(from, to int) (T, bool) {
+	if from < 0 || to > l.n || from >= to {
+		var zero T
+		return zero, false
+	}
+	return l.query(1, 0, l.n, from, to), true
+}
+
+// build fills node, which covers the values with indexes in [lo, hi), and the nodes below it.
+func (l *Lazy[T, U]) build(values []T, node, lo, hi int) {
+	if hi-lo == 1 {
+		l.nodes[node] = values[lo]
+		return
+	}
+	mid := (lo + hi) / 2
+	l.build(values, 2*node, lo, mid)
+	l.build(values, 2*node+1, mid, hi)
+	l.nodes[node] = l.combine(l.nodes[2*node], l.nodes[2*node+1])
+}
+
+// update applies update to the values with indexes in [from, to) below node, which covers [lo, hi).
+func (l *Lazy[T, U]) update(node, lo, hi, from, to int, update U) {
+	if from <= lo && hi <= to {
+		l.mark(node, hi-lo, update)
+		return
+	}
+	l.push(node, lo, hi)
+	mid := (lo + hi) / 2
+	if from < mid {
+		l.update(2*node, lo, mid, from, to, update)
+	}
+	if to > mid {
+		l.update(2*node+1, mid, hi, from, to, update)
+	}
+	l.nodes[node] = l.combine(l.nodes[2*node], l.nodes[2*node+1])
+}
+
+// query returns the combination of the values with indexes in [from, to) below node, which covers [lo, hi) and
+// overlaps the range.
+func (l *Lazy[T, U]) query(node, lo, hi, from, to int) T {
+	if from <= lo && hi <= to {
+		return l.nodes[node]
+	}
+	l.push(node, lo, hi)
+	mid := (lo + hi) / 2
+	switch {
+	case to <= mid:
+		return l.query(2*node, lo, mid, from, to)
+	case from >= mid:
+		return l.query(2*node+1, mid, hi, from, to)
+	}
+	return l.combine(l.query(2*node, lo, mid, from, to), l.query(2*node+1, mid, hi, from, to))
+}
+
+// mark applies update to node, which covers length values, and records it as pending for the children of node.
+func (l *Lazy[T, U]) mark(node, length int, update U) {
+	l.nodes[node] = l.apply(update, l.nodes[node], length)
+	if length == 1 {
+		return
+	}
+	if l.dirty[node] {
+		l.pending[node] = l.compose(update, l.pending[node])
+	} else {
+		l.pending[node], l.dirty[node] = update, true
+	}
+}
+
+// push passes the pending update of node, which covers [lo, hi), down to its children.
+func (l *Lazy[T, U]) push(node, lo, hi int) {
+	if !l.dirty[node] {
+		return
+	}
+	mid := (lo + hi) / 2
+	l.mark(2*node, mid-lo, l.pending[node])
+	l.mark(2*node+1, hi-mid, l.pending[node])
+	var zero U
+	l.pending[node], l.dirty[node] = zero, false
+}
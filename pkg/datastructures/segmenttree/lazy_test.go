@@ -0,0 +1,72 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segmenttree_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/segmenttree"
+)
+
+func TestLazy(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 100
+	values := make([]int, n)
+	for i := range values {
+		values[i] = r.Intn(100)
+	}
+	// Range addition with range sums.
+	lazy := segmenttree.NewLazy(values,
+		func(a, b int) int { return a + b },
+		func(add, sum, length int) int { return sum + add*length },
+		func(newer, older int) int { return newer + older })
+	for i := 0; i < 2000; i++ {
+		from := r.Intn(n)
+		to := from + 1 + r.Intn(n-from)
+		if r.Intn(2) == 0 {
+			add := r.Intn(21) - 10
+			lazy.Update(from, to, add)
+			for j := from; j < to; j++ {
+				values[j] += add
+			}
+			continue
+		}
+		expected := 0
+		for _, value := range values[from:to] {
+			expected += value
+		}
+		if actual, _ := lazy.Query(from, to); actual != expected {
+			t.Fatalf("Expected: %d Actual: %d", expected, actual)
+		}
+	}
+}
+
+func TestLazy_Assign(t *testing.T) {
+	// Range assignment with range minimums, where a later assignment replaces an earlier one.
+	lazy := segmenttree.NewLazy([]int{4, 8, 15, 16, 23, 42},
+		func(a, b int) int { return min(a, b) },
+		func(assign, _, _ int) int { return assign },
+		func(newer, _ int) int { return newer })
+	lazy.Update(0, 4, 20)
+	lazy.Update(2, 6, 30)
+	if low, _ := lazy.Query(0, 6); low != 20 {
+		t.Fatalf("Expected: %d Actual: %d", 20, low)
+	}
+	if low, _ := lazy.Query(2, 6); low != 30 {
+		t.Fatalf("Expected: %d Actual: %d", 30, low)
+	}
+	if _, ok := lazy.Query(3, 3); ok {
+		t.Fatalf("Expected an empty range to be invalid")
+	}
+}
@@ -0,0 +1,122 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segmenttree
+
+import "cmp"
+
+// A Number is a type that can be summed.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 | ~float64
+}
+
+// A Tree is a segment tree over a sequence of values and an associative operation combine, which need not be
+// commutative.  Set and Query are O(log n).  It is stored bottom-up in a slice of 2n values, with the values
+// themselves in the second half and the combination of the children of node i, nodes 2i and 2i+1, at node i.
+type Tree[T any] struct {
+	nodes   []T
+	n       int
+	combine func(a, b T) T
+}
+
+// New returns a Tree over a copy of values in O(n).  combine must be associative:  combine(combine(a, b), c) equals
+// combine(a, combine(b, c)).
+func New[T any](values []T, combine func(a, b T) T) *Tree[T] {
+	n := len(values)
+	t := &Tree[T]{nodes: make([]T, 2*n), n: n, combine: combine}
+	copy(t.nodes[n:], values)
+	for i := n - 1; i > 0; i-- {
+		t.nodes[i] = combine(t.nodes[2*i], t.nodes[2*i+1])
+	}
+	return t
+}
+
+// NewSum returns a Tree whose queries return the sum of a range.
+func NewSum[T Number](values []T) *Tree[T] {
+	return New(values, func(a, b T) T { return a + b })
+}
+
+// NewMin returns a Tree whose queries return the minimum of a range.
+func NewMin[T cmp.Ordered](values []T) *Tree[T] {
+	return New(values, func(a, b T) T { return min(a, b) })
+}
+
+// NewMax returns a Tree whose queries return the maximum of a range.
+func NewMax[T cmp.Ordered](values []T) *Tree[T] {
+	return New(values, func(a, b T) T { return max(a, b) })
+}
+
+// Len returns the number of values in the Tree.
+func (t *Tree[T]) Len() int {
+	return t.n
+}
+
+// Get returns the value at index i in O(1).  It panics if i is out of range.
+func (t *Tree[T]) Get(i int) T {
+	return t.nodes[t.leaf(i)]
+}
+
+// Set sets the value at index i and updates the combinations above it in O(log n).  It panics if i is out of range.
+func (t *Tree[T]) Set(i int, value T) {
+	i = t.leaf(i)
+	t.nodes[i] = value
+	for i > 1 {
+		i /= 2
+		t.nodes[i] = t.combine(t.nodes[2*i], t.nodes[2*i+1])
+	}
+}
+
+// Query returns the combination of the values with indexes in [from, to), in order, in O(log n).  The second return
+// value is false if the range is empty or not within the Tree.
+func (t *Tree[T]) Query(from, to int) (T, bool) {
+	var left, right T
+	if from < 0 || to > t.n || from >= to {
+		return left, false
+	}
+	// Climb from both ends, combining the nodes that fall wholly inside the range into left and right, which keeps
+	// the order of a non-commutative operation.
+	hasLeft, hasRight := false, false
+	for l, r := from+t.n, to+t.n; l < r; l, r = l/2, r/2 {
+		if l&1 == 1 {
+			left, hasLeft = t.join(left, hasLeft, t.nodes[l]), true
+			l++
+		}
+		if r&1 == 1 {
+			r--
+			if hasRight {
+				right = t.combine(t.nodes[r], right)
+			} else {
+				right, hasRight = t.nodes[r], true
+			}
+		}
+	}
+	if !hasRight {
+		return left, true
+	}
+	return t.join(left, hasLeft, right), true
+}
+
+// join returns combine(acc, value), or value alone if acc is not set.
+func (t *Tree[T]) join(acc T, set bool, value T) T {
+	if !set {
+		return value
+	}
+	return t.combine(acc, value)
+}
+
+// leaf returns the node holding the value at index i.
+func (t *Tree[T]) leaf(i int) int {
+	if i < 0 || i >= t.n {
+		panic("segmenttree: index out of range")
+	}
+	return i + t.n
+}
@@ -0,0 +1,65 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segmenttree_test
+
+import (
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/segmenttree"
+)
+
+func TestTree(t *testing.T) {
+	values := []int{5, 3, 8, 1, 9, 2, 7}
+	sum := segmenttree.NewSum(values)
+	minimum := segmenttree.NewMin(values)
+	maximum := segmenttree.NewMax(values)
+	if total, _ := sum.Query(0, 7); total != 35 {
+		t.Fatalf("Expected: %d Actual: %d", 35, total)
+	}
+	if total, _ := sum.Query(2, 5); total != 18 {
+		t.Fatalf("Expected: %d Actual: %d", 18, total)
+	}
+	if low, _ := minimum.Query(0, 3); low != 3 {
+		t.Fatalf("Expected: %d Actual: %d", 3, low)
+	}
+	if high, _ := maximum.Query(5, 7); high != 7 {
+		t.Fatalf("Expected: %d Actual: %d", 7, high)
+	}
+	minimum.Set(4, -1)
+	if low, _ := minimum.Query(2, 7); low != -1 || minimum.Get(4) != -1 {
+		t.Fatalf("Expected: %d Actual: %d", -1, low)
+	}
+	for _, r := range [][2]int{{3, 3}, {-1, 2}, {0, 8}, {4, 2}} {
+		if _, ok := sum.Query(r[0], r[1]); ok {
+			t.Fatalf("Expected the range %v to be invalid", r)
+		}
+	}
+}
+
+func TestTree_NonCommutative(t *testing.T) {
+	letters := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}
+	concat := segmenttree.New(letters, func(a, b string) string { return a + b })
+	for from := 0; from < len(letters); from++ {
+		expected := ""
+		for to := from + 1; to <= len(letters); to++ {
+			expected += letters[to-1]
+			if actual, _ := concat.Query(from, to); actual != expected {
+				t.Fatalf("Expected: %s Actual: %s", expected, actual)
+			}
+		}
+	}
+	concat.Set(0, "z")
+	if actual, _ := concat.Query(0, 3); actual != "zbc" {
+		t.Fatalf("Expected: %s Actual: %s", "zbc", actual)
+	}
+}
@@ -0,0 +1,233 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package set
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"iter"
+	"reflect"
+	"sort"
+)
+
+// A Set is an unordered collection of distinct values.  Add, Remove and Contains are O(1).  The zero value is an empty
+// Set ready to use.
+type Set[T comparable] struct {
+	values map[T]struct{}
+}
+
+// New returns a Set holding values, ignoring duplicates.
+func New[T comparable](values ...T) *Set[T] {
+	s := &Set[T]{values: make(map[T]struct{}, len(values))}
+	for _, value := range values {
+		s.values[value] = struct{}{}
+	}
+	return s
+}
+
+// Len returns the number of values in the Set.
+func (s *Set[T]) Len() int {
+	return len(s.values)
+}
+
+// Add adds value to the Set.  It returns false if value was already in the Set.
+func (s *Set[T]) Add(value T) bool {
+	if _, ok := s.values[value]; ok {
+		return false
+	}
+	if s.values == nil {
+		s.values = make(map[T]struct{})
+	}
+	s.values[value] = struct{}{}
+	return true
+}
+
+// Remove removes value from the Set.  It returns false if value was not in the Set.
+func (s *Set[T]) Remove(value T) bool {
+	if _, ok := s.values[value]; !ok {
+		return false
+	}
+	delete(s.values, value)
+	return true
+}
+
+// Contains reports whether value is in the Set.
+func (s *Set[T]) Contains(value T) bool {
+	_, ok := s.values[value]
+	return ok
+}
+
+// All returns an iterator over the values of the Set in no particular order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range s.values {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the values of the Set in a new slice, in no particular order.
+func (s *Set[T]) Slice() []T {
+	values := make([]T, 0, len(s.values))
+	for value := range s.values {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Union returns a new Set holding the values in either the Set or other.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	union := &Set[T]{values: make(map[T]struct{}, len(s.values)+len(other.values))}
+	for value := range s.values {
+		union.values[value] = struct{}{}
+	}
+	for value := range other.values {
+		union.values[value] = struct{}{}
+	}
+	return union
+}
+
+// Intersection returns a new Set holding the values in both the Set and other.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	smaller, larger := s, other
+	if len(larger.values) < len(smaller.values) {
+		smaller, larger = larger, smaller
+	}
+	intersection := New[T]()
+	for value := range smaller.values {
+		if larger.Contains(value) {
+			intersection.values[value] = struct{}{}
+		}
+	}
+	return intersection
+}
+
+// Difference returns a new Set holding the values in the Set but not in other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	difference := New[T]()
+	for value := range s.values {
+		if !other.Contains(value) {
+			difference.values[value] = struct{}{}
+		}
+	}
+	return difference
+}
+
+// SymmetricDifference returns a new Set holding the values in exactly one of the Set and other.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	difference := s.Difference(other)
+	for value := range other.values {
+		if !s.Contains(value) {
+			difference.values[value] = struct{}{}
+		}
+	}
+	return difference
+}
+
+// IsSubset reports whether every value in the Set is also in other.
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if len(s.values) > len(other.values) {
+		return false
+	}
+	for value := range s.values {
+		if !other.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether the Set and other hold the same values.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return len(s.values) == len(other.values) && s.IsSubset(other)
+}
+
+// MarshalJSON marshals the Set as a sorted array, so that equal Sets always marshal identically.  Values are sorted by
+// their dynamic type and then within each type:  by value if its kind is ordered, i.e. numbers and strings, and
+// otherwise by their encodings.  Sorting by type first keeps the order total when a Set of an interface type mixes
+// types, such as ints and floats.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	values := s.Slice()
+	encoded := make([]json.RawMessage, len(values))
+	for i, value := range values {
+		valueJSON, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = valueJSON
+	}
+	indexes := make([]int, len(values))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		a, b := indexes[i], indexes[j]
+		if c := compare(reflect.ValueOf(values[a]), reflect.ValueOf(values[b])); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(encoded[a], encoded[b]) < 0
+	})
+	sorted := make([]json.RawMessage, len(indexes))
+	for i, index := range indexes {
+		sorted[i] = encoded[index]
+	}
+	return json.Marshal(sorted)
+}
+
+// UnmarshalJSON replaces the contents of the Set with the values of the array in data, ignoring duplicates.  The Set
+// is left unchanged if data cannot be decoded.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*s = *New(values...)
+	return nil
+}
+
+// compare orders a and b by the name of their type, then by their kind, and then by value if their kind is ordered.
+// It returns 0 if a and b are not ordered by these, which leaves them to be ordered by their encodings.  An invalid
+// Value, from a nil interface, sorts first.
+func compare(a, b reflect.Value) int {
+	if !a.IsValid() || !b.IsValid() {
+		return cmp.Compare(btoi(a.IsValid()), btoi(b.IsValid()))
+	}
+	if c := cmp.Compare(a.Type().String(), b.Type().String()); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Kind(), b.Kind()); c != 0 {
+		return c
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	}
+	return 0
+}
+
+// btoi returns 1 for true and 0 for false.
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,115 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package set_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/set"
+)
+
+// sorted returns the values of s in ascending order.
+func sorted(s *set.Set[int]) []int {
+	values := s.Slice()
+	slices.Sort(values)
+	return values
+}
+
+func TestSet(t *testing.T) {
+	var s set.Set[string]
+	if !s.Add("apple") || s.Add("apple") {
+		t.Fatalf("Expected to add %s once", "apple")
+	}
+	s.Add("banana")
+	if !s.Contains("apple") || s.Contains("carrot") {
+		t.Fatalf("Unexpected membership")
+	}
+	if !s.Remove("apple") || s.Remove("apple") {
+		t.Fatalf("Expected to remove %s once", "apple")
+	}
+	if s.Len() != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, s.Len())
+	}
+	for value := range s.All() {
+		if value != "banana" {
+			t.Fatalf("Expected: %s Actual: %s", "banana", value)
+		}
+	}
+}
+
+func TestSet_Algebra(t *testing.T) {
+	a := set.New(1, 2, 3, 4, 4)
+	b := set.New(3, 4, 5)
+	cases := []struct {
+		name     string
+		actual   *set.Set[int]
+		expected []int
+	}{
+		{"Union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"Intersection", a.Intersection(b), []int{3, 4}},
+		{"Difference", a.Difference(b), []int{1, 2}},
+		{"SymmetricDifference", a.SymmetricDifference(b), []int{1, 2, 5}},
+	}
+	for _, c := range cases {
+		if actual := sorted(c.actual); !slices.Equal(c.expected, actual) {
+			t.Fatalf("%s: Expected: %v Actual: %v", c.name, c.expected, actual)
+		}
+	}
+	if a.Len() != 4 || b.Len() != 3 {
+		t.Fatalf("Expected the operands to be unchanged")
+	}
+	if !set.New(3, 4).IsSubset(a) || b.IsSubset(a) || !set.New[int]().IsSubset(b) {
+		t.Fatalf("Unexpected subsets")
+	}
+	if !a.Union(b).Equal(b.Union(a)) || a.Equal(b) {
+		t.Fatalf("Unexpected equality")
+	}
+}
+
+func TestSet_JSON(t *testing.T) {
+	s := set.New(10, 9, -1, 100)
+	jsonBytes, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	if string(jsonBytes) != "[-1,9,10,100]" {
+		t.Fatalf("Expected: %s Actual: %s", "[-1,9,10,100]", jsonBytes)
+	}
+	var restored set.Set[int]
+	if err := json.Unmarshal([]byte("[3,1,3,2]"), &restored); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	if expected, actual := []int{1, 2, 3}, sorted(&restored); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	mixed := set.New[any]("apple", 2, 1.5, true, nil)
+	jsonBytes, err = json.Marshal(mixed)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	if string(jsonBytes) != `[null,true,1.5,2,"apple"]` {
+		t.Fatalf("Expected: %s Actual: %s", `[null,true,1.5,2,"apple"]`, jsonBytes)
+	}
+	// Mixed numeric types must still sort by a total order, or the output would depend on map iteration order.
+	for i := 0; i < 20; i++ {
+		jsonBytes, err = json.Marshal(set.New[any](9, 10, 2.5))
+		if err != nil {
+			t.Fatalf("Unexpected error marshaling JSON: %s", err)
+		}
+		if string(jsonBytes) != "[2.5,9,10]" {
+			t.Fatalf("Expected: %s Actual: %s", "[2.5,9,10]", jsonBytes)
+		}
+	}
+}
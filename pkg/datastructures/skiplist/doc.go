@@ -0,0 +1,20 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package skiplist implements a generic SkipList that maps ordered keys to values.  A SkipList is a sorted linked list
+with randomly chosen express lanes, which gives the expected O(log n) operations of a balanced tree without any
+rebalancing.  The random source can be seeded so that the shape of a SkipList, and thus its performance, is
+reproducible in tests.
+*/
+
+package skiplist
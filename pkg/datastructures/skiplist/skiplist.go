@@ -0,0 +1,229 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skiplist
+
+import (
+	"cmp"
+	"iter"
+	"math/rand"
+	"time"
+)
+
+const (
+	maxLevel = 32 // Enough levels for 4^32 keys.
+	branch   = 4  // Each level holds about 1 in branch of the keys of the level below.
+)
+
+// A SkipList maps keys to values, keeping the keys sorted.  Insert, Search and Delete are O(log n) expected.
+type SkipList[K, V any] struct {
+	head    node[K, V] // A sentinel whose next pointers start every level.
+	level   int        // The number of levels in use.
+	size    int
+	compare func(a, b K) int
+	rand    *rand.Rand
+}
+
+// node is a node of the SkipList, which appears in len(next) levels.
+type node[K, V any] struct {
+	key   K
+	value V
+	next  []*node[K, V]
+}
+
+// An Option configures a SkipList constructed by New or NewFunc.
+type Option func(*options)
+
+// options holds the configuration set by Options.
+type options struct {
+	seed int64
+}
+
+// WithSeed seeds the random source that chooses the levels of new keys, making the shape of the SkipList
+// deterministic.  By default the source is seeded from the current time.
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// New returns an empty SkipList with keys in ascending order.
+func New[K cmp.Ordered, V any](opts ...Option) *SkipList[K, V] {
+	return NewFunc[K, V](cmp.Compare[K], opts...)
+}
+
+// NewFunc returns an empty SkipList with keys ordered by compare, which returns a negative number when a sorts before b,
+// a positive number when a sorts after b and zero when they are equal, as in slices.SortFunc.
+func NewFunc[K, V any](compare func(a, b K) int, opts ...Option) *SkipList[K, V] {
+	o := options{seed: time.Now().UnixNano()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &SkipList[K, V]{
+		head:    node[K, V]{next: make([]*node[K, V], maxLevel)},
+		level:   1,
+		compare: compare,
+		rand:    rand.New(rand.NewSource(o.seed)),
+	}
+}
+
+// Len returns the number of keys in the SkipList.
+func (s *SkipList[K, V]) Len() int {
+	return s.size
+}
+
+// Insert sets the value of key, adding key if it is not already in the SkipList.  It returns true if key was added.
+func (s *SkipList[K, V]) Insert(key K, value V) bool {
+	var update [maxLevel]*node[K, V]
+	if n := s.find(key, &update); n != nil {
+		n.value = value
+		return false
+	}
+	level := s.randomLevel()
+	for ; s.level < level; s.level++ {
+		update[s.level] = &s.head
+	}
+	n := &node[K, V]{key: key, value: value, next: make([]*node[K, V], level)}
+	for i := 0; i < level; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+	s.size++
+	return true
+}
+
+// Search returns the value of key.  The second return value is false if key is not in the SkipList.
+func (s *SkipList[K, V]) Search(key K) (V, bool) {
+	if n := s.find(key, nil); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is in the SkipList.
+func (s *SkipList[K, V]) Contains(key K) bool {
+	return s.find(key, nil) != nil
+}
+
+// Delete removes key from the SkipList.  It returns false if key was not in the SkipList.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	var update [maxLevel]*node[K, V]
+	n := s.find(key, &update)
+	if n == nil {
+		return false
+	}
+	for i := range n.next {
+		update[i].next[i] = n.next[i]
+		n.next[i] = nil // avoid memory leak
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.size--
+	return true
+}
+
+// Min returns the smallest key in the SkipList and its value.  The third return value is false if the SkipList is
+// empty.
+func (s *SkipList[K, V]) Min() (K, V, bool) {
+	return s.head.next[0].get()
+}
+
+// Max returns the largest key in the SkipList and its value.  The third return value is false if the SkipList is
+// empty.
+func (s *SkipList[K, V]) Max() (K, V, bool) {
+	var last *node[K, V]
+	x := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+		last = x
+	}
+	if last == &s.head {
+		last = nil
+	}
+	return last.get()
+}
+
+// All returns an iterator over the keys and values of the SkipList in ascending order of key.  The SkipList must not be
+// modified during iteration.
+func (s *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := s.head.next[0]; n != nil; n = n.next[0] {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the keys k of the SkipList with from <= k < to and their values, in ascending order.
+// Finding from is O(log n) expected.  The SkipList must not be modified during iteration.
+func (s *SkipList[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := s.seek(from); n != nil && s.compare(n.key, to) < 0; n = n.next[0] {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// seek returns the first node whose key is not less than key, or nil if there is none.
+func (s *SkipList[K, V]) seek(key K) *node[K, V] {
+	x := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.compare(x.next[i].key, key) < 0 {
+			x = x.next[i]
+		}
+	}
+	return x.next[0]
+}
+
+// find returns the node with key, or nil if key is not in the SkipList.  If update is not nil, it records the last node
+// before key on each level in use.
+func (s *SkipList[K, V]) find(key K, update *[maxLevel]*node[K, V]) *node[K, V] {
+	x := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.compare(x.next[i].key, key) < 0 {
+			x = x.next[i]
+		}
+		if update != nil {
+			update[i] = x
+		}
+	}
+	if n := x.next[0]; n != nil && s.compare(n.key, key) == 0 {
+		return n
+	}
+	return nil
+}
+
+// randomLevel returns the number of levels for a new node, which is l with probability (1 - 1/branch) / branch^(l-1).
+func (s *SkipList[K, V]) randomLevel() int {
+	level := 1
+	for level < maxLevel && s.rand.Intn(branch) == 0 {
+		level++
+	}
+	return level
+}
+
+// get returns the key and value of n, or false if n is nil.
+func (n *node[K, V]) get() (K, V, bool) {
+	if n == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
@@ -0,0 +1,95 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skiplist_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/skiplist"
+)
+
+func TestSkipList(t *testing.T) {
+	s := skiplist.New[int, string](skiplist.WithSeed(1))
+	if _, _, ok := s.Max(); ok {
+		t.Fatalf("Expected an empty SkipList to have no Max")
+	}
+	for _, key := range []int{30, 10, 50, 20, 40} {
+		if !s.Insert(key, "v") {
+			t.Fatalf("Expected to add %d", key)
+		}
+	}
+	if s.Insert(20, "twenty") || s.Len() != 5 {
+		t.Fatalf("Expected Insert of an existing key to replace its value")
+	}
+	if value, ok := s.Search(20); !ok || value != "twenty" {
+		t.Fatalf("Expected: %s Actual: %s", "twenty", value)
+	}
+	if s.Contains(25) {
+		t.Fatalf("Expected %d to be absent", 25)
+	}
+	if key, _, _ := s.Min(); key != 10 {
+		t.Fatalf("Expected: %d Actual: %d", 10, key)
+	}
+	if key, _, _ := s.Max(); key != 50 {
+		t.Fatalf("Expected: %d Actual: %d", 50, key)
+	}
+	var keys []int
+	for key := range s.Range(15, 40) {
+		keys = append(keys, key)
+	}
+	if expected := []int{20, 30}; !slices.Equal(expected, keys) {
+		t.Fatalf("Expected: %v Actual: %v", expected, keys)
+	}
+	if !s.Delete(50) || s.Delete(50) {
+		t.Fatalf("Expected to delete %d once", 50)
+	}
+	if key, _, _ := s.Max(); key != 40 {
+		t.Fatalf("Expected: %d Actual: %d", 40, key)
+	}
+}
+
+func TestSkipList_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := skiplist.New[int, int](skiplist.WithSeed(1))
+	reference := map[int]int{}
+	for i := 0; i < 20000; i++ {
+		key := r.Intn(2000)
+		if r.Intn(2) == 0 {
+			_, ok := reference[key]
+			if s.Delete(key) != ok {
+				t.Fatalf("Delete(%d) disagreed with the reference", key)
+			}
+			delete(reference, key)
+		} else {
+			s.Insert(key, i)
+			reference[key] = i
+		}
+	}
+	var expected []int
+	for key := range reference {
+		expected = append(expected, key)
+	}
+	slices.Sort(expected)
+	var actual []int
+	for key, value := range s.All() {
+		if reference[key] != value {
+			t.Fatalf("Expected: %d Actual: %d", reference[key], value)
+		}
+		actual = append(actual, key)
+	}
+	if !slices.Equal(expected, actual) || s.Len() != len(expected) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,337 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sortedset
+
+import (
+	"cmp"
+	"iter"
+)
+
+// A SortedSet is a collection of distinct values ordered by a comparator.  It is backed by an AVL tree whose nodes
+// record the size of their subtree, so Add, Remove, Contains, Floor, Ceiling, Rank and Select are all O(log n).
+type SortedSet[T any] struct {
+	root    *node[T]
+	compare func(a, b T) int
+}
+
+// node is a node of the AVL tree.
+type node[T any] struct {
+	value       T
+	left, right *node[T]
+	height      int // The height of the subtree rooted at the node; a leaf has height 1.
+	size        int // The number of nodes in the subtree rooted at the node.
+}
+
+// New returns an empty SortedSet of values in ascending order.
+func New[T cmp.Ordered]() *SortedSet[T] {
+	return NewFunc(cmp.Compare[T])
+}
+
+// NewFunc returns an empty SortedSet ordered by compare, which returns a negative number when a sorts before b, a
+// positive number when a sorts after b and zero when they are equal, as in slices.SortFunc.
+func NewFunc[T any](compare func(a, b T) int) *SortedSet[T] {
+	return &SortedSet[T]{compare: compare}
+}
+
+// Len returns the number of values in the SortedSet.
+func (s *SortedSet[T]) Len() int {
+	return s.root.len()
+}
+
+// Add adds value to the SortedSet.  It returns false if an equal value was already in the SortedSet.
+func (s *SortedSet[T]) Add(value T) bool {
+	var added bool
+	s.root = s.insert(s.root, value, &added)
+	return added
+}
+
+// Remove removes the value equal to value from the SortedSet.  It returns false if there was no such value.
+func (s *SortedSet[T]) Remove(value T) bool {
+	var removed bool
+	s.root = s.delete(s.root, value, &removed)
+	return removed
+}
+
+// Contains reports whether a value equal to value is in the SortedSet.
+func (s *SortedSet[T]) Contains(value T) bool {
+	n := s.root
+	for n != nil {
+		c := s.compare(value, n.value)
+		if c == 0 {
+			return true
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return false
+}
+
+// Min returns the smallest value in the SortedSet.  The second return value is false if the SortedSet is empty.
+func (s *SortedSet[T]) Min() (T, bool) {
+	if s.root == nil {
+		var zero T
+		return zero, false
+	}
+	return s.root.min().value, true
+}
+
+// Max returns the largest value in the SortedSet.  The second return value is false if the SortedSet is empty.
+func (s *SortedSet[T]) Max() (T, bool) {
+	if s.root == nil {
+		var zero T
+		return zero, false
+	}
+	n := s.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.value, true
+}
+
+// Floor returns the largest value in the SortedSet that is less than or equal to value.  The second return value is
+// false if there is no such value.
+func (s *SortedSet[T]) Floor(value T) (T, bool) {
+	var floor *node[T]
+	for n := s.root; n != nil; {
+		c := s.compare(value, n.value)
+		if c == 0 {
+			return n.value, true
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			floor, n = n, n.right
+		}
+	}
+	return floor.get()
+}
+
+// Ceiling returns the smallest value in the SortedSet that is greater than or equal to value.  The second return value
+// is false if there is no such value.
+func (s *SortedSet[T]) Ceiling(value T) (T, bool) {
+	var ceiling *node[T]
+	for n := s.root; n != nil; {
+		c := s.compare(value, n.value)
+		if c == 0 {
+			return n.value, true
+		}
+		if c < 0 {
+			ceiling, n = n, n.left
+		} else {
+			n = n.right
+		}
+	}
+	return ceiling.get()
+}
+
+// Rank returns the number of values in the SortedSet that are less than value, which is the index value has, or would
+// have, in ascending order.
+func (s *SortedSet[T]) Rank(value T) int {
+	rank := 0
+	for n := s.root; n != nil; {
+		c := s.compare(value, n.value)
+		if c <= 0 {
+			if c == 0 {
+				return rank + n.left.len()
+			}
+			n = n.left
+		} else {
+			rank += n.left.len() + 1
+			n = n.right
+		}
+	}
+	return rank
+}
+
+// Select returns the value at index i in ascending order, so Select(0) is the Min.  The second return value is false if
+// i is out of range.
+func (s *SortedSet[T]) Select(i int) (T, bool) {
+	if i < 0 || i >= s.Len() {
+		var zero T
+		return zero, false
+	}
+	n := s.root
+	for {
+		left := n.left.len()
+		switch {
+		case i < left:
+			n = n.left
+		case i > left:
+			i -= left + 1
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+}
+
+// All returns an iterator over the values of the SortedSet in ascending order.  The SortedSet must not be modified
+// during iteration.
+func (s *SortedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.root.walk(nil, nil, yield)
+	}
+}
+
+// Range returns an iterator over the values v of the SortedSet with from <= v < to, in ascending order.  The SortedSet
+// must not be modified during iteration.
+func (s *SortedSet[T]) Range(from, to T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		lower := func(v T) bool { return s.compare(v, from) >= 0 }
+		upper := func(v T) bool { return s.compare(v, to) < 0 }
+		s.root.walk(lower, upper, yield)
+	}
+}
+
+// insert adds value to the subtree rooted at n and returns its new, rebalanced root.
+func (s *SortedSet[T]) insert(n *node[T], value T, added *bool) *node[T] {
+	if n == nil {
+		*added = true
+		return &node[T]{value: value, height: 1, size: 1}
+	}
+	c := s.compare(value, n.value)
+	switch {
+	case c < 0:
+		n.left = s.insert(n.left, value, added)
+	case c > 0:
+		n.right = s.insert(n.right, value, added)
+	default:
+		return n
+	}
+	return n.balance()
+}
+
+// delete removes value from the subtree rooted at n and returns its new, rebalanced root.
+func (s *SortedSet[T]) delete(n *node[T], value T, removed *bool) *node[T] {
+	if n == nil {
+		return nil
+	}
+	c := s.compare(value, n.value)
+	switch {
+	case c < 0:
+		n.left = s.delete(n.left, value, removed)
+	case c > 0:
+		n.right = s.delete(n.right, value, removed)
+	default:
+		*removed = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		successor := n.right.min()
+		n.value = successor.value
+		n.right = s.delete(n.right, successor.value, new(bool))
+	}
+	return n.balance()
+}
+
+// walk yields the values of the subtree rooted at n in order, skipping subtrees that are wholly below lower or above
+// upper, where a nil bound is unbounded.  It returns false if yield asked to stop.
+func (n *node[T]) walk(lower, upper func(T) bool, yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	inLower := lower == nil || lower(n.value)
+	inUpper := upper == nil || upper(n.value)
+	if inLower && !n.left.walk(lower, upper, yield) {
+		return false
+	}
+	if inLower && inUpper && !yield(n.value) {
+		return false
+	}
+	if inUpper {
+		return n.right.walk(lower, upper, yield)
+	}
+	return true
+}
+
+// get returns the value of n, or false if n is nil.
+func (n *node[T]) get() (T, bool) {
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+	return n.value, true
+}
+
+// min returns the leftmost node of the subtree rooted at n, which must not be nil.
+func (n *node[T]) min() *node[T] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// len returns the size of the subtree rooted at n, which may be nil.
+func (n *node[T]) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// depth returns the height of the subtree rooted at n, which may be nil.
+func (n *node[T]) depth() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// update recomputes the height and size of n from its children.
+func (n *node[T]) update() {
+	n.height = max(n.left.depth(), n.right.depth()) + 1
+	n.size = n.left.len() + n.right.len() + 1
+}
+
+// balance restores the AVL invariant at n, whose children are balanced and differ in height by at most 2, and returns
+// the new root of the subtree.
+func (n *node[T]) balance() *node[T] {
+	n.update()
+	switch skew := n.left.depth() - n.right.depth(); {
+	case skew > 1:
+		if n.left.left.depth() < n.left.right.depth() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case skew < -1:
+		if n.right.right.depth() < n.right.left.depth() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+// rotateLeft rotates the subtree rooted at n to the left and returns its new root.
+func (n *node[T]) rotateLeft() *node[T] {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.update()
+	r.update()
+	return r
+}
+
+// rotateRight rotates the subtree rooted at n to the right and returns its new root.
+func (n *node[T]) rotateRight() *node[T] {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.update()
+	l.update()
+	return l
+}
@@ -0,0 +1,126 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sortedset_test
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/sortedset"
+)
+
+func TestSortedSet(t *testing.T) {
+	s := sortedset.New[int]()
+	if _, ok := s.Min(); ok {
+		t.Fatalf("Expected an empty SortedSet to have no Min")
+	}
+	for _, value := range []int{50, 20, 80, 10, 30, 70, 90} {
+		if !s.Add(value) {
+			t.Fatalf("Expected to add %d", value)
+		}
+	}
+	if s.Add(30) || s.Len() != 7 {
+		t.Fatalf("Expected duplicates to be ignored")
+	}
+	if min, _ := s.Min(); min != 10 {
+		t.Fatalf("Expected: %d Actual: %d", 10, min)
+	}
+	if max, _ := s.Max(); max != 90 {
+		t.Fatalf("Expected: %d Actual: %d", 90, max)
+	}
+	if floor, ok := s.Floor(55); !ok || floor != 50 {
+		t.Fatalf("Expected: %d Actual: %d", 50, floor)
+	}
+	if _, ok := s.Floor(5); ok {
+		t.Fatalf("Expected no Floor below the Min")
+	}
+	if ceiling, ok := s.Ceiling(55); !ok || ceiling != 70 {
+		t.Fatalf("Expected: %d Actual: %d", 70, ceiling)
+	}
+	if _, ok := s.Ceiling(95); ok {
+		t.Fatalf("Expected no Ceiling above the Max")
+	}
+	if rank := s.Rank(50); rank != 3 {
+		t.Fatalf("Expected: %d Actual: %d", 3, rank)
+	}
+	if rank := s.Rank(75); rank != 5 {
+		t.Fatalf("Expected: %d Actual: %d", 5, rank)
+	}
+	if value, ok := s.Select(5); !ok || value != 80 {
+		t.Fatalf("Expected: %d Actual: %d", 80, value)
+	}
+	if _, ok := s.Select(7); ok {
+		t.Fatalf("Expected Select out of range to fail")
+	}
+	if expected, actual := []int{20, 30, 50, 70}, slices.Collect(s.Range(15, 80)); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if !s.Remove(50) || s.Remove(50) || s.Contains(50) {
+		t.Fatalf("Expected to remove %d once", 50)
+	}
+	if expected, actual := []int{10, 20, 30, 70, 80, 90}, slices.Collect(s.All()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
+
+func TestSortedSet_Func(t *testing.T) {
+	s := sortedset.NewFunc(strings.Compare)
+	for _, value := range []string{"pear", "apple", "fig"} {
+		s.Add(value)
+	}
+	for value := range s.All() {
+		if value != "apple" {
+			t.Fatalf("Expected: %s Actual: %s", "apple", value)
+		}
+		break
+	}
+}
+
+func TestSortedSet_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := sortedset.New[int]()
+	reference := map[int]bool{}
+	for i := 0; i < 5000; i++ {
+		value := r.Intn(500)
+		if r.Intn(3) == 0 {
+			if s.Remove(value) != reference[value] {
+				t.Fatalf("Remove(%d) disagreed with the reference", value)
+			}
+			delete(reference, value)
+		} else {
+			if s.Add(value) == reference[value] {
+				t.Fatalf("Add(%d) disagreed with the reference", value)
+			}
+			reference[value] = true
+		}
+	}
+	var expected []int
+	for value := range reference {
+		expected = append(expected, value)
+	}
+	sort.Ints(expected)
+	if actual := slices.Collect(s.All()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	for i, value := range expected {
+		if rank := s.Rank(value); rank != i {
+			t.Fatalf("Expected: %d Actual: %d", i, rank)
+		}
+		if selected, _ := s.Select(i); selected != value {
+			t.Fatalf("Expected: %d Actual: %d", value, selected)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import "encoding/json"
+
+// A Stack is a last-in, first-out collection of values.  Push and Pop are amortized O(1).  The zero value is an empty
+// Stack ready to use.
+type Stack[T any] struct {
+	values []T // The bottom of the Stack is first and the top is last.
+}
+
+// New returns an empty Stack.
+func New[T any]() *Stack[T] {
+	return &Stack[T]{}
+}
+
+// Len returns the number of values on the Stack.
+func (s *Stack[T]) Len() int {
+	return len(s.values)
+}
+
+// IsEmpty reports whether the Stack holds no values.
+func (s *Stack[T]) IsEmpty() bool {
+	return len(s.values) == 0
+}
+
+// Push adds value to the top of the Stack.
+func (s *Stack[T]) Push(value T) {
+	s.values = append(s.values, value)
+}
+
+// Pop removes and returns the value at the top of the Stack.  The second return value is false if the Stack is empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	n := len(s.values)
+	if n == 0 {
+		return zero, false
+	}
+	value := s.values[n-1]
+	s.values[n-1] = zero // avoid memory leak
+	s.values = s.values[:n-1]
+	return value, true
+}
+
+// Peek returns the value at the top of the Stack without removing it.  The second return value is false if the Stack
+// is empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	if len(s.values) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.values[len(s.values)-1], true
+}
+
+// MarshalJSON marshals the Stack as an array in Pop order, i.e. from the top of the Stack to the bottom, as the
+// priorityqueue package marshals in Pop order.  The Stack is not modified.
+func (s *Stack[T]) MarshalJSON() ([]byte, error) {
+	values := make([]T, len(s.values))
+	for i, value := range s.values {
+		values[len(values)-1-i] = value
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON replaces the contents of the Stack with the values in data, which must be an array in the Pop order
+// produced by MarshalJSON.  The Stack is left unchanged if data cannot be decoded.
+func (s *Stack[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	s.values = values
+	return nil
+}
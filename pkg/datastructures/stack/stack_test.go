@@ -0,0 +1,74 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/stack"
+)
+
+func TestStack(t *testing.T) {
+	s := stack.New[string]()
+	if _, ok := s.Pop(); ok {
+		t.Fatalf("Expected an empty Stack")
+	}
+	if _, ok := s.Peek(); ok {
+		t.Fatalf("Expected an empty Stack")
+	}
+	for _, value := range []string{"apple", "banana", "carrot", "danish"} {
+		s.Push(value)
+	}
+	if s.Len() != 4 || s.IsEmpty() {
+		t.Fatalf("Expected: %d Actual: %d", 4, s.Len())
+	}
+	if value, _ := s.Peek(); value != "danish" {
+		t.Fatalf("Expected: %s Actual: %s", "danish", value)
+	}
+	expectedPopOrder := []string{"danish", "carrot", "banana", "apple"}
+	for _, expected := range expectedPopOrder {
+		if actual, ok := s.Pop(); !ok || expected != actual {
+			t.Fatalf("Expected: %s Actual: %s", expected, actual)
+		}
+	}
+	if !s.IsEmpty() {
+		t.Fatalf("Expected: %d Actual: %d", 0, s.Len())
+	}
+}
+
+func TestStack_JSON(t *testing.T) {
+	var s stack.Stack[int]
+	for i := 1; i <= 3; i++ {
+		s.Push(i)
+	}
+	jsonBytes, err := json.Marshal(&s)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling JSON: %s", err)
+	}
+	if string(jsonBytes) != "[3,2,1]" {
+		t.Fatalf("Expected: %s Actual: %s", "[3,2,1]", jsonBytes)
+	}
+	var restored stack.Stack[int]
+	if err := json.Unmarshal(jsonBytes, &restored); err != nil {
+		t.Fatalf("Unexpected error unmarshaling JSON: %s", err)
+	}
+	for expected := 3; expected >= 1; expected-- {
+		if actual, _ := restored.Pop(); expected != actual {
+			t.Fatalf("Expected: %d Actual: %d", expected, actual)
+		}
+	}
+	if err := restored.UnmarshalJSON([]byte(`["apple"]`)); err == nil {
+		t.Fatalf("Expected an error unmarshaling strings into a Stack of ints")
+	}
+}
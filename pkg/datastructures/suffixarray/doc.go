@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package suffixarray implements a suffix array Index over a byte slice.  Once built, an Index finds every occurrence of a
+substring in O(len(s) log n) plus the number of occurrences, and it can be marshaled so that a large Index is built once
+and reused across process restarts.  Unlike index/suffixarray it returns occurrences in ascending order.
+*/
+
+package suffixarray
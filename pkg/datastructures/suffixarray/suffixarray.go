@@ -0,0 +1,198 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package suffixarray
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"sort"
+)
+
+// binaryVersion is the version of the encoding produced by MarshalBinary, written as its first byte.
+const binaryVersion = 1
+
+// An Index is a suffix array over some data:  the start of every suffix of the data, sorted so that the suffixes are in
+// lexicographic order.  The suffixes beginning with a given substring are then adjacent and can be found by binary
+// search.
+type Index struct {
+	data []byte
+	sa   []int
+}
+
+// New builds an Index over data in O(n log n) by prefix doubling, which sorts the suffixes by their first 2^k bytes for
+// increasing k using a radix sort of the ranks found in the previous round.  The Index keeps data, which must not be
+// modified afterwards.
+func New(data []byte) *Index {
+	return &Index{data: data, sa: build(data)}
+}
+
+// Bytes returns the data over which the Index was built.  It must not be modified.
+func (x *Index) Bytes() []byte {
+	return x.data
+}
+
+// Lookup returns the positions of up to n occurrences of s in the data, in ascending order, or of every occurrence if n
+// is negative.  It returns nil if s is empty or n is zero.
+func (x *Index) Lookup(s []byte, n int) []int {
+	if len(s) == 0 || n == 0 {
+		return nil
+	}
+	lo, hi := x.find(s)
+	if lo == hi {
+		return nil
+	}
+	positions := slices.Clone(x.sa[lo:hi])
+	slices.Sort(positions)
+	if n >= 0 && n < len(positions) {
+		positions = positions[:n]
+	}
+	return positions
+}
+
+// Count returns the number of occurrences of s in the data in O(len(s) log n), or 0 if s is empty.
+func (x *Index) Count(s []byte) int {
+	if len(s) == 0 {
+		return 0
+	}
+	lo, hi := x.find(s)
+	return hi - lo
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The encoding holds the data and the sorted suffixes as varints,
+// so UnmarshalBinary restores the Index in O(n) without sorting it again.
+func (x *Index) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 1+binary.MaxVarintLen64+len(x.data)+2*len(x.sa))
+	b = append(b, binaryVersion)
+	b = binary.AppendUvarint(b, uint64(len(x.data)))
+	b = append(b, x.data...)
+	for _, position := range x.sa {
+		b = binary.AppendUvarint(b, uint64(position))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler by replacing the Index with the one encoded in data by
+// MarshalBinary.  It checks that the suffixes form a permutation of the data, but not that they are sorted.  The Index is
+// left unchanged if data cannot be decoded.
+func (x *Index) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return errors.New("suffixarray: unsupported binary encoding")
+	}
+	corrupt := errors.New("suffixarray: corrupt binary encoding")
+	data = data[1:]
+	length, k := binary.Uvarint(data)
+	if k <= 0 || length > uint64(len(data)-k) {
+		return corrupt
+	}
+	n := int(length)
+	text := slices.Clone(data[k : k+n])
+	data = data[k+n:]
+	sa := make([]int, n)
+	seen := make([]bool, n)
+	for i := range sa {
+		position, k := binary.Uvarint(data)
+		if k <= 0 || position >= length || seen[position] {
+			return corrupt
+		}
+		sa[i], seen[position] = int(position), true
+		data = data[k:]
+	}
+	if len(data) != 0 {
+		return corrupt
+	}
+	x.data, x.sa = text, sa
+	return nil
+}
+
+// find returns the range of sa holding the suffixes that start with s.
+func (x *Index) find(s []byte) (int, int) {
+	lo := sort.Search(len(x.sa), func(i int) bool { return bytes.Compare(x.data[x.sa[i]:], s) >= 0 })
+	hi := lo + sort.Search(len(x.sa)-lo, func(i int) bool { return !bytes.HasPrefix(x.data[x.sa[lo+i]:], s) })
+	return lo, hi
+}
+
+// build returns the suffix array of data.
+func build(data []byte) []int {
+	n := len(data)
+	sa := make([]int, n)
+	rank := make([]int, n)
+	next := make([]int, n)
+	counts := make([]int, max(n, 256))
+	// Sort the suffixes by their first byte, which is also their initial rank.
+	for i, b := range data {
+		rank[i] = int(b)
+		counts[b]++
+	}
+	sum(counts)
+	for i := n - 1; i >= 0; i-- {
+		counts[data[i]]--
+		sa[counts[data[i]]] = i
+	}
+	for k := 1; k < n; k <<= 1 {
+		// Order the suffixes by the rank of their second half:  those too short to have one come first, then the rest
+		// in the order of the suffixes starting at their second half, which sa already holds.
+		p := 0
+		for i := n - k; i < n; i++ {
+			next[p] = i
+			p++
+		}
+		for _, i := range sa {
+			if i >= k {
+				next[p] = i - k
+				p++
+			}
+		}
+		// Stably sort by the rank of the first half to order by both.
+		clear(counts)
+		for _, i := range next {
+			counts[rank[i]]++
+		}
+		sum(counts)
+		for j := n - 1; j >= 0; j-- {
+			i := next[j]
+			counts[rank[i]]--
+			sa[counts[rank[i]]] = i
+		}
+		// Rank the suffixes by their first 2k bytes.
+		second := func(i int) int {
+			if i+k < n {
+				return rank[i+k]
+			}
+			return -1
+		}
+		next[sa[0]] = 0
+		for j := 1; j < n; j++ {
+			a, b := sa[j-1], sa[j]
+			next[b] = next[a]
+			if rank[a] != rank[b] || second(a) != second(b) {
+				next[b]++
+			}
+		}
+		rank, next = next, rank
+		if rank[sa[n-1]] == n-1 {
+			break // Every suffix has a distinct rank, so sa is sorted.
+		}
+	}
+	return sa
+}
+
+// sum turns counts into the index at which each key's run ends, as in a counting sort.
+func sum(counts []int) {
+	total := 0
+	for i, count := range counts {
+		total += count
+		counts[i] = total
+	}
+}
@@ -0,0 +1,118 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package suffixarray_test
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/suffixarray"
+)
+
+// occurrences returns every position of s in data, found by brute force.
+func occurrences(data, s []byte) []int {
+	var positions []int
+	for i := range data {
+		if bytes.HasPrefix(data[i:], s) {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+func TestIndex_Lookup(t *testing.T) {
+	x := suffixarray.New([]byte("mississippi"))
+	cases := []struct {
+		s        string
+		expected []int
+	}{
+		{"issi", []int{1, 4}},
+		{"i", []int{1, 4, 7, 10}},
+		{"ss", []int{2, 5}},
+		{"mississippi", []int{0}},
+		{"missi", []int{0}},
+		{"x", nil},
+		{"ippix", nil},
+		{"", nil},
+	}
+	for _, c := range cases {
+		if actual := x.Lookup([]byte(c.s), -1); !slices.Equal(c.expected, actual) {
+			t.Fatalf("Expected: %v Actual: %v", c.expected, actual)
+		}
+		if count := x.Count([]byte(c.s)); count != len(c.expected) {
+			t.Fatalf("Expected: %d Actual: %d", len(c.expected), count)
+		}
+	}
+	if expected, actual := []int{1, 4}, x.Lookup([]byte("i"), 2); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
+
+func TestIndex_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 10, 1000} {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = "ab"[r.Intn(2)]
+		}
+		x := suffixarray.New(data)
+		for i := 0; i < 50 && n > 0; i++ {
+			start := r.Intn(n)
+			s := data[start:min(n, start+1+r.Intn(8))]
+			if expected, actual := occurrences(data, s), x.Lookup(s, -1); !slices.Equal(expected, actual) {
+				t.Fatalf("Expected: %v Actual: %v", expected, actual)
+			}
+		}
+	}
+}
+
+func TestIndex_Repetitive(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 500)
+	x := suffixarray.New(data)
+	if count := x.Count(data[:250]); count != 251 {
+		t.Fatalf("Expected: %d Actual: %d", 251, count)
+	}
+	if expected, actual := []int{0, 1}, x.Lookup(data[:499], -1); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}
+
+func TestIndex_Binary(t *testing.T) {
+	x := suffixarray.New([]byte("abracadabra"))
+	b, err := x.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling: %s", err)
+	}
+	var restored suffixarray.Index
+	if err := restored.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Unexpected error unmarshaling: %s", err)
+	}
+	if string(restored.Bytes()) != "abracadabra" {
+		t.Fatalf("Expected: %s Actual: %s", "abracadabra", restored.Bytes())
+	}
+	if expected, actual := []int{0, 7}, restored.Lookup([]byte("abra"), -1); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	for _, corrupt := range [][]byte{nil, {2}, b[:len(b)-1], append(slices.Clone(b), 0)} {
+		if err := restored.UnmarshalBinary(corrupt); err == nil {
+			t.Fatalf("Expected an error unmarshaling %v", corrupt)
+		}
+	}
+	duplicate := slices.Clone(b)
+	duplicate[len(duplicate)-1] = duplicate[len(duplicate)-2]
+	if err := restored.UnmarshalBinary(duplicate); err == nil {
+		t.Fatalf("Expected an error unmarshaling a duplicate suffix")
+	}
+}
@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package treemap implements a generic TreeMap, a sorted map backed by a red-black tree.  Alongside the usual Put, Get and
+Remove it finds the nearest keys to a given key with Floor and Ceiling, and iterates over its keys in ascending or
+descending order, which Go's built in map cannot do.
+*/
+
+package treemap
@@ -0,0 +1,335 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treemap
+
+import (
+	"cmp"
+	"iter"
+)
+
+// The colors of the nodes of a TreeMap.
+const (
+	red   = true
+	black = false
+)
+
+// A TreeMap maps keys to values, keeping the keys sorted.  It is a left-leaning red-black tree, in which red links
+// always lean left and every path from the root to a leaf crosses the same number of black links, so Put, Get and
+// Remove are O(log n) in the worst case.
+type TreeMap[K, V any] struct {
+	root    *node[K, V]
+	size    int
+	compare func(a, b K) int
+}
+
+// node is a node of the TreeMap.
+type node[K, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	color       bool // The color of the link from the parent of the node.
+}
+
+// New returns an empty TreeMap with keys in ascending order.
+func New[K cmp.Ordered, V any]() *TreeMap[K, V] {
+	return NewFunc[K, V](cmp.Compare[K])
+}
+
+// NewFunc returns an empty TreeMap with keys ordered by compare, which returns a negative number when a sorts before b,
+// a positive number when a sorts after b and zero when they are equal, as in slices.SortFunc.
+func NewFunc[K, V any](compare func(a, b K) int) *TreeMap[K, V] {
+	return &TreeMap[K, V]{compare: compare}
+}
+
+// Len returns the number of keys in the TreeMap.
+func (m *TreeMap[K, V]) Len() int {
+	return m.size
+}
+
+// Put sets the value of key, adding key if it is not already in the TreeMap.  It returns true if key was added.
+func (m *TreeMap[K, V]) Put(key K, value V) bool {
+	var added bool
+	m.root = m.put(m.root, key, value, &added)
+	m.root.color = black
+	if added {
+		m.size++
+	}
+	return added
+}
+
+// Get returns the value of key.  The second return value is false if key is not in the TreeMap.
+func (m *TreeMap[K, V]) Get(key K) (V, bool) {
+	if n := m.find(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is in the TreeMap.
+func (m *TreeMap[K, V]) Contains(key K) bool {
+	return m.find(key) != nil
+}
+
+// Remove removes key from the TreeMap.  It returns false if key was not in the TreeMap.
+func (m *TreeMap[K, V]) Remove(key K) bool {
+	// The top-down deletion below assumes key is present.
+	if m.find(key) == nil {
+		return false
+	}
+	if !isRed(m.root.left) && !isRed(m.root.right) {
+		m.root.color = red
+	}
+	m.root = m.remove(m.root, key)
+	if m.root != nil {
+		m.root.color = black
+	}
+	m.size--
+	return true
+}
+
+// Min returns the smallest key in the TreeMap and its value.  The third return value is false if the TreeMap is empty.
+func (m *TreeMap[K, V]) Min() (K, V, bool) {
+	return m.root.min().get()
+}
+
+// Max returns the largest key in the TreeMap and its value.  The third return value is false if the TreeMap is empty.
+func (m *TreeMap[K, V]) Max() (K, V, bool) {
+	n := m.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n.get()
+}
+
+// Floor returns the largest key in the TreeMap that is less than or equal to key, and its value.  The third return value
+// is false if there is no such key.
+func (m *TreeMap[K, V]) Floor(key K) (K, V, bool) {
+	var floor *node[K, V]
+	for n := m.root; n != nil; {
+		c := m.compare(key, n.key)
+		if c == 0 {
+			return n.get()
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			floor, n = n, n.right
+		}
+	}
+	return floor.get()
+}
+
+// Ceiling returns the smallest key in the TreeMap that is greater than or equal to key, and its value.  The third return
+// value is false if there is no such key.
+func (m *TreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	var ceiling *node[K, V]
+	for n := m.root; n != nil; {
+		c := m.compare(key, n.key)
+		if c == 0 {
+			return n.get()
+		}
+		if c < 0 {
+			ceiling, n = n, n.left
+		} else {
+			n = n.right
+		}
+	}
+	return ceiling.get()
+}
+
+// Ascend returns an iterator over the keys and values of the TreeMap in ascending order of key.  The TreeMap must not be
+// modified during iteration.
+func (m *TreeMap[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.ascend(yield)
+	}
+}
+
+// Descend returns an iterator over the keys and values of the TreeMap in descending order of key.  The TreeMap must not
+// be modified during iteration.
+func (m *TreeMap[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.descend(yield)
+	}
+}
+
+// find returns the node with key, or nil if key is not in the TreeMap.
+func (m *TreeMap[K, V]) find(key K) *node[K, V] {
+	n := m.root
+	for n != nil {
+		c := m.compare(key, n.key)
+		if c == 0 {
+			return n
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return nil
+}
+
+// put sets key to value in the subtree rooted at h and returns its new root.
+func (m *TreeMap[K, V]) put(h *node[K, V], key K, value V, added *bool) *node[K, V] {
+	if h == nil {
+		*added = true
+		return &node[K, V]{key: key, value: value, color: red}
+	}
+	c := m.compare(key, h.key)
+	switch {
+	case c < 0:
+		h.left = m.put(h.left, key, value, added)
+	case c > 0:
+		h.right = m.put(h.right, key, value, added)
+	default:
+		h.value = value
+	}
+	return h.fixUp()
+}
+
+// remove removes key, which must be in the subtree rooted at h, and returns the new root of the subtree.  On the way
+// down it keeps the current node or its left child red, so the node finally removed is never a lone black leaf.
+func (m *TreeMap[K, V]) remove(h *node[K, V], key K) *node[K, V] {
+	if m.compare(key, h.key) < 0 {
+		if !isRed(h.left) && !isRed(h.left.left) {
+			h = h.moveRedLeft()
+		}
+		h.left = m.remove(h.left, key)
+	} else {
+		if isRed(h.left) {
+			h = h.rotateRight()
+		}
+		if m.compare(key, h.key) == 0 && h.right == nil {
+			return nil
+		}
+		if !isRed(h.right) && !isRed(h.right.left) {
+			h = h.moveRedRight()
+		}
+		if m.compare(key, h.key) == 0 {
+			successor := h.right.min()
+			h.key, h.value = successor.key, successor.value
+			h.right = h.right.removeMin()
+		} else {
+			h.right = m.remove(h.right, key)
+		}
+	}
+	return h.fixUp()
+}
+
+// isRed reports whether the link to n is red.  The nil links below the leaves are black.
+func isRed[K, V any](n *node[K, V]) bool {
+	return n != nil && n.color == red
+}
+
+// removeMin removes the smallest key from the subtree rooted at h and returns its new root.
+func (h *node[K, V]) removeMin() *node[K, V] {
+	if h.left == nil {
+		return nil
+	}
+	if !isRed(h.left) && !isRed(h.left.left) {
+		h = h.moveRedLeft()
+	}
+	h.left = h.left.removeMin()
+	return h.fixUp()
+}
+
+// rotateLeft turns the red right link of h into a left link and returns the new root of the subtree.
+func (h *node[K, V]) rotateLeft() *node[K, V] {
+	x := h.right
+	h.right, x.left = x.left, h
+	x.color, h.color = h.color, red
+	return x
+}
+
+// rotateRight turns the red left link of h into a right link and returns the new root of the subtree.
+func (h *node[K, V]) rotateRight() *node[K, V] {
+	x := h.left
+	h.left, x.right = x.right, h
+	x.color, h.color = h.color, red
+	return x
+}
+
+// flip inverts the colors of h and its children.
+func (h *node[K, V]) flip() {
+	h.color = !h.color
+	h.left.color = !h.left.color
+	h.right.color = !h.right.color
+}
+
+// moveRedLeft makes the left child of h or one of its children red, assuming h is red and both h.left and h.left.left
+// are black.
+func (h *node[K, V]) moveRedLeft() *node[K, V] {
+	h.flip()
+	if isRed(h.right.left) {
+		h.right = h.right.rotateRight()
+		h = h.rotateLeft()
+		h.flip()
+	}
+	return h
+}
+
+// moveRedRight makes the right child of h or one of its children red, assuming h is red and both h.right and
+// h.right.left are black.
+func (h *node[K, V]) moveRedRight() *node[K, V] {
+	h.flip()
+	if isRed(h.left.left) {
+		h = h.rotateRight()
+		h.flip()
+	}
+	return h
+}
+
+// fixUp restores the left-leaning red-black invariants at h on the way back up the tree and returns the new root of the
+// subtree.
+func (h *node[K, V]) fixUp() *node[K, V] {
+	if isRed(h.right) && !isRed(h.left) {
+		h = h.rotateLeft()
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = h.rotateRight()
+	}
+	if isRed(h.left) && isRed(h.right) {
+		h.flip()
+	}
+	return h
+}
+
+// get returns the key and value of n, or false if n is nil.
+func (n *node[K, V]) get() (K, V, bool) {
+	if n == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
+
+// min returns the leftmost node of the subtree rooted at n, or nil if n is nil.
+func (n *node[K, V]) min() *node[K, V] {
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// ascend yields the subtree rooted at n in ascending order.  It returns false if yield asked to stop.
+func (n *node[K, V]) ascend(yield func(K, V) bool) bool {
+	return n == nil || n.left.ascend(yield) && yield(n.key, n.value) && n.right.ascend(yield)
+}
+
+// descend yields the subtree rooted at n in descending order.  It returns false if yield asked to stop.
+func (n *node[K, V]) descend(yield func(K, V) bool) bool {
+	return n == nil || n.right.descend(yield) && yield(n.key, n.value) && n.left.descend(yield)
+}
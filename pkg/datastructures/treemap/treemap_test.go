@@ -0,0 +1,118 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treemap_test
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/treemap"
+)
+
+func TestTreeMap(t *testing.T) {
+	m := treemap.NewFunc[string, int](strings.Compare)
+	if _, _, ok := m.Min(); ok {
+		t.Fatalf("Expected an empty TreeMap to have no Min")
+	}
+	for i, key := range []string{"delta", "alpha", "echo", "charlie"} {
+		if !m.Put(key, i) {
+			t.Fatalf("Expected to add %s", key)
+		}
+	}
+	if m.Put("alpha", 10) || m.Len() != 4 {
+		t.Fatalf("Expected Put of an existing key to replace its value")
+	}
+	if value, ok := m.Get("alpha"); !ok || value != 10 {
+		t.Fatalf("Expected: %d Actual: %d", 10, value)
+	}
+	if key, _, ok := m.Floor("bravo"); !ok || key != "alpha" {
+		t.Fatalf("Expected: %s Actual: %s", "alpha", key)
+	}
+	if key, _, ok := m.Ceiling("bravo"); !ok || key != "charlie" {
+		t.Fatalf("Expected: %s Actual: %s", "charlie", key)
+	}
+	if key, _, ok := m.Floor("delta"); !ok || key != "delta" {
+		t.Fatalf("Expected: %s Actual: %s", "delta", key)
+	}
+	if _, _, ok := m.Floor("a"); ok {
+		t.Fatalf("Expected no Floor below the Min")
+	}
+	if _, _, ok := m.Ceiling("foxtrot"); ok {
+		t.Fatalf("Expected no Ceiling above the Max")
+	}
+	if key, _, _ := m.Max(); key != "echo" {
+		t.Fatalf("Expected: %s Actual: %s", "echo", key)
+	}
+	var descending []string
+	for key := range m.Descend() {
+		descending = append(descending, key)
+	}
+	if expected := []string{"echo", "delta", "charlie", "alpha"}; !slices.Equal(expected, descending) {
+		t.Fatalf("Expected: %v Actual: %v", expected, descending)
+	}
+	if !m.Remove("charlie") || m.Remove("charlie") || m.Contains("charlie") {
+		t.Fatalf("Expected to remove %s once", "charlie")
+	}
+	for key := range m.Ascend() {
+		if key != "alpha" {
+			t.Fatalf("Expected: %s Actual: %s", "alpha", key)
+		}
+		break
+	}
+}
+
+func TestTreeMap_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := treemap.New[int, int]()
+	reference := map[int]int{}
+	for i := 0; i < 10000; i++ {
+		key := r.Intn(1000)
+		if r.Intn(2) == 0 {
+			_, ok := reference[key]
+			if m.Remove(key) != ok {
+				t.Fatalf("Remove(%d) disagreed with the reference", key)
+			}
+			delete(reference, key)
+		} else {
+			m.Put(key, i)
+			reference[key] = i
+		}
+	}
+	var expected []int
+	for key := range reference {
+		expected = append(expected, key)
+	}
+	sort.Ints(expected)
+	var actual []int
+	for key, value := range m.Ascend() {
+		if reference[key] != value {
+			t.Fatalf("Expected: %d Actual: %d", reference[key], value)
+		}
+		actual = append(actual, key)
+	}
+	if !slices.Equal(expected, actual) || m.Len() != len(expected) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	for len(expected) > 0 {
+		if !m.Remove(expected[0]) {
+			t.Fatalf("Expected to remove %d", expected[0])
+		}
+		expected = expected[1:]
+	}
+	if m.Len() != 0 {
+		t.Fatalf("Expected: %d Actual: %d", 0, m.Len())
+	}
+}
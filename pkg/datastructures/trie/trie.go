@@ -0,0 +1,173 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"iter"
+	"sort"
+)
+
+// A Trie maps string keys to values, with one node per byte of each key.  The children of a node are kept sorted by
+// byte, so Insert, Get and Delete take O(log 256) per byte of the key and keys are walked in lexicographic order.  The
+// zero value is an empty Trie ready to use.
+type Trie[V any] struct {
+	root node[V]
+	size int
+}
+
+// node is a node of the Trie, reached by the bytes of the path from the root.
+type node[V any] struct {
+	edges []edge[V] // Sorted by label.
+	value V
+	leaf  bool // Whether a key ends at the node.
+}
+
+// edge links a node to its child along the byte label.
+type edge[V any] struct {
+	label byte
+	child *node[V]
+}
+
+// New returns an empty Trie.
+func New[V any]() *Trie[V] {
+	return &Trie[V]{}
+}
+
+// Len returns the number of keys in the Trie.
+func (t *Trie[V]) Len() int {
+	return t.size
+}
+
+// Insert sets the value of key, adding key if it is not already in the Trie.  It returns true if key was added.
+func (t *Trie[V]) Insert(key string, value V) bool {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		j, ok := n.find(key[i])
+		if !ok {
+			n.edges = append(n.edges, edge[V]{})
+			copy(n.edges[j+1:], n.edges[j:])
+			n.edges[j] = edge[V]{label: key[i], child: &node[V]{}}
+		}
+		n = n.edges[j].child
+	}
+	added := !n.leaf
+	n.value, n.leaf = value, true
+	if added {
+		t.size++
+	}
+	return added
+}
+
+// Get returns the value of key.  The second return value is false if key is not in the Trie.
+func (t *Trie[V]) Get(key string) (V, bool) {
+	if n := t.walk(key); n != nil && n.leaf {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes key from the Trie, along with any nodes that no longer lead to a key.  It returns false if key was not
+// in the Trie.
+func (t *Trie[V]) Delete(key string) bool {
+	if !t.root.delete(key) {
+		return false
+	}
+	t.size--
+	return true
+}
+
+// HasPrefix reports whether any key in the Trie starts with prefix.
+func (t *Trie[V]) HasPrefix(prefix string) bool {
+	return t.walk(prefix) != nil
+}
+
+// WalkPrefix returns an iterator over the keys of the Trie that start with prefix, and their values, in lexicographic
+// order of key.  The Trie must not be modified during iteration.
+func (t *Trie[V]) WalkPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		if n := t.walk(prefix); n != nil {
+			n.each([]byte(prefix), yield)
+		}
+	}
+}
+
+// All returns an iterator over the keys and values of the Trie in lexicographic order of key.  The Trie must not be
+// modified during iteration.
+func (t *Trie[V]) All() iter.Seq2[string, V] {
+	return t.WalkPrefix("")
+}
+
+// walk returns the node reached by the bytes of path, or nil if there is none.  Every node other than the root leads to
+// a key, so a non-nil result means some key starts with path.
+func (t *Trie[V]) walk(path string) *node[V] {
+	n := &t.root
+	for i := 0; i < len(path); i++ {
+		j, ok := n.find(path[i])
+		if !ok {
+			return nil
+		}
+		n = n.edges[j].child
+	}
+	if n == &t.root && !n.leaf && len(n.edges) == 0 {
+		return nil
+	}
+	return n
+}
+
+// find returns the index of the edge of n labeled b and true, or the index at which to insert such an edge and false.
+func (n *node[V]) find(b byte) (int, bool) {
+	i := sort.Search(len(n.edges), func(i int) bool { return n.edges[i].label >= b })
+	return i, i < len(n.edges) && n.edges[i].label == b
+}
+
+// delete removes key, relative to n, from the subtree rooted at n and prunes the children left without keys.  It returns
+// false if key was not in the subtree.
+func (n *node[V]) delete(key string) bool {
+	if key == "" {
+		if !n.leaf {
+			return false
+		}
+		var zero V
+		n.value, n.leaf = zero, false // avoid memory leak
+		return true
+	}
+	i, ok := n.find(key[0])
+	if !ok {
+		return false
+	}
+	child := n.edges[i].child
+	if !child.delete(key[1:]) {
+		return false
+	}
+	if !child.leaf && len(child.edges) == 0 {
+		copy(n.edges[i:], n.edges[i+1:])
+		n.edges[len(n.edges)-1] = edge[V]{} // avoid memory leak
+		n.edges = n.edges[:len(n.edges)-1]
+	}
+	return true
+}
+
+// each yields the keys of the subtree rooted at n, which is reached by path, in lexicographic order.  It returns false if
+// yield asked to stop.
+func (n *node[V]) each(path []byte, yield func(string, V) bool) bool {
+	if n.leaf && !yield(string(path), n.value) {
+		return false
+	}
+	for _, e := range n.edges {
+		if !e.child.each(append(path, e.label), yield) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/trie"
+)
+
+// keys collects the keys of seq.
+func keys(seq func(func(string, int) bool)) []string {
+	var keys []string
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestTrie(t *testing.T) {
+	var tr trie.Trie[int]
+	if tr.HasPrefix("") {
+		t.Fatalf("Expected an empty Trie to have no keys")
+	}
+	for i, key := range []string{"tea", "ten", "to", "inn", "in", "i", "tent"} {
+		if !tr.Insert(key, i) {
+			t.Fatalf("Expected to add %s", key)
+		}
+	}
+	if tr.Insert("ten", 100) || tr.Len() != 7 {
+		t.Fatalf("Expected Insert of an existing key to replace its value")
+	}
+	if value, ok := tr.Get("ten"); !ok || value != 100 {
+		t.Fatalf("Expected: %d Actual: %d", 100, value)
+	}
+	if _, ok := tr.Get("te"); ok {
+		t.Fatalf("Expected a prefix alone not to be a key")
+	}
+	if !tr.HasPrefix("te") || tr.HasPrefix("tx") || !tr.HasPrefix("") {
+		t.Fatalf("Unexpected HasPrefix")
+	}
+	if expected, actual := []string{"tea", "ten", "tent"}, keys(tr.WalkPrefix("te")); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	expected := []string{"i", "in", "inn", "tea", "ten", "tent", "to"}
+	if actual := keys(tr.All()); !slices.Equal(expected, actual) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	if !tr.Delete("ten") || tr.Delete("ten") || tr.Delete("te") {
+		t.Fatalf("Expected to delete %s once", "ten")
+	}
+	if _, ok := tr.Get("tent"); !ok {
+		t.Fatalf("Expected %s to remain", "tent")
+	}
+	tr.Delete("tent")
+	tr.Delete("tea")
+	if tr.HasPrefix("te") {
+		t.Fatalf("Expected the path to %s to be pruned", "te")
+	}
+	if tr.Len() != 4 {
+		t.Fatalf("Expected: %d Actual: %d", 4, tr.Len())
+	}
+}
+
+func TestTrie_EmptyKey(t *testing.T) {
+	tr := trie.New[int]()
+	tr.Insert("", 1)
+	if value, ok := tr.Get(""); !ok || value != 1 {
+		t.Fatalf("Expected: %d Actual: %d", 1, value)
+	}
+	if !tr.HasPrefix("") {
+		t.Fatalf("Expected the empty key to be a prefix")
+	}
+	tr.Delete("")
+	if tr.HasPrefix("") || tr.Len() != 0 {
+		t.Fatalf("Expected an empty Trie")
+	}
+}
@@ -0,0 +1,19 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package unionfind implements disjoint sets with union by rank and path compression, which answer whether two elements
+are connected in nearly constant amortized time.  DisjointSet works on the integers 0 to n-1, while Keyed works on any
+comparable keys, as graph connectivity and clustering usually need.
+*/
+
+package unionfind
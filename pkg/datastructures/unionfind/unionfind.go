@@ -0,0 +1,176 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unionfind
+
+// A DisjointSet partitions the elements 0 to Len()-1 into disjoint sets, each represented by one of its elements.  With
+// union by rank and path compression, Find and Union take O(α(n)) amortized time, where α is the inverse Ackermann
+// function, which is at most 4 for any practical n.  The zero value is an empty DisjointSet ready to use.
+type DisjointSet struct {
+	parent []int
+	rank   []uint8 // An upper bound on the height of the tree below each root.
+	sets   int
+}
+
+// New returns a DisjointSet of the elements 0 to n-1, each in a set of its own.
+func New(n int) *DisjointSet {
+	d := &DisjointSet{parent: make([]int, n), rank: make([]uint8, n), sets: n}
+	for i := range d.parent {
+		d.parent[i] = i
+	}
+	return d
+}
+
+// Len returns the number of elements in the DisjointSet.
+func (d *DisjointSet) Len() int {
+	return len(d.parent)
+}
+
+// SetCount returns the number of disjoint sets.
+func (d *DisjointSet) SetCount() int {
+	return d.sets
+}
+
+// Add adds a new element in a set of its own and returns it.
+func (d *DisjointSet) Add() int {
+	x := len(d.parent)
+	d.parent = append(d.parent, x)
+	d.rank = append(d.rank, 0)
+	d.sets++
+	return x
+}
+
+// Find returns the representative of the set containing x.  It panics if x is out of range.
+func (d *DisjointSet) Find(x int) int {
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	// Compress the path so that every element on it points straight at the root.
+	for d.parent[x] != root {
+		d.parent[x], x = root, d.parent[x]
+	}
+	return root
+}
+
+// Union merges the sets containing x and y.  It returns false if they were already the same set.
+func (d *DisjointSet) Union(x, y int) bool {
+	x, y = d.Find(x), d.Find(y)
+	if x == y {
+		return false
+	}
+	// Hang the shorter tree below the taller, so that trees grow in height only when merging trees of equal rank.
+	switch {
+	case d.rank[x] < d.rank[y]:
+		d.parent[x] = y
+	case d.rank[x] > d.rank[y]:
+		d.parent[y] = x
+	default:
+		d.parent[y] = x
+		d.rank[x]++
+	}
+	d.sets--
+	return true
+}
+
+// Connected reports whether x and y are in the same set.
+func (d *DisjointSet) Connected(x, y int) bool {
+	return d.Find(x) == d.Find(y)
+}
+
+// A Keyed partitions keys into disjoint sets, mapping each key to an element of a DisjointSet.  Keys are added the first
+// time they are passed to Add or Union.  The zero value is an empty Keyed ready to use.
+type Keyed[K comparable] struct {
+	set     DisjointSet
+	indexes map[K]int
+	keys    []K
+}
+
+// NewKeyed returns an empty Keyed.
+func NewKeyed[K comparable]() *Keyed[K] {
+	return &Keyed[K]{indexes: make(map[K]int)}
+}
+
+// Len returns the number of keys in the Keyed.
+func (k *Keyed[K]) Len() int {
+	return len(k.keys)
+}
+
+// SetCount returns the number of disjoint sets.
+func (k *Keyed[K]) SetCount() int {
+	return k.set.SetCount()
+}
+
+// Add adds key in a set of its own.  It returns false if key was already present.
+func (k *Keyed[K]) Add(key K) bool {
+	if _, ok := k.indexes[key]; ok {
+		return false
+	}
+	k.index(key)
+	return true
+}
+
+// Find returns the representative key of the set containing key.  The second return value is false if key is not
+// present.
+func (k *Keyed[K]) Find(key K) (K, bool) {
+	i, ok := k.indexes[key]
+	if !ok {
+		var zero K
+		return zero, false
+	}
+	return k.keys[k.set.Find(i)], true
+}
+
+// Union merges the sets containing a and b, adding either key if it is not present.  It returns false if they were
+// already the same set.
+func (k *Keyed[K]) Union(a, b K) bool {
+	return k.set.Union(k.index(a), k.index(b))
+}
+
+// Connected reports whether a and b are in the same set.  A key that is not present is connected to nothing, not even
+// itself.
+func (k *Keyed[K]) Connected(a, b K) bool {
+	i, ok := k.indexes[a]
+	j, ok2 := k.indexes[b]
+	return ok && ok2 && k.set.Connected(i, j)
+}
+
+// Sets returns the disjoint sets of keys, each with its keys in the order they were added.
+func (k *Keyed[K]) Sets() [][]K {
+	byRoot := make(map[int]int, k.set.SetCount())
+	sets := make([][]K, 0, k.set.SetCount())
+	for i, key := range k.keys {
+		root := k.set.Find(i)
+		j, ok := byRoot[root]
+		if !ok {
+			j = len(sets)
+			byRoot[root] = j
+			sets = append(sets, nil)
+		}
+		sets[j] = append(sets[j], key)
+	}
+	return sets
+}
+
+// index returns the element of key, adding key if it is not present.
+func (k *Keyed[K]) index(key K) int {
+	if i, ok := k.indexes[key]; ok {
+		return i
+	}
+	if k.indexes == nil {
+		k.indexes = make(map[K]int)
+	}
+	i := k.set.Add()
+	k.indexes[key] = i
+	k.keys = append(k.keys, key)
+	return i
+}
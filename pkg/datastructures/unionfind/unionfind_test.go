@@ -0,0 +1,108 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unionfind_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ryandgoulding/godatastructures/pkg/datastructures/unionfind"
+)
+
+func TestDisjointSet(t *testing.T) {
+	d := unionfind.New(10)
+	if d.SetCount() != 10 {
+		t.Fatalf("Expected: %d Actual: %d", 10, d.SetCount())
+	}
+	if !d.Union(1, 2) || !d.Union(3, 4) || !d.Union(2, 4) || d.Union(1, 3) {
+		t.Fatalf("Unexpected Union result")
+	}
+	if !d.Connected(1, 4) || d.Connected(1, 5) {
+		t.Fatalf("Unexpected Connected result")
+	}
+	if d.SetCount() != 7 {
+		t.Fatalf("Expected: %d Actual: %d", 7, d.SetCount())
+	}
+	x := d.Add()
+	if x != 10 || d.Len() != 11 || d.SetCount() != 8 || d.Find(x) != x {
+		t.Fatalf("Expected a new element in a set of its own")
+	}
+}
+
+func TestDisjointSet_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 500
+	d := unionfind.New(n)
+	// A naive reference labels every element with its component.
+	component := make([]int, n)
+	for i := range component {
+		component[i] = i
+	}
+	for i := 0; i < 400; i++ {
+		x, y := r.Intn(n), r.Intn(n)
+		merged := component[x] != component[y]
+		if d.Union(x, y) != merged {
+			t.Fatalf("Union(%d, %d) disagreed with the reference", x, y)
+		}
+		if merged {
+			from, to := component[y], component[x]
+			for j := range component {
+				if component[j] == from {
+					component[j] = to
+				}
+			}
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		x, y := r.Intn(n), r.Intn(n)
+		if d.Connected(x, y) != (component[x] == component[y]) {
+			t.Fatalf("Connected(%d, %d) disagreed with the reference", x, y)
+		}
+	}
+	distinct := map[int]bool{}
+	for _, c := range component {
+		distinct[c] = true
+	}
+	if d.SetCount() != len(distinct) {
+		t.Fatalf("Expected: %d Actual: %d", len(distinct), d.SetCount())
+	}
+}
+
+func TestKeyed(t *testing.T) {
+	var k unionfind.Keyed[string]
+	k.Union("london", "paris")
+	k.Union("paris", "berlin")
+	k.Union("tokyo", "osaka")
+	if !k.Add("lima") || k.Add("lima") {
+		t.Fatalf("Expected to add %s once", "lima")
+	}
+	if k.Len() != 6 || k.SetCount() != 3 {
+		t.Fatalf("Expected: %d keys in %d sets Actual: %d keys in %d sets", 6, 3, k.Len(), k.SetCount())
+	}
+	if !k.Connected("london", "berlin") || k.Connected("london", "tokyo") || k.Connected("nowhere", "nowhere") {
+		t.Fatalf("Unexpected Connected result")
+	}
+	a, _ := k.Find("berlin")
+	b, _ := k.Find("london")
+	if a != b {
+		t.Fatalf("Expected: %s Actual: %s", a, b)
+	}
+	if _, ok := k.Find("nowhere"); ok {
+		t.Fatalf("Expected %s to be absent", "nowhere")
+	}
+	expected := [][]string{{"london", "paris", "berlin"}, {"tokyo", "osaka"}, {"lima"}}
+	if actual := k.Sets(); !slices.EqualFunc(expected, actual, slices.Equal) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+}